@@ -16,6 +16,10 @@ interface ValidatorReportProps {
 interface VerificationResult {
   success: boolean;
   signature?: string;
+  // The nonce, deadline and, when bound, era the oracle's signature commits to
+  nonce?: number;
+  deadline?: number;
+  era?: number;
   message?: string;
   fullResponse?: any;
 }
@@ -79,6 +83,9 @@ export const ValidatorReport: React.FC<ValidatorReportProps> = ({ isWalletConnec
         setVerificationResult({
           success: true,
           signature: result.signature,
+          nonce: result.nonce,
+          deadline: result.deadline,
+          era: result.era,
           message: 'Report verified successfully!',
           fullResponse: result,
         });
@@ -208,6 +215,8 @@ export const ValidatorReport: React.FC<ValidatorReportProps> = ({ isWalletConnec
             { internalType: 'string', name: 'validator_address', type: 'string' },
             { internalType: 'string', name: 'nominator_address', type: 'string' },
             { internalType: 'string', name: 'msgText', type: 'string' },
+            { internalType: 'uint64', name: 'nonce', type: 'uint64' },
+            { internalType: 'uint64', name: 'deadline', type: 'uint64' },
             { internalType: 'bytes', name: 'signature', type: 'bytes' }
           ],
           name: 'submitMessage',
@@ -215,6 +224,21 @@ export const ValidatorReport: React.FC<ValidatorReportProps> = ({ isWalletConnec
           stateMutability: 'nonpayable',
           type: 'function'
         },
+        {
+          inputs: [
+            { internalType: 'string', name: 'validator_address', type: 'string' },
+            { internalType: 'string', name: 'nominator_address', type: 'string' },
+            { internalType: 'string', name: 'msgText', type: 'string' },
+            { internalType: 'uint32', name: 'era', type: 'uint32' },
+            { internalType: 'uint64', name: 'nonce', type: 'uint64' },
+            { internalType: 'uint64', name: 'deadline', type: 'uint64' },
+            { internalType: 'bytes', name: 'signature', type: 'bytes' }
+          ],
+          name: 'submitMessageForEra',
+          outputs: [],
+          stateMutability: 'nonpayable',
+          type: 'function'
+        },
         {
           inputs: [
             { internalType: 'string', name: 'validator_address', type: 'string' },
@@ -358,10 +382,13 @@ export const ValidatorReport: React.FC<ValidatorReportProps> = ({ isWalletConnec
       const contract = new ethers.Contract(contractAddress, contractABI, signer);
 
       const hasVerified = !!verificationResult?.success && !!verificationResult?.signature;
-      const methodName = hasVerified ? 'submitMessage' : 'submitMessageUnverified';
-      const methodArgs = hasVerified
-        ? [report.validatorAddress, walletAddress, report.message, verificationResult!.signature]
-        : [report.validatorAddress, walletAddress, report.message];
+      const eraBound = hasVerified && verificationResult!.era !== undefined;
+      const methodName = !hasVerified ? 'submitMessageUnverified' : eraBound ? 'submitMessageForEra' : 'submitMessage';
+      const methodArgs = !hasVerified
+        ? [report.validatorAddress, walletAddress, report.message]
+        : eraBound
+          ? [report.validatorAddress, walletAddress, report.message, verificationResult!.era, verificationResult!.nonce, verificationResult!.deadline, verificationResult!.signature]
+          : [report.validatorAddress, walletAddress, report.message, verificationResult!.nonce, verificationResult!.deadline, verificationResult!.signature];
 
       // Preflight check to detect reverts and get revert reasons
       try {
@@ -101,6 +101,8 @@ export const Messages: React.FC = () => {
         { "internalType": "string", "name": "validator_address", "type": "string" },
         { "internalType": "string", "name": "nominator_address", "type": "string" },
         { "internalType": "string", "name": "msgText", "type": "string" },
+        { "internalType": "uint64", "name": "nonce", "type": "uint64" },
+        { "internalType": "uint64", "name": "deadline", "type": "uint64" },
         { "internalType": "bytes", "name": "signature", "type": "bytes" }
       ],
       "name": "submitMessage",
@@ -108,6 +110,21 @@ export const Messages: React.FC = () => {
       "stateMutability": "nonpayable",
       "type": "function"
     },
+    {
+      "inputs": [
+        { "internalType": "string", "name": "validator_address", "type": "string" },
+        { "internalType": "string", "name": "nominator_address", "type": "string" },
+        { "internalType": "string", "name": "msgText", "type": "string" },
+        { "internalType": "uint32", "name": "era", "type": "uint32" },
+        { "internalType": "uint64", "name": "nonce", "type": "uint64" },
+        { "internalType": "uint64", "name": "deadline", "type": "uint64" },
+        { "internalType": "bytes", "name": "signature", "type": "bytes" }
+      ],
+      "name": "submitMessageForEra",
+      "outputs": [],
+      "stateMutability": "nonpayable",
+      "type": "function"
+    },
     {
       "inputs": [
         { "internalType": "string", "name": "validator_address", "type": "string" },
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"oracle/pkg/delegation"
+)
+
+// addressNetwork is the network request addresses must belong to; nil accepts any network
+var addressNetwork *delegation.Network
+
+// validateAddress checks that address is a well-formed SS58 address of the configured network,
+// returning the error to report under errorCode otherwise
+func validateAddress(address, field, errorCode string) *ErrorResponse {
+	var err error
+	if addressNetwork != nil {
+		err = addressNetwork.ValidateAddress(address)
+	} else {
+		_, _, err = delegation.DecodeSS58(address)
+	}
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, delegation.ErrWrongNetwork) {
+		return &ErrorResponse{
+			Error:   errorCode,
+			Message: fmt.Sprintf("The %s belongs to a different network: %v", field, err),
+		}
+	}
+	return &ErrorResponse{
+		Error:   errorCode,
+		Message: fmt.Sprintf("The %s is not a valid SS58 address: %v", field, err),
+	}
+}
+
+// validateAddresses checks both addresses of a request before any RPC or signing work
+func validateAddresses(req Request) *ErrorResponse {
+	if errorResp := validateAddress(req.ValidatorAddress, "validator address", "invalid_validator_address"); errorResp != nil {
+		return errorResp
+	}
+	return validateAddress(req.NominatorAddress, "nominator address", "invalid_nominator_address")
+}
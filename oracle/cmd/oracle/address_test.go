@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"testing"
+
+	"oracle/pkg/delegation"
+)
+
+func TestVerifyHandler_ValidatesAddresses(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyHandler_ValidatesAddresses")
+
+	const polkadotAddress = "14E5nqKAp3oAJcmzgZhUD2RcptBeUBScxKHgJKU4HPNcKVf3"
+	const kusamaAddress = "HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F"
+	t.Cleanup(func() { addressNetwork = nil })
+
+	for _, tc := range []struct {
+		name      string
+		network   *delegation.Network
+		validator string
+		nominator string
+		expected  string
+	}{
+		{"valid generic addresses", nil, selfTestValidator, selfTestNominator, ""},
+		{"valid addresses of the configured network", &delegation.Substrate, selfTestValidator, selfTestNominator, ""},
+		{"garbage validator", nil, "not-an-address", selfTestNominator, "invalid_validator_address"},
+		{"garbage nominator", nil, selfTestValidator, "not-an-address", "invalid_nominator_address"},
+		{"bad checksum", nil, selfTestValidator, selfTestNominator[:len(selfTestNominator)-1] + "z", "invalid_nominator_address"},
+		{"truncated", nil, selfTestValidator[:20], selfTestNominator, "invalid_validator_address"},
+		{"hex public key", nil, selfTestValidator, "0xdeadbeef", "invalid_nominator_address"},
+		{"validator on another network", &delegation.Polkadot, selfTestValidator, polkadotAddress, "invalid_validator_address"},
+		{"nominator on another network", &delegation.Polkadot, polkadotAddress, selfTestNominator, "invalid_nominator_address"},
+		{"Kusama addresses on Kusama", &delegation.Kusama, kusamaAddress, kusamaAddress, ""},
+		{"Polkadot nominator on Kusama", &delegation.Kusama, kusamaAddress, polkadotAddress, "invalid_nominator_address"},
+		{"Kusama validator on Polkadot", &delegation.Polkadot, kusamaAddress, polkadotAddress, "invalid_validator_address"},
+	} {
+		addressNetwork = tc.network
+		signer := newCheckedSigningOracle(t, fakeChecker{delegated: true})
+		rec := postVerify(t, VerifyHandler(signer, nil, ""), "/verify", Request{
+			ValidatorAddress: tc.validator,
+			NominatorAddress: tc.nominator,
+			Msg:              "hello",
+		})
+
+		if tc.expected == "" {
+			if rec.Code != http.StatusOK {
+				t.Fatalf("%s: expected 200, got %d: %s", tc.name, rec.Code, rec.Body.String())
+			}
+			log.Printf("✅ %s accepted", tc.name)
+			continue
+		}
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", tc.name, rec.Code)
+		}
+		var errorResp ErrorResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &errorResp); err != nil {
+			t.Fatalf("%s: failed to decode error response: %v", tc.name, err)
+		}
+		if errorResp.Error != tc.expected {
+			t.Fatalf("%s: expected error %s, got %s", tc.name, tc.expected, errorResp.Error)
+		}
+		if signer.LastNonce(tc.nominator) != 0 {
+			t.Fatalf("%s: expected no signing for an invalid address", tc.name)
+		}
+		log.Printf("✅ %s rejected: %s", tc.name, errorResp.Message)
+	}
+}
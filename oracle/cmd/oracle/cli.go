@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"oracle/pkg/delegation"
+	signatureverifier "oracle/pkg/signature_verifier"
+)
+
+// Exit codes of the CLI subcommands
+const (
+	exitOK     = 0
+	exitFailed = 1 // the delegation isn't valid or the command failed
+	exitUsage  = 2 // the arguments are invalid, as with the flag package
+)
+
+// runSubcommand runs the CLI subcommand named by args[0] and returns its exit code.
+// ok is false when args name no subcommand, in which case main starts the server.
+func runSubcommand(args []string, stdout, stderr io.Writer) (code int, ok bool) {
+	if len(args) == 0 {
+		return 0, false
+	}
+
+	switch args[0] {
+	case "verify":
+		return runVerifyCommand(args[1:], stdout, stderr), true
+	case "sign":
+		return runSignCommand(args[1:], stdout, stderr), true
+	default:
+		return 0, false
+	}
+}
+
+// runVerifyCommand verifies a delegation with VerifyV2 and prints the result as JSON:
+//
+//	oracle verify --nominator ADDRESS --validator ADDRESS [--rpc URL] [--chain NAME] [--timeout 10s]
+//
+// The RPC URL and chain default to POLKADOT_RPC_URL and CHAIN, like the server.
+func runVerifyCommand(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("verify", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	nominator := flags.String("nominator", "", "nominator address, SS58 or 0x-prefixed hex AccountId")
+	validator := flags.String("validator", "", "validator address")
+	rpcURL := flags.String("rpc", os.Getenv("POLKADOT_RPC_URL"), "RPC endpoint, or comma-separated endpoints to fail over between; defaults to the chain's public endpoint")
+	chain := flags.String("chain", os.Getenv("CHAIN"), "chain to verify on: polkadot, kusama or substrate")
+	timeout := flags.Duration("timeout", delegation.DefaultRPCTimeout, "timeout of each RPC call")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *nominator == "" || *validator == "" {
+		fmt.Fprintln(stderr, "verify: --nominator and --validator are required")
+		flags.Usage()
+		return exitUsage
+	}
+
+	network := delegation.Polkadot
+	if *chain != "" {
+		var err error
+		network, err = delegation.NetworkByName(*chain)
+		if err != nil {
+			fmt.Fprintf(stderr, "verify: %v\n", err)
+			return exitUsage
+		}
+	}
+
+	verifier := delegation.NewVerifierWithConfig(delegation.VerifierConfig{
+		RPCURL:         *rpcURL,
+		Timeout:        *timeout,
+		Network:        network,
+		ResultCacheTTL: -1,
+	})
+	defer verifier.Close()
+
+	result, err := verifier.VerifyV2(*nominator, *validator)
+	if err != nil {
+		fmt.Fprintf(stderr, "verify: %v\n", err)
+		return exitFailed
+	}
+
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		fmt.Fprintf(stderr, "verify: failed to write result: %v\n", err)
+		return exitFailed
+	}
+
+	if !result.IsValid {
+		return exitFailed
+	}
+	return exitOK
+}
+
+// SignOutput is what the sign subcommand prints
+type SignOutput struct {
+	Signature     string `json:"signature"`
+	Address       string `json:"address"`
+	OracleAddress string `json:"oracle_address"`
+}
+
+// runSignCommand signs a triplet with the oracle key, without verifying the delegation, and
+// prints the signature with the signer recovered from it:
+//
+//	oracle sign --validator ADDRESS --nominator ADDRESS --msg TEXT
+//
+// The key is loaded like the server's, from KEYSTORE_FILE, PRIVATE_KEY_FILE or PRIVATE_KEY.
+func runSignCommand(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("sign", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	validator := flags.String("validator", "", "validator address")
+	nominator := flags.String("nominator", "", "nominator address")
+	msg := flags.String("msg", "", "message to sign with the addresses")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *validator == "" || *nominator == "" || *msg == "" {
+		fmt.Fprintln(stderr, "sign: --validator, --nominator and --msg are required")
+		flags.Usage()
+		return exitUsage
+	}
+
+	oracle, err := loadSigningOracle()
+	if err != nil {
+		fmt.Fprintf(stderr, "sign: failed to load the signing key: %v\n", err)
+		return exitFailed
+	}
+
+	signature, err := oracle.SignTriplet(*validator, *nominator, *msg)
+	if err != nil {
+		fmt.Fprintf(stderr, "sign: %v\n", err)
+		return exitFailed
+	}
+	signature[64] += 27 // canonical v byte, as ecrecover expects
+	signatureHex := "0x" + hex.EncodeToString(signature)
+
+	verifier, err := newTripletVerifier(oracle)
+	if err != nil {
+		fmt.Fprintf(stderr, "sign: %v\n", err)
+		return exitFailed
+	}
+	address, err := verifier.RecoverSigner(*validator, *nominator, *msg, signatureverifier.SignedFields{}, signatureHex)
+	if err != nil {
+		fmt.Fprintf(stderr, "sign: failed to recover the signer: %v\n", err)
+		return exitFailed
+	}
+
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(SignOutput{
+		Signature:     signatureHex,
+		Address:       address.Hex(),
+		OracleAddress: verifier.GetOracleAddress().Hex(),
+	}); err != nil {
+		fmt.Fprintf(stderr, "sign: failed to write result: %v\n", err)
+		return exitFailed
+	}
+	return exitOK
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"oracle/pkg/delegation"
+)
+
+// Storage prefixes of the Staking.Bonded and Staking.Ledger maps, twox128("Staking") ++ twox128(item)
+const (
+	bondedStoragePrefix = "0x5f3e4907f716ac89b6347d15ececedca3ed14b45ed20d054f05e37e2542cfe70"
+	ledgerStoragePrefix = "0x5f3e4907f716ac89b6347d15ececedca422adb579f1dbf4f3886c5cfa3bb8cc4"
+)
+
+// newStakingRPCServer answers JSON-RPC calls, single or batched, for a chain in active era 10
+// where every account is a bonded stash of 1,000,000 planck nominating target since era 9
+func newStakingRPCServer(t *testing.T, target []byte) *httptest.Server {
+	t.Helper()
+
+	nominations := append([]byte{1 << 2}, target...)
+	nominations = binary.LittleEndian.AppendUint32(nominations, 9)
+	nominations = append(nominations, 0)
+
+	activeEra := binary.LittleEndian.AppendUint32(nil, 10)
+	activeEra = append(activeEra, 1)
+	activeEra = binary.LittleEndian.AppendUint64(activeEra, 1700000000000)
+
+	answer := func(request map[string]interface{}) map[string]interface{} {
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": request["id"]}
+		switch request["method"] {
+		case "chain_getFinalizedHead":
+			response["result"] = "0x" + hex.EncodeToString(bytes.Repeat([]byte{0xab}, 32))
+		case "state_getStorage":
+			// Staking.ActiveEra is a plain 32-byte key; map entries such as Staking.Nominators are longer
+			// and end with the account ID they are keyed by
+			key, _ := request["params"].([]interface{})[0].(string)
+			accountID := key[max(len(key)-64, 0):]
+			switch {
+			case len(key) == 66:
+				response["result"] = "0x" + hex.EncodeToString(activeEra)
+			case strings.HasPrefix(key, bondedStoragePrefix):
+				response["result"] = "0x" + accountID
+			case strings.HasPrefix(key, ledgerStoragePrefix):
+				// stash, then total and active as compact integers, no unlocking chunks or claimed rewards
+				bond := hex.EncodeToString(binary.LittleEndian.AppendUint32(nil, 1_000_000<<2|0b10))
+				response["result"] = "0x" + accountID + bond + bond + "0000"
+			default:
+				response["result"] = "0x" + hex.EncodeToString(nominations)
+			}
+		default:
+			response["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+		}
+		return response
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+
+		var batch []map[string]interface{}
+		if err := json.Unmarshal(body, &batch); err == nil {
+			responses := make([]map[string]interface{}, 0, len(batch))
+			for _, request := range batch {
+				responses = append(responses, answer(request))
+			}
+			json.NewEncoder(w).Encode(responses)
+			return
+		}
+
+		var request map[string]interface{}
+		json.Unmarshal(body, &request)
+		json.NewEncoder(w).Encode(answer(request))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRunVerifyCommand(t *testing.T) {
+	log.Printf("🧪 Starting TestRunVerifyCommand")
+
+	validatorID, _, _ := delegation.DecodeSS58(selfTestValidator)
+	server := newStakingRPCServer(t, validatorID)
+
+	cases := []struct {
+		name      string
+		args      []string
+		wantCode  int
+		wantValid bool
+	}{
+		{"nominated validator", []string{"verify", "--nominator", selfTestNominator, "--validator", selfTestValidator, "--rpc", server.URL, "--chain", "substrate"}, exitOK, true},
+		{"validator not nominated", []string{"verify", "--nominator", selfTestNominator, "--validator", "5FLSigC9HGRKVhB9FiEo4Y3koPsNmBmLJbpXg2mp1hXcS59Y", "--rpc", server.URL, "--chain", "substrate"}, exitFailed, false},
+		{"bad nominator", []string{"verify", "--nominator", "not-an-address", "--validator", selfTestValidator, "--rpc", server.URL, "--chain", "substrate"}, exitFailed, false},
+		{"nominator of another network", []string{"verify", "--nominator", selfTestNominator, "--validator", selfTestValidator, "--rpc", server.URL}, exitFailed, false},
+	}
+
+	for _, tc := range cases {
+		var stdout, stderr bytes.Buffer
+		code, ok := runSubcommand(tc.args, &stdout, &stderr)
+		if !ok {
+			t.Fatalf("%s: expected verify to be recognized as a subcommand", tc.name)
+		}
+		if code != tc.wantCode {
+			t.Fatalf("%s: expected exit code %d, got %d (stderr: %s)", tc.name, tc.wantCode, code, stderr.String())
+		}
+
+		var result delegation.DelegationVerificationResult
+		if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+			t.Fatalf("%s: expected a JSON result on stdout, got %q: %v", tc.name, stdout.String(), err)
+		}
+		if result.IsValid != tc.wantValid {
+			t.Fatalf("%s: expected isValid %v, got %+v", tc.name, tc.wantValid, result)
+		}
+		log.Printf("✅ %s: exit code %d, isValid %v", tc.name, code, result.IsValid)
+	}
+}
+
+func TestRunSubcommand_Usage(t *testing.T) {
+	log.Printf("🧪 Starting TestRunSubcommand_Usage")
+
+	if _, ok := runSubcommand(nil, io.Discard, io.Discard); ok {
+		t.Fatalf("Expected no subcommand without arguments, so the server starts")
+	}
+	log.Printf("✅ No arguments starts the server")
+
+	for name, args := range map[string][]string{
+		"missing validator": {"verify", "--nominator", selfTestNominator},
+		"unknown chain":     {"verify", "--nominator", selfTestNominator, "--validator", selfTestValidator, "--chain", "westend"},
+		"unknown flag":      {"verify", "--bogus"},
+	} {
+		var stderr bytes.Buffer
+		code, _ := runSubcommand(args, io.Discard, &stderr)
+		if code != exitUsage || stderr.Len() == 0 {
+			t.Fatalf("%s: expected exit code %d with a message, got %d: %q", name, exitUsage, code, stderr.String())
+		}
+		log.Printf("✅ %s rejected with exit code %d", name, code)
+	}
+}
+
+func TestRunSignCommand(t *testing.T) {
+	log.Printf("🧪 Starting TestRunSignCommand")
+
+	oracleAddress := newTestSigningOracle(t).GetAddress()
+	t.Setenv("KEYSTORE_FILE", "")
+	t.Setenv("PRIVATE_KEY", "1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")
+
+	var stdout, stderr bytes.Buffer
+	code, ok := runSubcommand([]string{"sign", "--validator", selfTestValidator, "--nominator", selfTestNominator, "--msg", "hello"}, &stdout, &stderr)
+	if !ok || code != exitOK {
+		t.Fatalf("Expected sign to succeed, got %d (stderr: %s)", code, stderr.String())
+	}
+
+	var output SignOutput
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		t.Fatalf("Expected a JSON result on stdout, got %q: %v", stdout.String(), err)
+	}
+	if !strings.HasPrefix(output.Signature, "0x") || len(output.Signature) != 2+65*2 {
+		t.Fatalf("Expected a 0x-prefixed 65-byte signature, got %q", output.Signature)
+	}
+	if v := output.Signature[len(output.Signature)-2:]; v != "1b" && v != "1c" {
+		t.Fatalf("Expected a canonical v byte of 27 or 28, got 0x%s", v)
+	}
+	if !strings.EqualFold(output.Address, oracleAddress) || !strings.EqualFold(output.OracleAddress, oracleAddress) {
+		t.Fatalf("Expected the signature to recover to the oracle %s, got %+v", oracleAddress, output)
+	}
+	log.Printf("✅ Signed and recovered: %+v", output)
+}
+
+func TestRunSignCommand_MissingKey(t *testing.T) {
+	log.Printf("🧪 Starting TestRunSignCommand_MissingKey")
+
+	t.Setenv("KEYSTORE_FILE", "")
+	t.Setenv("PRIVATE_KEY", "")
+
+	var stdout, stderr bytes.Buffer
+	code, _ := runSubcommand([]string{"sign", "--validator", selfTestValidator, "--nominator", selfTestNominator, "--msg", "hello"}, &stdout, &stderr)
+	if code != exitFailed || stdout.Len() != 0 {
+		t.Fatalf("Expected exit code %d and no output, got %d: %q", exitFailed, code, stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "PRIVATE_KEY") {
+		t.Fatalf("Expected the error to name PRIVATE_KEY, got %q", stderr.String())
+	}
+	log.Printf("✅ Missing key reported: %s", strings.TrimSpace(stderr.String()))
+}
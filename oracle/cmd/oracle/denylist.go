@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"oracle/pkg/delegation"
+)
+
+// DenyList holds SS58 addresses the oracle refuses to sign for, loaded from a file with one
+// address per line. Blank lines and lines starting with '#' are ignored. Entries are matched
+// by AccountId, so the same account listed under any network prefix is denied.
+type DenyList struct {
+	mu       sync.RWMutex
+	path     string
+	accounts map[string]struct{}
+}
+
+// LoadDenyList reads a deny list from path
+func LoadDenyList(path string) (*DenyList, error) {
+	d := &DenyList{path: path}
+	if err := d.Reload(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Reload re-reads the deny list file, keeping the previous entries if the file is invalid
+func (d *DenyList) Reload() error {
+	file, err := os.Open(d.path)
+	if err != nil {
+		return fmt.Errorf("failed to open deny list: %w", err)
+	}
+	defer file.Close()
+
+	accounts := make(map[string]struct{})
+	scanner := bufio.NewScanner(file)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		accountID, _, err := delegation.DecodeSS58(line)
+		if err != nil {
+			return fmt.Errorf("invalid deny list entry on line %d: %w", lineNum, err)
+		}
+		accounts[hex.EncodeToString(accountID)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read deny list: %w", err)
+	}
+
+	d.mu.Lock()
+	d.accounts = accounts
+	d.mu.Unlock()
+
+	slog.Info("deny list loaded", "event", "denylist_loaded", "entries", len(accounts), "path", d.path)
+	return nil
+}
+
+// Contains reports whether the address belongs to a denied account. A nil list denies nothing.
+func (d *DenyList) Contains(address string) bool {
+	if d == nil {
+		return false
+	}
+
+	accountID, _, err := delegation.DecodeSS58(address)
+	if err != nil {
+		return false
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	_, denied := d.accounts[hex.EncodeToString(accountID)]
+	return denied
+}
+
+// Len returns the number of denied accounts
+func (d *DenyList) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.accounts)
+}
+
+// AdminReloadHandler handles POST /admin/reload, re-reading reloadable configuration. The
+// request must carry adminToken as a bearer token, compared by SHA-256 digest in constant time.
+// An empty adminToken rejects every request; the route isn't registered without ADMIN_TOKEN.
+func AdminReloadHandler(denyList *DenyList, adminToken string) http.HandlerFunc {
+	digest := sha256.Sum256([]byte(adminToken))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		presented, hasBearer := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		presentedDigest := sha256.Sum256([]byte(presented))
+		if adminToken == "" || !hasBearer || subtle.ConstantTimeCompare(presentedDigest[:], digest[:]) != 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(ErrorResponse{
+				Error:   "unauthorized",
+				Message: "A valid admin token is required",
+			})
+			return
+		}
+
+		status := map[string]interface{}{"status": "reloaded"}
+		if denyList != nil {
+			if err := denyList.Reload(); err != nil {
+				slog.Error("failed to reload deny list", "event", "denylist_reload_failed", "error", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(ErrorResponse{
+					Error:   "reload_failed",
+					Message: fmt.Sprintf("Failed to reload deny list: %v", err),
+				})
+				return
+			}
+			status["denylist_entries"] = denyList.Len()
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(status)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Bob's account under the Polkadot (0) prefix; the same account as selfTestNominator
+const bobPolkadotAddress = "14E5nqKAp3oAJcmzgZhUD2RcptBeUBScxKHgJKU4HPNcKVf3"
+
+func writeDenyListFile(t *testing.T, path string, contents string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("Failed to write deny list: %v", err)
+	}
+}
+
+func TestVerifyHandler_DenyList(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyHandler_DenyList")
+
+	path := filepath.Join(t.TempDir(), "denylist.txt")
+	writeDenyListFile(t, path, "# sanctioned accounts\n\n"+bobPolkadotAddress+"\n")
+
+	denyList, err := LoadDenyList(path)
+	if err != nil {
+		t.Fatalf("Failed to load deny list: %v", err)
+	}
+
+	handler := VerifyHandler(newCheckedSigningOracle(t, fakeChecker{delegated: true}), denyList, "")
+
+	// Bob is listed under the Polkadot prefix and queried under the generic Substrate prefix
+	rec := postVerify(t, handler, "/verify", testVerifyRequest)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("Expected 403 for a listed nominator, got %d", rec.Code)
+	}
+	var errResp ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &errResp); err != nil {
+		t.Fatalf("Failed to decode error response: %v", err)
+	}
+	if errResp.Error != "address_denied" {
+		t.Errorf("Expected address_denied, got %s", errResp.Error)
+	}
+	log.Printf("✅ Listed nominator refused with %s", errResp.Error)
+
+	unlisted := testVerifyRequest
+	unlisted.NominatorAddress = selfTestValidator
+	unlisted.ValidatorAddress = "5FLSigC9HGRKVhB9FiEo4Y3koPsNmBmLJbpXg2mp1hXcS59Y"
+	if rec := postVerify(t, handler, "/verify", unlisted); rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 for unlisted addresses, got %d: %s", rec.Code, rec.Body.String())
+	}
+	log.Printf("✅ Unlisted addresses signed")
+}
+
+func TestAdminReloadHandler_ReloadsDenyList(t *testing.T) {
+	log.Printf("🧪 Starting TestAdminReloadHandler_ReloadsDenyList")
+
+	path := filepath.Join(t.TempDir(), "denylist.txt")
+	writeDenyListFile(t, path, "")
+
+	denyList, err := LoadDenyList(path)
+	if err != nil {
+		t.Fatalf("Failed to load deny list: %v", err)
+	}
+	if denyList.Contains(selfTestValidator) {
+		t.Fatalf("Expected empty deny list")
+	}
+
+	writeDenyListFile(t, path, selfTestValidator+"\n")
+	reload := AdminReloadHandler(denyList, "secret")
+
+	rec := httptest.NewRecorder()
+	reload.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/reload", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 without the admin token, got %d", rec.Code)
+	}
+	for _, header := range []string{"Bearer wrong", "Bearer secretsecret", "secret"} {
+		req := httptest.NewRequest(http.MethodPost, "/admin/reload", nil)
+		req.Header.Set("Authorization", header)
+		rec = httptest.NewRecorder()
+		reload.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("Expected 401 for Authorization %q, got %d", header, rec.Code)
+		}
+	}
+	log.Printf("✅ Missing and wrong admin tokens rejected")
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/reload", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	reload.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !denyList.Contains(selfTestValidator) {
+		t.Fatalf("Expected reloaded deny list to contain the new entry")
+	}
+	log.Printf("✅ Deny list reloaded: %s", rec.Body.String())
+
+	// An invalid file keeps the previous entries
+	writeDenyListFile(t, path, "not-an-address\n")
+	if err := denyList.Reload(); err == nil {
+		t.Fatalf("Expected error reloading an invalid deny list")
+	}
+	if !denyList.Contains(selfTestValidator) {
+		t.Fatalf("Expected previous entries to survive a failed reload")
+	}
+	log.Printf("✅ Invalid deny list rejected without dropping entries")
+}
+
+func TestAdminReloadHandler_NoTokenConfigured(t *testing.T) {
+	log.Printf("🧪 Starting TestAdminReloadHandler_NoTokenConfigured")
+
+	reload := AdminReloadHandler(nil, "")
+	for _, header := range []string{"", "Bearer ", "Bearer anything"} {
+		req := httptest.NewRequest(http.MethodPost, "/admin/reload", nil)
+		req.Header.Set("Authorization", header)
+		rec := httptest.NewRecorder()
+		reload.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("Expected 401 for Authorization %q without a configured token, got %d", header, rec.Code)
+		}
+	}
+	log.Printf("✅ Reload refused while no admin token is configured")
+
+	stub := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	r := newRouter(routeHandlers{Verify: stub, VerifyBatch: stub})
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/reload", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("Expected /admin/reload not to be routed without a handler, got %d", rec.Code)
+	}
+	log.Printf("✅ /admin/reload not routed without a handler")
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"oracle/pkg/delegation"
+)
+
+// Media types the verify response can be encoded as
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeMsgpack  = "application/x-msgpack"
+	ContentTypeProtobuf = "application/protobuf"
+)
+
+// ResponseEncoder marshals a verify Response in one wire format
+type ResponseEncoder interface {
+	ContentType() string
+	Encode(w io.Writer, resp Response) error
+}
+
+type jsonResponseEncoder struct{}
+
+func (jsonResponseEncoder) ContentType() string { return ContentTypeJSON }
+
+func (jsonResponseEncoder) Encode(w io.Writer, resp Response) error {
+	return json.NewEncoder(w).Encode(resp)
+}
+
+type msgpackResponseEncoder struct{}
+
+func (msgpackResponseEncoder) ContentType() string { return ContentTypeMsgpack }
+
+func (msgpackResponseEncoder) Encode(w io.Writer, resp Response) error {
+	return msgpack.NewEncoder(w).Encode(resp)
+}
+
+type protobufResponseEncoder struct{}
+
+func (protobufResponseEncoder) ContentType() string { return ContentTypeProtobuf }
+
+func (protobufResponseEncoder) Encode(w io.Writer, resp Response) error {
+	encoded, err := resp.MarshalProto()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(encoded)
+	return err
+}
+
+// responseEncoders lists the supported encoders; the first is the default
+var responseEncoders = []ResponseEncoder{
+	jsonResponseEncoder{},
+	msgpackResponseEncoder{},
+	protobufResponseEncoder{},
+}
+
+// negotiateResponseEncoder picks the first supported media type listed in an Accept header,
+// falling back to JSON when the header is empty, a wildcard, or names nothing supported
+func negotiateResponseEncoder(accept string) ResponseEncoder {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		for _, encoder := range responseEncoders {
+			if encoder.ContentType() == mediaType {
+				return encoder
+			}
+		}
+	}
+	return responseEncoders[0]
+}
+
+// Protobuf field numbers of the Response message. The verification sub-checks and the transcript
+// are carried as their JSON encoding, as served by /verify, rather than mirrored as messages:
+//
+//	message Response {
+//	  string validator_address = 1;
+//	  string nominator_address = 2;
+//	  string msg = 3;
+//	  string signature = 4;
+//	  string attestation = 5;
+//	  optional uint32 era = 6;
+//	  uint64 nonce = 7;
+//	  int64 deadline = 8;
+//	  string block_hash = 9;
+//	  string block_signature = 10;
+//	  bool dry_run = 11;
+//	  bytes verification_json = 12;
+//	  bytes transcript_json = 13;
+//	}
+const (
+	protoFieldValidatorAddress protowire.Number = 1
+	protoFieldNominatorAddress protowire.Number = 2
+	protoFieldMsg              protowire.Number = 3
+	protoFieldSignature        protowire.Number = 4
+	protoFieldAttestation      protowire.Number = 5
+	protoFieldEra              protowire.Number = 6
+	protoFieldNonce            protowire.Number = 7
+	protoFieldDeadline         protowire.Number = 8
+	protoFieldBlockHash        protowire.Number = 9
+	protoFieldBlockSignature   protowire.Number = 10
+	protoFieldDryRun           protowire.Number = 11
+	protoFieldVerification     protowire.Number = 12
+	protoFieldTranscript       protowire.Number = 13
+)
+
+// MarshalProto encodes the response as the protobuf Response message. It fails only if the
+// verification or transcript can't be encoded as JSON.
+func (r Response) MarshalProto() ([]byte, error) {
+	var b []byte
+	appendString := func(num protowire.Number, value string) {
+		if value == "" {
+			return
+		}
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		b = protowire.AppendString(b, value)
+	}
+
+	appendString(protoFieldValidatorAddress, r.ValidatorAddress)
+	appendString(protoFieldNominatorAddress, r.NominatorAddress)
+	appendString(protoFieldMsg, r.Msg)
+	appendString(protoFieldSignature, r.Signature)
+	appendString(protoFieldAttestation, r.Attestation)
+	if r.Era != nil {
+		b = protowire.AppendTag(b, protoFieldEra, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(*r.Era))
+	}
+	if r.Nonce != 0 {
+		b = protowire.AppendTag(b, protoFieldNonce, protowire.VarintType)
+		b = protowire.AppendVarint(b, r.Nonce)
+	}
+	if r.Deadline != 0 {
+		b = protowire.AppendTag(b, protoFieldDeadline, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(r.Deadline))
+	}
+	appendString(protoFieldBlockHash, r.BlockHash)
+	appendString(protoFieldBlockSignature, r.BlockSignature)
+	if r.DryRun {
+		b = protowire.AppendTag(b, protoFieldDryRun, protowire.VarintType)
+		b = protowire.AppendVarint(b, protowire.EncodeBool(true))
+	}
+	if r.Verification != nil {
+		encoded, err := json.Marshal(r.Verification)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode verification: %w", err)
+		}
+		b = protowire.AppendTag(b, protoFieldVerification, protowire.BytesType)
+		b = protowire.AppendBytes(b, encoded)
+	}
+	if r.Transcript != nil {
+		encoded, err := json.Marshal(r.Transcript)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode transcript: %w", err)
+		}
+		b = protowire.AppendTag(b, protoFieldTranscript, protowire.BytesType)
+		b = protowire.AppendBytes(b, encoded)
+	}
+	return b, nil
+}
+
+// UnmarshalResponseProto decodes a protobuf Response message, skipping unknown fields
+func UnmarshalResponseProto(b []byte) (Response, error) {
+	var r Response
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return Response{}, fmt.Errorf("invalid protobuf tag: %w", protowire.ParseError(n))
+		}
+		b = b[n:]
+
+		switch {
+		case typ == protowire.BytesType && (num >= protoFieldValidatorAddress && num <= protoFieldAttestation || num == protoFieldBlockHash || num == protoFieldBlockSignature):
+			value, n := protowire.ConsumeString(b)
+			if n < 0 {
+				return Response{}, fmt.Errorf("invalid protobuf field %d: %w", num, protowire.ParseError(n))
+			}
+			b = b[n:]
+			switch num {
+			case protoFieldValidatorAddress:
+				r.ValidatorAddress = value
+			case protoFieldNominatorAddress:
+				r.NominatorAddress = value
+			case protoFieldMsg:
+				r.Msg = value
+			case protoFieldSignature:
+				r.Signature = value
+			case protoFieldAttestation:
+				r.Attestation = value
+			case protoFieldBlockHash:
+				r.BlockHash = value
+			case protoFieldBlockSignature:
+				r.BlockSignature = value
+			}
+		case typ == protowire.VarintType && num == protoFieldEra:
+			value, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return Response{}, fmt.Errorf("invalid protobuf field %d: %w", num, protowire.ParseError(n))
+			}
+			b = b[n:]
+			era := uint32(value)
+			r.Era = &era
+		case typ == protowire.VarintType && num == protoFieldNonce:
+			value, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return Response{}, fmt.Errorf("invalid protobuf field %d: %w", num, protowire.ParseError(n))
+			}
+			b = b[n:]
+			r.Nonce = value
+		case typ == protowire.VarintType && num == protoFieldDeadline:
+			value, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return Response{}, fmt.Errorf("invalid protobuf field %d: %w", num, protowire.ParseError(n))
+			}
+			b = b[n:]
+			r.Deadline = int64(value)
+		case typ == protowire.VarintType && num == protoFieldDryRun:
+			value, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return Response{}, fmt.Errorf("invalid protobuf field %d: %w", num, protowire.ParseError(n))
+			}
+			b = b[n:]
+			r.DryRun = protowire.DecodeBool(value)
+		case typ == protowire.BytesType && num == protoFieldVerification:
+			value, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return Response{}, fmt.Errorf("invalid protobuf field %d: %w", num, protowire.ParseError(n))
+			}
+			b = b[n:]
+			r.Verification = &delegation.VerificationResult{}
+			if err := json.Unmarshal(value, r.Verification); err != nil {
+				return Response{}, fmt.Errorf("invalid verification in protobuf field %d: %w", num, err)
+			}
+		case typ == protowire.BytesType && num == protoFieldTranscript:
+			value, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return Response{}, fmt.Errorf("invalid protobuf field %d: %w", num, protowire.ParseError(n))
+			}
+			b = b[n:]
+			r.Transcript = delegation.NewTranscript()
+			if err := json.Unmarshal(value, r.Transcript); err != nil {
+				return Response{}, fmt.Errorf("invalid transcript in protobuf field %d: %w", num, err)
+			}
+		default:
+			n := protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return Response{}, fmt.Errorf("invalid protobuf field %d: %w", num, protowire.ParseError(n))
+			}
+			b = b[n:]
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestVerifyHandler_ContentNegotiation(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyHandler_ContentNegotiation")
+
+	handler := VerifyHandler(newCheckedSigningOracle(t, fakeChecker{delegated: true, era: 1523}), nil, "")
+
+	body, _ := json.Marshal(testVerifyRequest)
+	cases := []struct {
+		accept      string
+		contentType string
+		decode      func([]byte) (Response, error)
+	}{
+		{"", ContentTypeJSON, func(b []byte) (Response, error) {
+			var resp Response
+			err := json.Unmarshal(b, &resp)
+			return resp, err
+		}},
+		{"application/x-msgpack", ContentTypeMsgpack, func(b []byte) (Response, error) {
+			var resp Response
+			err := msgpack.Unmarshal(b, &resp)
+			return resp, err
+		}},
+		{"application/protobuf;q=1.0, application/json;q=0.5", ContentTypeProtobuf, UnmarshalResponseProto},
+		{"text/html, */*", ContentTypeJSON, func(b []byte) (Response, error) {
+			var resp Response
+			err := json.Unmarshal(b, &resp)
+			return resp, err
+		}},
+	}
+
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodPost, "/verify?bind_era=true", strings.NewReader(string(body)))
+		if tc.accept != "" {
+			req.Header.Set("Accept", tc.accept)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Accept %q: expected 200, got %d", tc.accept, rec.Code)
+		}
+		if got := rec.Header().Get("Content-Type"); got != tc.contentType {
+			t.Errorf("Accept %q: expected Content-Type %s, got %s", tc.accept, tc.contentType, got)
+		}
+
+		resp, err := tc.decode(rec.Body.Bytes())
+		if err != nil {
+			t.Fatalf("Accept %q: failed to decode response: %v", tc.accept, err)
+		}
+		if len(resp.Signature) != len("0x")+2*65 || resp.NominatorAddress != testVerifyRequest.NominatorAddress ||
+			resp.Msg != testVerifyRequest.Msg || resp.Era == nil || *resp.Era != 1523 || resp.Nonce == 0 || resp.Deadline == 0 {
+			t.Errorf("Accept %q: response did not round-trip: %+v", tc.accept, resp)
+		}
+		log.Printf("✅ %s response round-tripped (%d bytes)", tc.contentType, rec.Body.Len())
+	}
+}
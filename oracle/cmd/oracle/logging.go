@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+
+	"oracle/pkg/delegation"
+)
+
+// newLogger builds the service logger from LOG_LEVEL (debug, info, warn or error; default info)
+// and LOG_FORMAT (json or text; default json). Text mode is meant for local development.
+// Lines logged under a request's context carry its request_id.
+func newLogger(w io.Writer, level, format string) (*slog.Logger, error) {
+	var slogLevel slog.Level
+	switch strings.ToLower(level) {
+	case "debug":
+		slogLevel = slog.LevelDebug
+	case "", "info":
+		slogLevel = slog.LevelInfo
+	case "warn":
+		slogLevel = slog.LevelWarn
+	case "error":
+		slogLevel = slog.LevelError
+	default:
+		return nil, fmt.Errorf("invalid LOG_LEVEL: %s", level)
+	}
+
+	options := &slog.HandlerOptions{Level: slogLevel}
+	switch strings.ToLower(format) {
+	case "", "json":
+		return slog.New(delegation.NewRequestIDHandler(slog.NewJSONHandler(w, options))), nil
+	case "text":
+		return slog.New(delegation.NewRequestIDHandler(slog.NewTextHandler(w, options))), nil
+	default:
+		return nil, fmt.Errorf("invalid LOG_FORMAT: %s", format)
+	}
+}
+
+// fatal logs msg at error level and exits non-zero
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
+// statusRecorder remembers the status code written through it, for request logs
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"oracle/pkg/delegation"
+)
+
+func TestNewLogger(t *testing.T) {
+	log.Printf("🧪 Starting TestNewLogger")
+
+	// JSON is the default format and info the default level
+	var buf bytes.Buffer
+	logger, err := newLogger(&buf, "", "")
+	if err != nil {
+		t.Fatalf("Expected default logger, got: %v", err)
+	}
+	logger.Debug("hidden", "event", "debug_event")
+	logger.Info("verify request handled", "event", "verify_request", "nominator", "alice", "duration_ms", 12)
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected a single JSON log line, got %q: %v", buf.String(), err)
+	}
+	if entry["event"] != "verify_request" || entry["nominator"] != "alice" || entry["duration_ms"] != float64(12) {
+		t.Errorf("Unexpected log entry: %v", entry)
+	}
+	log.Printf("✅ Default logger emits JSON at info level")
+
+	// Text mode with debug enabled
+	buf.Reset()
+	logger, err = newLogger(&buf, "DEBUG", "text")
+	if err != nil {
+		t.Fatalf("Expected text logger, got: %v", err)
+	}
+	logger.Debug("shown", "event", "debug_event")
+	if !strings.Contains(buf.String(), "level=DEBUG") || !strings.Contains(buf.String(), "event=debug_event") {
+		t.Errorf("Expected a text debug line, got %q", buf.String())
+	}
+	log.Printf("✅ Text logger emits debug lines")
+
+	// Error level drops warnings
+	buf.Reset()
+	logger, _ = newLogger(&buf, "error", "json")
+	logger.Warn("dropped")
+	if buf.Len() != 0 {
+		t.Errorf("Expected warnings to be dropped at error level, got %q", buf.String())
+	}
+
+	if _, err := newLogger(&buf, "verbose", ""); err == nil {
+		t.Errorf("Expected an invalid LOG_LEVEL to be rejected")
+	}
+	if _, err := newLogger(&buf, "", "xml"); err == nil {
+		t.Errorf("Expected an invalid LOG_FORMAT to be rejected")
+	}
+	log.Printf("✅ Invalid LOG_LEVEL and LOG_FORMAT rejected")
+}
+
+func TestRequestID_PropagatesToVerifierLogs(t *testing.T) {
+	log.Printf("🧪 Starting TestRequestID_PropagatesToVerifierLogs")
+
+	var buf bytes.Buffer
+	logger, err := newLogger(&buf, "debug", "json")
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	previous := slog.Default()
+	slog.SetDefault(logger)
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	validatorID, _, _ := delegation.DecodeSS58(selfTestValidator)
+	verifier := delegation.NewVerifierWithConfig(delegation.VerifierConfig{
+		RPCURL:         newStakingRPCServer(t, validatorID).URL,
+		ResultCacheTTL: -1,
+		Logger:         logger,
+	})
+	handler := RequestIDMiddleware(VerifyHandler(newCheckedSigningOracle(t, verifier), nil, ""))
+
+	body, _ := json.Marshal(testVerifyRequest)
+	req := httptest.NewRequest(http.MethodPost, "/verify", bytes.NewReader(body))
+	req.Header.Set(RequestIDHeader, "trace-123")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get(RequestIDHeader); got != "trace-123" {
+		t.Fatalf("Expected the incoming request ID to be echoed, got %q", got)
+	}
+
+	events := map[string]bool{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Expected JSON log lines, got %q: %v", line, err)
+		}
+		if entry["event"] == "rpc_call" && entry["request_id"] != "trace-123" {
+			t.Fatalf("Expected every RPC log line to carry the request ID, got %v", entry)
+		}
+		if entry["request_id"] == "trace-123" {
+			events[entry["event"].(string)] = true
+		}
+	}
+	if !events["verify_request"] || !events["rpc_call"] {
+		t.Fatalf("Expected handler and verifier logs tagged with the request ID, got events %v", events)
+	}
+	log.Printf("✅ Request ID logged by the handler and the verifier: %v", events)
+
+	// Without a usable incoming ID, one is generated
+	req = httptest.NewRequest(http.MethodPost, "/verify", bytes.NewReader(body))
+	req.Header.Set(RequestIDHeader, "has spaces")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if got := rec.Header().Get(RequestIDHeader); len(got) != 32 || got == "has spaces" {
+		t.Fatalf("Expected a generated request ID, got %q", got)
+	}
+	log.Printf("✅ Invalid incoming ID replaced by %s", rec.Header().Get(RequestIDHeader))
+}
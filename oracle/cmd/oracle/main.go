@@ -1,16 +1,27 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
+	"oracle/pkg/delegation"
 	"oracle/pkg/signingoracle"
 
 	"github.com/gorilla/mux"
 	"github.com/joho/godotenv"
+	"golang.org/x/time/rate"
 )
 
 // Request represents the incoming request structure
@@ -20,28 +31,74 @@ type Request struct {
 	Msg              string `json:"msg"`
 }
 
-// Response represents the response structure
+// Response represents the response structure.
+// It is encoded as JSON, MessagePack or protobuf depending on the request's Accept header.
 type Response struct {
-	ValidatorAddress string `json:"validator_address"`
-	NominatorAddress string `json:"nominator_address"`
-	Msg              string `json:"msg"`
-	Signature        string `json:"signature"`
+	ValidatorAddress string  `json:"validator_address" msgpack:"validator_address"`
+	NominatorAddress string  `json:"nominator_address" msgpack:"nominator_address"`
+	Msg              string  `json:"msg" msgpack:"msg"`
+	Signature        string  `json:"signature,omitempty" msgpack:"signature,omitempty"`
+	Era              *uint32 `json:"era,omitempty" msgpack:"era,omitempty"`
+	Nonce            uint64  `json:"nonce,omitempty" msgpack:"nonce,omitempty"`
+	Deadline         int64   `json:"deadline,omitempty" msgpack:"deadline,omitempty"`
+	Attestation      string  `json:"attestation,omitempty" msgpack:"attestation,omitempty"`
+	// BlockHash and BlockSignature are set with ?bind_block=true: the block the delegation was
+	// confirmed at and the oracle's signature over the triplet and that block hash
+	BlockHash      string `json:"block_hash,omitempty" msgpack:"block_hash,omitempty"`
+	BlockSignature string `json:"block_signature,omitempty" msgpack:"block_signature,omitempty"`
+	// DryRun is set with ?dry_run=true, when the delegation was verified but nothing was signed,
+	// so Signature, Nonce and Deadline are left empty
+	DryRun bool `json:"dry_run,omitempty" msgpack:"dry_run,omitempty"`
+
+	Verification *delegation.VerificationResult `json:"verification,omitempty" msgpack:"verification,omitempty"`
+	Transcript   *delegation.Transcript         `json:"transcript,omitempty" msgpack:"transcript,omitempty"`
 }
 
-// ErrorResponse represents error response structure
+// ErrorResponse represents error response structure. Verification carries the delegation
+// sub-checks when a request fails them, so callers can tell why.
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
+	Error        string                         `json:"error"`
+	Message      string                         `json:"message"`
+	Verification *delegation.VerificationResult `json:"verification,omitempty"`
 }
 
-// VerifyHandler handles the /verify endpoint
-func VerifyHandler(so *signingoracle.SigningOracle) http.HandlerFunc {
+// MaxRequestBodyBytes caps the size of a request body
+const MaxRequestBodyBytes = 1 << 20
+
+// decodeRequestBody decodes the JSON body of r into v, refusing bodies larger than
+// MaxRequestBodyBytes and fields v doesn't have, so client typos aren't silently ignored
+func decodeRequestBody(w http.ResponseWriter, r *http.Request, v interface{}) *ErrorResponse {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxRequestBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return &ErrorResponse{
+				Error:   "request_too_large",
+				Message: fmt.Sprintf("Request body must not exceed %d bytes", tooLarge.Limit),
+			}
+		}
+		return &ErrorResponse{
+			Error:   "invalid_request_body",
+			Message: fmt.Sprintf("Invalid request body: %v", err),
+		}
+	}
+	return nil
+}
+
+// VerifyHandler handles the /verify endpoint.
+// With ?transcript=true the response carries a transcript of the verification, which is also
+// written to transcriptDir when it is set. With ?dry_run=true the delegation is fully verified
+// but nothing is signed and no nonce is issued, for monitoring the RPC and decoding pipeline.
+// Verifying and signing is left to signer; the handler only translates between it and HTTP.
+func VerifyHandler(signer DelegationSigner, denyList *DenyList, transcriptDir string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Set CORS headers
 		w.Header().Set("Content-Type", "application/json")
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID")
+		w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID")
 
 		// Handle preflight requests
 		if r.Method == "OPTIONS" {
@@ -55,10 +112,22 @@ func VerifyHandler(so *signingoracle.SigningOracle) http.HandlerFunc {
 			return
 		}
 
-		// Parse the request body
+		verifyRequestsTotal.Inc()
 		var req Request
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			http.Error(w, "Invalid request body", http.StatusBadRequest)
+		dryRun := r.URL.Query().Get("dry_run") == "true"
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		w = recorder
+		defer func(start time.Time) {
+			elapsed := time.Since(start)
+			verifyDuration.Observe(elapsed.Seconds())
+			slog.InfoContext(r.Context(), "verify request handled", "event", "verify_request", "nominator", req.NominatorAddress,
+				"validator", req.ValidatorAddress, "status", recorder.status, "dry_run", dryRun, "duration_ms", elapsed.Milliseconds())
+		}(time.Now())
+
+		// Parse the request body
+		if errorResp := decodeRequestBody(w, r, &req); errorResp != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(errorResp)
 			return
 		}
 
@@ -68,71 +137,293 @@ func VerifyHandler(so *signingoracle.SigningOracle) http.HandlerFunc {
 			return
 		}
 
-		// Verify delegation
-		verifier := so.GetVerifier()
-		isDelegated, err := verifier.VerifyDelegation(req.NominatorAddress, req.ValidatorAddress)
-		if err != nil {
-			log.Printf("Error verifying delegation: %v", err)
-			errorResp := ErrorResponse{
-				Error:   "verification_failed",
-				Message: fmt.Sprintf("Failed to verify delegation: %v", err),
-			}
-			w.WriteHeader(http.StatusInternalServerError)
+		// Reject malformed addresses before they cost an RPC round-trip
+		if errorResp := validateAddresses(req); errorResp != nil {
+			w.WriteHeader(http.StatusBadRequest)
 			json.NewEncoder(w).Encode(errorResp)
 			return
 		}
 
-		if !isDelegated {
-			errorResp := ErrorResponse{
-				Error:   "delegation_not_found",
-				Message: "Nominator has not delegated to the specified validator",
-			}
-			w.WriteHeader(http.StatusBadRequest)
+		// Optionally record every verification step for reproducibility
+		ctx := r.Context()
+		var transcript *delegation.Transcript
+		if r.URL.Query().Get("transcript") == "true" {
+			transcript = delegation.NewTranscript()
+			transcript.SetInput("msg", req.Msg)
+			transcript.SetInput("bind_era", r.URL.Query().Get("bind_era"))
+			ctx = delegation.WithTranscript(ctx, transcript)
+		}
+
+		// Refuse to sign for denied addresses before doing any verification work
+		if errorResp := checkDenyList(ctx, denyList, req); errorResp != nil {
+			w.WriteHeader(http.StatusForbidden)
 			json.NewEncoder(w).Encode(errorResp)
 			return
 		}
 
-		// Sign the triplet (validator, nominator, msg)
-		signatureBytes, err := so.SignTriplet(req.ValidatorAddress, req.NominatorAddress, req.Msg)
-		if err != nil {
-			log.Printf("Error signing triplet: %v", err)
+		// Verify delegation and the bonded threshold, then sign the triplet (validator, nominator,
+		// msg), optionally committing to the active era
+		result, err := signer.VerifyAndSignWithOptions(ctx, req.ValidatorAddress, req.NominatorAddress, req.Msg, signingoracle.VerifyOptions{
+			BindEra: r.URL.Query().Get("bind_era") == "true",
+			DryRun:  dryRun,
+		})
+		if errors.Is(err, signingoracle.ErrSigningFailed) {
+			slog.ErrorContext(ctx, "failed to sign triplet", "event", "signing_failed", "nominator", req.NominatorAddress, "validator", req.ValidatorAddress, "error", err)
+			signingErrorsTotal.Inc()
 			http.Error(w, "Internal server error", http.StatusInternalServerError)
 			return
 		}
-
-		// Convert signature bytes to hex string
-		signature := fmt.Sprintf("%x", signatureBytes)
+		if err != nil {
+			status, errorResp := delegationErrorResponse(ctx, req, err)
+			if errorResp.Error == "delegation_not_found" {
+				delegationNotFoundTotal.Inc()
+			}
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(errorResp)
+			return
+		}
+		verification := result.Verification
 
 		// Create the response
 		response := Response{
-			ValidatorAddress: req.ValidatorAddress,
-			NominatorAddress: req.NominatorAddress,
-			Msg:              req.Msg,
-			Signature:        "0x" + signature,
+			ValidatorAddress: result.ValidatorAddress,
+			NominatorAddress: result.NominatorAddress,
+			Msg:              result.Msg,
+			Era:              result.Era,
+			Nonce:            result.Nonce,
+			Deadline:         result.Deadline,
+			DryRun:           result.DryRun,
+			Verification:     verification,
 		}
 
-		// Return the response
-		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(response)
+		// A dry run stops after verification: nothing is signed, so no nonce is issued and
+		// bind_block and attestation, which sign too, are ignored
+		if result.DryRun {
+			response.Transcript = transcript
+			writeVerifyResponse(ctx, w, r, response)
+			return
+		}
+		response.Signature = fmt.Sprintf("0x%x", result.Signature)
+
+		// Optionally attach a receipt binding the triplet to the block the delegation was confirmed at
+		if r.URL.Query().Get("bind_block") == "true" {
+			blockSigner, ok := signer.(BlockSigner)
+			if !ok {
+				http.Error(w, "Block receipts not supported", http.StatusNotImplemented)
+				return
+			}
+			if verification == nil || verification.BlockHash == "" {
+				errorResp := ErrorResponse{
+					Error:   "block_hash_unavailable",
+					Message: "The block the delegation was confirmed at is unknown",
+				}
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(errorResp)
+				return
+			}
+			blockSignature, err := blockSigner.SignTripletAtBlock(req.ValidatorAddress, req.NominatorAddress, req.Msg, verification.BlockHash)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to sign block receipt", "event", "signing_failed", "nominator", req.NominatorAddress, "validator", req.ValidatorAddress, "error", err)
+				signingErrorsTotal.Inc()
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+			response.BlockHash = verification.BlockHash
+			response.BlockSignature = fmt.Sprintf("0x%x", blockSignature)
+		}
+
+		// Optionally attach a short-lived JWT attestation of the verification
+		if r.URL.Query().Get("attestation") == "true" {
+			issuer, ok := signer.(AttestationIssuer)
+			if !ok {
+				http.Error(w, "Attestations not supported", http.StatusNotImplemented)
+				return
+			}
+			attestation, err := issuer.IssueAttestation(delegation.DelegationVerificationResult{
+				NominatorAddress: req.NominatorAddress,
+				ValidatorAddress: req.ValidatorAddress,
+				IsValid:          true,
+				Timestamp:        time.Now(),
+			})
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to issue attestation", "event", "attestation_failed", "nominator", req.NominatorAddress, "error", err)
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+			response.Attestation = attestation
+		}
+
+		if transcript != nil {
+			transcript.SetSignature(response.Signature)
+			if transcriptDir != "" {
+				if path, err := transcript.Persist(transcriptDir); err != nil {
+					slog.ErrorContext(ctx, "failed to persist transcript", "event", "transcript_failed", "error", err)
+				} else {
+					slog.DebugContext(ctx, "transcript written", "event", "transcript_written", "path", path)
+				}
+			}
+			response.Transcript = transcript
+		}
+
+		writeVerifyResponse(ctx, w, r, response)
+	}
+}
+
+// writeVerifyResponse writes a successful /verify response in the format the client accepts
+func writeVerifyResponse(ctx context.Context, w http.ResponseWriter, r *http.Request, response Response) {
+	encoder := negotiateResponseEncoder(r.Header.Get("Accept"))
+	w.Header().Set("Content-Type", encoder.ContentType())
+	w.WriteHeader(http.StatusOK)
+	if err := encoder.Encode(w, response); err != nil {
+		slog.ErrorContext(ctx, "failed to encode response", "event", "encode_failed", "content_type", encoder.ContentType(), "error", err)
 	}
 }
 
+// verificationErrorStatus maps a verification error to the status that says whose fault it is:
+// 502 when the upstream RPC endpoint failed, 400 when an address couldn't be decoded and 500
+// for anything else, which is a fault of the oracle itself
+func verificationErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, delegation.ErrRPCUnavailable):
+		return http.StatusBadGateway, "upstream_rpc_failed"
+	case errors.Is(err, delegation.ErrInvalidAddress):
+		return http.StatusBadRequest, "invalid_address"
+	case errors.Is(err, delegation.ErrNotBonded):
+		return http.StatusBadRequest, "nominator_not_bonded"
+	default:
+		return http.StatusInternalServerError, "verification_failed"
+	}
+}
+
+// checkDenyList refuses requests naming a denied nominator or validator
+func checkDenyList(ctx context.Context, denyList *DenyList, req Request) *ErrorResponse {
+	if !denyList.Contains(req.NominatorAddress) && !denyList.Contains(req.ValidatorAddress) {
+		return nil
+	}
+	slog.WarnContext(ctx, "refusing denied address", "event", "address_denied", "nominator", req.NominatorAddress, "validator", req.ValidatorAddress)
+	return &ErrorResponse{
+		Error:   "address_denied",
+		Message: "The nominator or validator address is not allowed",
+	}
+}
+
+// delegationErrorResponse maps an error from verifying a delegation to the HTTP status and error
+// to report: the failed sub-checks for a delegation that didn't pass, otherwise whose fault it was
+func delegationErrorResponse(ctx context.Context, req Request, err error) (int, *ErrorResponse) {
+	var verificationErr *signingoracle.VerificationError
+	switch {
+	case errors.As(err, &verificationErr) && errors.Is(err, signingoracle.ErrBondBelowThreshold):
+		return http.StatusBadRequest, &ErrorResponse{
+			Error:        "bond_below_threshold",
+			Message:      fmt.Sprintf("Nominator's active bond of %s planck is below the required minimum", verificationErr.Verification.BondedAmount),
+			Verification: verificationErr.Verification,
+		}
+	case errors.As(err, &verificationErr):
+		return http.StatusBadRequest, &ErrorResponse{
+			Error:        "delegation_not_found",
+			Message:      "Nominator has not delegated to the specified validator",
+			Verification: verificationErr.Verification,
+		}
+	case errors.Is(err, signingoracle.ErrActiveEraUnavailable):
+		slog.ErrorContext(ctx, "failed to look up active era", "event", "era_lookup_failed", "error", err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, delegation.ErrRPCUnavailable) {
+			status = http.StatusBadGateway
+		}
+		return status, &ErrorResponse{
+			Error:   "era_lookup_failed",
+			Message: errorMessage(err),
+		}
+	default:
+		status, code := verificationErrorStatus(err)
+		slog.ErrorContext(ctx, "failed to verify delegation", "event", "verification_failed", "nominator", req.NominatorAddress, "validator", req.ValidatorAddress, "status", status, "error", err)
+		return status, &ErrorResponse{
+			Error:   code,
+			Message: errorMessage(err),
+		}
+	}
+}
+
+// errorMessage turns an error into a sentence for ErrorResponse.Message
+func errorMessage(err error) string {
+	message := err.Error()
+	if message == "" {
+		return message
+	}
+	return strings.ToUpper(message[:1]) + message[1:]
+}
+
 // InfoHandler provides information about the oracle's keys
 func InfoHandler(so *signingoracle.SigningOracle) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 
-		info := map[string]string{
-			"public_key": so.GetPublicKeyHex(),
-			"address":    so.GetAddress(),
-			"status":     "ready",
+		network := so.GetVerifier().Network()
+		info := map[string]interface{}{
+			"public_key":  so.GetPublicKeyHex(),
+			"address":     so.GetAddress(),
+			"status":      "ready",
+			"network":     network.Name,
+			"ss58_prefix": network.SS58Prefix,
+			"rpc_url":     redactURL(so.GetVerifier().RPCURL()),
+		}
+		if chainID := so.GetChainID(); chainID != 0 {
+			info["chain_id"] = chainID
 		}
 
 		json.NewEncoder(w).Encode(info)
 	}
 }
 
+// StatusHandler reports operational details such as per-method RPC success rates and signing volume
+func StatusHandler(so *signingoracle.SigningOracle) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		status := map[string]interface{}{
+			"address":      so.GetAddress(),
+			"rpc_stats":    so.GetVerifier().RPCStats(),
+			"signing_rate": so.SigningRate(),
+		}
+
+		json.NewEncoder(w).Encode(status)
+	}
+}
+
+// routeHandlers are the handlers behind the routes of the HTTP API, already wrapped in their middleware
+type routeHandlers struct {
+	Verify, VerifyBatch, VerifyStream, Validators http.Handler
+	Info, Status, Recover, Ready, Metrics         http.Handler
+	// AdminReload and AdminRotateKey are only routed when set, since reloading requires
+	// ADMIN_TOKEN and rotation requires API keys
+	AdminReload, AdminRotateKey http.Handler
+}
+
+// newRouter routes the HTTP API to h. Every route it registers must be described in openapi.json.
+func newRouter(h routeHandlers) *mux.Router {
+	r := mux.NewRouter()
+	r.Handle("/verify", h.Verify).Methods("POST", "OPTIONS")
+	r.Handle("/verify-batch", h.VerifyBatch).Methods("POST", "OPTIONS")
+	r.Handle("/verify-delegation/stream", h.VerifyStream).Methods("GET")
+	r.Handle("/validators", h.Validators).Methods("GET")
+	r.Handle("/info", h.Info).Methods("GET")
+	r.Handle("/status", h.Status).Methods("GET")
+	r.Handle("/recover", h.Recover).Methods("POST")
+	r.HandleFunc("/health", HealthHandler).Methods("GET")
+	r.Handle("/ready", h.Ready).Methods("GET")
+	r.Handle("/metrics", h.Metrics).Methods("GET")
+	r.HandleFunc("/openapi.json", OpenAPIHandler).Methods("GET")
+	if h.AdminReload != nil {
+		r.Handle("/admin/reload", h.AdminReload).Methods("POST")
+	}
+	if h.AdminRotateKey != nil {
+		r.Handle("/admin/rotate-key", h.AdminRotateKey).Methods("POST")
+	}
+	return r
+}
+
 // HealthHandler provides a simple health check endpoint
 func HealthHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -140,31 +431,158 @@ func HealthHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]string{"status": "healthy"})
 }
 
+// loadSigningOracle creates the signing oracle, keyed from an encrypted keystore when
+// KEYSTORE_FILE is configured and from the environment otherwise
+func loadSigningOracle() (*signingoracle.SigningOracle, error) {
+	path := os.Getenv("KEYSTORE_FILE")
+	if path == "" {
+		return signingoracle.NewSigningOracle()
+	}
+
+	password, err := os.ReadFile(os.Getenv("KEYSTORE_PASSWORD_FILE"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read KEYSTORE_PASSWORD_FILE: %w", err)
+	}
+	return signingoracle.NewSigningOracleFromKeystore(path, strings.TrimRight(string(password), "\r\n"))
+}
+
 func main() {
 	// Load environment variables from .env file
-	if err := godotenv.Load(); err != nil {
-		log.Printf("Warning: Could not load .env file: %v", err)
+	envErr := godotenv.Load()
+
+	// Log structured JSON by default, or text for local development
+	logger, err := newLogger(os.Stderr, os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT"))
+	if err != nil {
+		log.Fatalf("Failed to configure logging: %v", err)
+	}
+	slog.SetDefault(logger)
+	if envErr != nil {
+		slog.Warn("could not load .env file", "event", "env_load_failed", "error", envErr)
+	}
+
+	// A subcommand such as "oracle verify ..." runs once and exits instead of starting the server
+	if code, ok := runSubcommand(os.Args[1:], os.Stdout, os.Stderr); ok {
+		os.Exit(code)
 	}
 
 	// Create a new signing oracle
-	oracle, err := signingoracle.NewSigningOracle()
+	oracle, err := loadSigningOracle()
 	if err != nil {
-		log.Fatalf("Failed to create signing oracle: %v", err)
+		fatal("failed to create signing oracle", "event", "startup_failed", "error", err)
 	}
 
-	// Log oracle information
-	log.Printf("Oracle initialized successfully")
-	log.Printf("Private Key: %s", oracle.GetPrivateKeyHex())
-	log.Printf("Public Key: %s", oracle.GetPublicKeyHex())
-	log.Printf("Address: %s", oracle.GetAddress())
+	oracle.SetLogger(logger)
 
-	// Create a new router
-	r := mux.NewRouter()
+	// Log oracle information. Key material is never logged, at any level.
+	slog.Info("oracle initialized", "event", "oracle_initialized", "address", oracle.GetAddress(), "public_key", oracle.GetPublicKeyHex())
 
-	// Define routes
-	r.HandleFunc("/verify", VerifyHandler(oracle)).Methods("POST", "OPTIONS")
-	r.HandleFunc("/info", InfoHandler(oracle)).Methods("GET")
-	r.HandleFunc("/health", HealthHandler).Methods("GET")
+	// The Verifier contract only accepts a nonce above the last it consumed for the nominator, so
+	// nonces kept in memory restart at 1 and are rejected until they pass it again
+	if os.Getenv("NONCE_STORE_FILE") == "" {
+		slog.Warn("NONCE_STORE_FILE not set, nonces are kept in memory and restart at 1, so the Verifier contract rejects signatures issued after a restart as already used", "event", "config")
+	}
+
+	// Sign and verify a canonical triplet to catch key/config problems before serving traffic
+	if err := runSelfTest(oracle); err != nil {
+		if os.Getenv("STRICT_STARTUP") == "true" {
+			fatal("startup self-test failed, refusing to start", "event", "self_test_failed", "error", err)
+		}
+		slog.Warn("startup self-test failed", "event", "self_test_failed", "error", err)
+	} else {
+		slog.Info("startup self-test passed", "event", "self_test_passed", "address", oracle.GetAddress())
+	}
+
+	// Limit concurrent verifications, each of which performs upstream RPC calls
+	maxInFlight := 64
+	if value := os.Getenv("MAX_INFLIGHT"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			fatal("invalid MAX_INFLIGHT value", "event", "startup_failed", "value", value)
+		}
+		maxInFlight = parsed
+	}
+	limitInFlight := MaxInFlightMiddleware(maxInFlight)
+	slog.Debug("max in-flight verify requests", "event", "config", "max_inflight", maxInFlight)
+
+	// Limit each client IP so one caller can't exhaust the upstream RPC quota
+	rateLimit := rate.Limit(DefaultRateLimit)
+	if value := os.Getenv("RATE_LIMIT"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed <= 0 {
+			fatal("invalid RATE_LIMIT value", "event", "startup_failed", "value", value)
+		}
+		rateLimit = rate.Limit(parsed)
+	}
+	rateBurst := DefaultRateBurst
+	if value := os.Getenv("RATE_BURST"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			fatal("invalid RATE_BURST value", "event", "startup_failed", "value", value)
+		}
+		rateBurst = parsed
+	}
+	rateLimiter := NewRateLimiter(rateLimit, rateBurst, os.Getenv("TRUST_FORWARDED_FOR") == "true")
+	slog.Debug("per-client verify rate limit", "event", "config", "rate_limit", float64(rateLimit), "rate_burst", rateBurst)
+
+	// Poll RPC reachability in the background so /verify can fail fast during outages
+	healthInterval := 15 * time.Second
+	if value := os.Getenv("RPC_HEALTH_INTERVAL"); value != "" {
+		healthInterval, err = time.ParseDuration(value)
+		if err != nil || healthInterval <= 0 {
+			fatal("invalid RPC_HEALTH_INTERVAL value", "event", "startup_failed", "value", value)
+		}
+	}
+	oracle.GetVerifier().StartHealthPoller(context.Background(), healthInterval)
+	requireHealthyRPC := RequireHealthyRPCMiddleware(oracle.GetVerifier(), healthInterval)
+
+	// Load the optional deny list of sanctioned addresses
+	var denyList *DenyList
+	if path := os.Getenv("DENYLIST_FILE"); path != "" {
+		denyList, err = LoadDenyList(path)
+		if err != nil {
+			fatal("failed to load deny list", "event", "startup_failed", "error", err)
+		}
+	}
+
+	// Require an API key on the endpoints that sign or spend RPC budget
+	requireAPIKey := func(next http.Handler) http.Handler { return next }
+	apiKeys := ParseAPIKeys(os.Getenv("API_KEYS"))
+	if len(apiKeys) > 0 {
+		requireAPIKey = RequireAPIKeyMiddleware(apiKeys)
+		slog.Info("API key authentication enabled", "event", "config", "api_keys", len(apiKeys))
+	} else {
+		slog.Warn("API_KEYS not set, /verify and /info are unauthenticated", "event", "config")
+	}
+
+	// Only accept addresses of the configured network
+	network := oracle.GetVerifier().Network()
+	addressNetwork = &network
+	slog.Info("verifying delegations", "event", "config", "network", network.Name, "ss58_prefix", network.SS58Prefix)
+
+	handlers := routeHandlers{
+		Verify:       rateLimiter.Limit(requireAPIKey(limitInFlight(requireHealthyRPC(VerifyHandler(oracle, denyList, os.Getenv("TRANSCRIPT_DIR")))))),
+		VerifyBatch:  rateLimiter.LimitBatch(requireAPIKey(limitInFlight(requireHealthyRPC(VerifyBatchHandler(oracle, oracle.GetVerifier(), denyList))))),
+		VerifyStream: rateLimiter.Limit(requireAPIKey(limitInFlight(StreamVerifyHandler(oracle.GetVerifier())))),
+		Validators:   requireAPIKey(limitInFlight(ValidatorsHandler(oracle.GetVerifier()))),
+		Info:         requireAPIKey(InfoHandler(oracle)),
+		Status:       requireAPIKey(StatusHandler(oracle)),
+		Recover:      requireAPIKey(RecoverHandler(oracle)),
+		Ready:        ReadyHandler(oracle.GetVerifier(), DefaultReadyTimeout),
+		Metrics:      MetricsHandler(oracle.GetVerifier()),
+	}
+	// The deny list is never reloadable unauthenticated
+	if adminToken := os.Getenv("ADMIN_TOKEN"); adminToken != "" {
+		handlers.AdminReload = AdminReloadHandler(denyList, adminToken)
+	} else {
+		slog.Warn("ADMIN_TOKEN not set, /admin/reload is disabled", "event", "config")
+	}
+	// Key rotation is never exposed unauthenticated
+	if len(apiKeys) > 0 {
+		handlers.AdminRotateKey = requireAPIKey(AdminRotateKeyHandler(oracle))
+	} else {
+		slog.Warn("API_KEYS not set, /admin/rotate-key is disabled", "event", "config")
+	}
+	r := newRouter(handlers)
 
 	// Get port from environment variable or use default
 	port := os.Getenv("PORT")
@@ -173,13 +591,41 @@ func main() {
 	}
 
 	// Start the server
-	log.Printf("Starting signing oracle service on port %s", port)
-	log.Printf("Available endpoints:")
-	log.Printf("  POST /verify - Sign a message (with delegation verification)")
-	log.Printf("  GET  /info   - Get oracle information")
-	log.Printf("  GET  /health - Health check")
+	slog.Info("starting signing oracle service", "event", "server_starting", "port", port)
+	for _, endpoint := range []struct{ route, description string }{
+		{"POST /verify", "Sign a message (with delegation verification, ?attestation=true for a JWT, ?bind_era=true to commit to the active era, ?transcript=true for a verification transcript)"},
+		{"POST /verify-batch", fmt.Sprintf("Verify and sign up to %d messages, each succeeding or failing on its own (?bind_era=true to commit to the active era)", MaxVerifyBatchSize)},
+		{"GET /verify-delegation/stream", "Stream verification progress as Server-Sent Events"},
+		{"GET /info", "Get oracle information"},
+		{"GET /status", "RPC method success rates and signing-rate alert"},
+		{"POST /recover", "Recover the address that signed a triplet"},
+		{"GET /health", "Health check"},
+		{"GET /ready", "Readiness check against the Polkadot RPC"},
+		{"GET /metrics", "Prometheus metrics"},
+		{"GET /openapi.json", "OpenAPI 3.0 description of this API"},
+		{"POST /admin/reload", "Reload the deny list"},
+		{"POST /admin/rotate-key", "Rotate the signing key without a restart (requires API_KEYS)"},
+	} {
+		slog.Debug("endpoint available", "event", "endpoint", "route", endpoint.route, "description", endpoint.description)
+	}
+
+	// Drain in-flight requests on SIGINT/SIGTERM so a deploy doesn't drop them mid-signature
+	shutdownGrace := DefaultShutdownGrace
+	if value := os.Getenv("SHUTDOWN_GRACE"); value != "" {
+		shutdownGrace, err = time.ParseDuration(value)
+		if err != nil || shutdownGrace <= 0 {
+			fatal("invalid SHUTDOWN_GRACE value", "event", "startup_failed", "value", value)
+		}
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	if err := http.ListenAndServe(":"+port, r); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		fatal("failed to start server", "event", "startup_failed", "error", err)
+	}
+	if err := runServer(ctx, listener, RequestIDMiddleware(r), shutdownGrace); err != nil {
+		fatal("server stopped uncleanly", "event", "shutdown_failed", "error", err)
 	}
+	slog.Info("signing oracle service stopped", "event", "server_stopped")
 }
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Verify handler metrics, exported on /metrics alongside the verifier's RPC metrics
+var (
+	verifyRequestsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "oracle_verify_requests_total",
+		Help: "Verify requests received.",
+	})
+	delegationNotFoundTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "oracle_delegation_not_found_total",
+		Help: "Verify requests rejected because the nominator does not nominate the validator.",
+	})
+	signingErrorsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "oracle_signing_errors_total",
+		Help: "Verified delegations that could not be signed.",
+	})
+	verifyDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "oracle_verify_duration_seconds",
+		Help:    "Latency of the verify handler, from request to response.",
+		Buckets: prometheus.DefBuckets,
+	})
+)
+
+// MetricsHandler serves the handler metrics and every extra collector, such as the delegation
+// verifier's RPC metrics, in the Prometheus text format
+func MetricsHandler(collectors ...prometheus.Collector) http.Handler {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(verifyRequestsTotal, delegationNotFoundTotal, signingErrorsTotal, verifyDuration)
+	registry.MustRegister(collectors...)
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
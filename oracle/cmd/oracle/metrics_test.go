@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bufio"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// scrapeMetric fetches /metrics and returns the value of an unlabelled sample
+func scrapeMetric(t *testing.T, handler http.Handler, name string) float64 {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 from /metrics, got %d", rec.Code)
+	}
+
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), name+" "); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				t.Fatalf("Invalid value for %s: %q", name, value)
+			}
+			return parsed
+		}
+	}
+	t.Fatalf("Metric %s not exported", name)
+	return 0
+}
+
+func TestMetricsHandler_CountsVerifyOutcomes(t *testing.T) {
+	log.Printf("🧪 Starting TestMetricsHandler_CountsVerifyOutcomes")
+
+	metrics := MetricsHandler()
+	requestsBefore := scrapeMetric(t, metrics, "oracle_verify_requests_total")
+	notFoundBefore := scrapeMetric(t, metrics, "oracle_delegation_not_found_total")
+	latencyBefore := scrapeMetric(t, metrics, "oracle_verify_duration_seconds_count")
+
+	postVerify(t, VerifyHandler(newCheckedSigningOracle(t, fakeChecker{delegated: true}), nil, ""), "/verify", testVerifyRequest)
+	postVerify(t, VerifyHandler(newCheckedSigningOracle(t, fakeChecker{delegated: false}), nil, ""), "/verify", testVerifyRequest)
+
+	if got := scrapeMetric(t, metrics, "oracle_verify_requests_total") - requestsBefore; got != 2 {
+		t.Errorf("Expected 2 more verify requests, got %v", got)
+	}
+	if got := scrapeMetric(t, metrics, "oracle_delegation_not_found_total") - notFoundBefore; got != 1 {
+		t.Errorf("Expected 1 more delegation-not-found response, got %v", got)
+	}
+	if got := scrapeMetric(t, metrics, "oracle_verify_duration_seconds_count") - latencyBefore; got != 2 {
+		t.Errorf("Expected 2 more verify latency observations, got %v", got)
+	}
+	if got := scrapeMetric(t, metrics, "oracle_signing_errors_total"); got != 0 {
+		t.Errorf("Expected no signing errors, got %v", got)
+	}
+	log.Printf("✅ Verify requests, not-found responses and latency exported")
+}
@@ -0,0 +1,332 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"oracle/pkg/delegation"
+
+	"golang.org/x/time/rate"
+)
+
+// RequestIDHeader carries a request's ID in both directions
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds an incoming request ID, which is copied into every log line
+const maxRequestIDLength = 128
+
+// validRequestID reports whether an incoming request ID is safe to log and echo: non-empty,
+// bounded and made of printable ASCII without spaces
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID returns a random 128-bit request ID as hex
+func newRequestID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// RequestIDMiddleware tags every request with an ID, honoring a valid incoming X-Request-ID and
+// generating one otherwise. The ID is stored in the request context, where the logger picks it
+// up for every line logged during the request, and echoed in the X-Request-ID response header.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = newRequestID()
+		}
+
+		w.Header().Set(RequestIDHeader, requestID)
+		next.ServeHTTP(w, r.WithContext(delegation.WithRequestID(r.Context(), requestID)))
+	})
+}
+
+// MaxInFlightMiddleware limits the number of requests processed concurrently.
+// Requests beyond the limit are rejected immediately with 503 and a Retry-After header
+// instead of queueing, so a traffic spike can't pile up goroutines doing RPC work.
+func MaxInFlightMiddleware(limit int) func(http.Handler) http.Handler {
+	semaphore := make(chan struct{}, limit)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+				next.ServeHTTP(w, r)
+			default:
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				json.NewEncoder(w).Encode(ErrorResponse{
+					Error:   "too_many_requests_in_flight",
+					Message: "Server is at capacity, please retry shortly",
+				})
+			}
+		})
+	}
+}
+
+// HealthStatus reports the background health of the upstream RPC endpoint
+type HealthStatus interface {
+	RPCHealthy() bool
+}
+
+// RequireHealthyRPCMiddleware fails fast with 503 while the RPC endpoint is known to be down,
+// rather than attempting calls that would only time out. Retry-After suggests waiting for
+// the next health probe.
+func RequireHealthyRPCMiddleware(health HealthStatus, retryAfter time.Duration) func(http.Handler) http.Handler {
+	retrySeconds := strconv.Itoa(int(retryAfter.Round(time.Second).Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if health.RPCHealthy() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Retry-After", retrySeconds)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			json.NewEncoder(w).Encode(ErrorResponse{
+				Error:   "rpc_unavailable",
+				Message: "The Polkadot RPC endpoint is currently unreachable, please retry later",
+			})
+		})
+	}
+}
+
+// Default per-client rate limit on /verify, each of which costs upstream RPC calls
+const (
+	DefaultRateLimit = 5
+	DefaultRateBurst = 10
+)
+
+// rateLimiterIdleTTL is how long a client's bucket is kept after its last request. A bucket
+// idle this long has refilled completely, so dropping it loses nothing.
+const rateLimiterIdleTTL = 3 * time.Minute
+
+// clientLimiter is one client's token bucket
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// ipRateLimiter holds a token bucket per client IP. Idle buckets are swept on access so the
+// map can't grow without bound.
+type ipRateLimiter struct {
+	mu        sync.Mutex
+	limit     rate.Limit
+	burst     int
+	clients   map[string]*clientLimiter
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func newIPRateLimiter(limit rate.Limit, burst int) *ipRateLimiter {
+	return &ipRateLimiter{
+		limit:   limit,
+		burst:   burst,
+		clients: make(map[string]*clientLimiter),
+		now:     time.Now,
+	}
+}
+
+// allow takes n tokens from the client's bucket, or reports how long until they are available.
+// A cost larger than the burst is capped at the burst, as the bucket could never hold it.
+func (l *ipRateLimiter) allow(ip string, n int) (bool, time.Duration) {
+	n = min(n, l.burst)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastSweep) >= rateLimiterIdleTTL {
+		for key, client := range l.clients {
+			if now.Sub(client.lastSeen) >= rateLimiterIdleTTL {
+				delete(l.clients, key)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	client, ok := l.clients[ip]
+	if !ok {
+		client = &clientLimiter{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.clients[ip] = client
+	}
+	client.lastSeen = now
+
+	reservation := client.limiter.ReserveN(now, n)
+	if !reservation.OK() {
+		return false, time.Second
+	}
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// clientIP returns the address rate limits are keyed on. With trustForwardedFor, the last
+// X-Forwarded-For entry is used: it was appended by our own proxy, unlike earlier entries
+// which the client controls.
+func clientIP(r *http.Request, trustForwardedFor bool) string {
+	if trustForwardedFor {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			entries := strings.Split(forwarded, ",")
+			if ip := strings.TrimSpace(entries[len(entries)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// RateLimiter limits each client IP to limit tokens per second with bursts of up to burst.
+// Every handler it wraps draws from the same per-client buckets.
+type RateLimiter struct {
+	limiter           *ipRateLimiter
+	trustForwardedFor bool
+}
+
+// NewRateLimiter creates a RateLimiter. Set trustForwardedFor only behind a proxy that appends
+// the client to X-Forwarded-For.
+func NewRateLimiter(limit rate.Limit, burst int, trustForwardedFor bool) *RateLimiter {
+	return &RateLimiter{limiter: newIPRateLimiter(limit, burst), trustForwardedFor: trustForwardedFor}
+}
+
+// Limit charges one token per request. Requests over the limit are rejected with 429 and a
+// Retry-After header.
+func (l *RateLimiter) Limit(next http.Handler) http.Handler {
+	return l.limitBy(next, func(*http.Request) int { return 1 })
+}
+
+// LimitBatch charges one token per item of a JSON array request body, so a batch costs what its
+// items would as separate requests, up to the burst: a batch larger than the burst takes a full
+// bucket rather than being unservable.
+func (l *RateLimiter) LimitBatch(next http.Handler) http.Handler {
+	return l.limitBy(next, batchSize)
+}
+
+func (l *RateLimiter) limitBy(next http.Handler, cost func(*http.Request) int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		allowed, retryAfter := l.limiter.allow(clientIP(r, l.trustForwardedFor), cost(r))
+		if allowed {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		w.WriteHeader(http.StatusTooManyRequests)
+		json.NewEncoder(w).Encode(ErrorResponse{
+			Error:   "rate_limited",
+			Message: "Too many requests from this client, please retry later",
+		})
+	})
+}
+
+// batchSize counts the items of a JSON array request body, restoring the body for the next
+// handler. A body that isn't a non-empty array counts as one item; the handler rejects it, as it
+// does an oversized body, whose unread rest is left in place for the handler to find.
+func batchSize(r *http.Request) int {
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxRequestBodyBytes))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+	if err != nil {
+		return 1
+	}
+	var items []json.RawMessage
+	if json.Unmarshal(body, &items) != nil || len(items) == 0 {
+		return 1
+	}
+	return len(items)
+}
+
+// RateLimitMiddleware limits each client IP to limit requests per second with bursts of up to
+// burst, charging one token per request. Requests over the limit are rejected with 429 and a
+// Retry-After header. Set trustForwardedFor only behind a proxy that appends the client to
+// X-Forwarded-For.
+func RateLimitMiddleware(limit rate.Limit, burst int, trustForwardedFor bool) func(http.Handler) http.Handler {
+	return NewRateLimiter(limit, burst, trustForwardedFor).Limit
+}
+
+// ParseAPIKeys splits a comma-separated API_KEYS value, ignoring blank entries
+func ParseAPIKeys(value string) []string {
+	var keys []string
+	for _, key := range strings.Split(value, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// RequireAPIKeyMiddleware rejects requests with 401 unless they carry one of keys as an
+// Authorization bearer token. Keys are compared by SHA-256 digest in constant time, and every
+// key is checked, so neither a key's contents nor its length leak through response timing.
+// CORS preflight requests can't carry credentials and are passed through.
+func RequireAPIKeyMiddleware(keys []string) func(http.Handler) http.Handler {
+	digests := make([][sha256.Size]byte, len(keys))
+	for i, key := range keys {
+		digests[i] = sha256.Sum256([]byte(key))
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			presented, hasBearer := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			digest := sha256.Sum256([]byte(presented))
+			match := 0
+			for i := range digests {
+				match |= subtle.ConstantTimeCompare(digest[:], digests[i][:])
+			}
+
+			if hasBearer && match == 1 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(ErrorResponse{
+				Error:   "unauthorized",
+				Message: "A valid API key is required",
+			})
+		})
+	}
+}
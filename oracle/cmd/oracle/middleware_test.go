@@ -0,0 +1,330 @@
+package main
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestMaxInFlightMiddleware(t *testing.T) {
+	log.Printf("🧪 Starting TestMaxInFlightMiddleware")
+
+	const limit = 2
+	const requests = 6
+
+	release := make(chan struct{})
+	var entered sync.WaitGroup
+	entered.Add(limit)
+
+	handler := MaxInFlightMiddleware(limit)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		entered.Done()
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	codes := make(chan *httptest.ResponseRecorder, requests)
+	var done sync.WaitGroup
+
+	// Occupy every slot first so the remaining requests deterministically overflow
+	for i := 0; i < limit; i++ {
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/verify", nil))
+			codes <- rec
+		}()
+	}
+	entered.Wait()
+
+	for i := limit; i < requests; i++ {
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/verify", nil))
+			codes <- rec
+		}()
+	}
+
+	// Wait for the overflow requests before unblocking the in-flight ones
+	rejected := 0
+	for i := limit; i < requests; i++ {
+		rec := <-codes
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Fatalf("Expected 503 for overflow request, got %d", rec.Code)
+		}
+		if rec.Header().Get("Retry-After") == "" {
+			t.Errorf("Expected Retry-After header on 503 response")
+		}
+		rejected++
+	}
+	close(release)
+	done.Wait()
+	close(codes)
+
+	accepted := 0
+	for rec := range codes {
+		if rec.Code == http.StatusOK {
+			accepted++
+		}
+	}
+
+	if accepted != limit || rejected != requests-limit {
+		t.Fatalf("Expected %d accepted and %d rejected, got %d and %d", limit, requests-limit, accepted, rejected)
+	}
+
+	log.Printf("✅ %d requests accepted, %d rejected with 503", accepted, rejected)
+}
+
+// staticHealth reports a fixed RPC health
+type staticHealth bool
+
+func (h staticHealth) RPCHealthy() bool {
+	return bool(h)
+}
+
+func TestRequireHealthyRPCMiddleware(t *testing.T) {
+	log.Printf("🧪 Starting TestRequireHealthyRPCMiddleware")
+
+	called := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	RequireHealthyRPCMiddleware(staticHealth(false), 15*time.Second)(next).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/verify", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected 503 while the RPC is down, got %d", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") != "15" {
+		t.Errorf("Expected Retry-After 15, got %q", rec.Header().Get("Retry-After"))
+	}
+	if called {
+		t.Errorf("Expected the verify handler not to run while the RPC is down")
+	}
+	log.Printf("✅ Request failed fast with 503 while RPC is down")
+
+	rec = httptest.NewRecorder()
+	RequireHealthyRPCMiddleware(staticHealth(true), 15*time.Second)(next).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/verify", nil))
+	if rec.Code != http.StatusOK || !called {
+		t.Fatalf("Expected request to pass through while the RPC is healthy, got %d", rec.Code)
+	}
+	log.Printf("✅ Request passed through while RPC is healthy")
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	log.Printf("🧪 Starting TestRateLimitMiddleware")
+
+	const burst = 3
+
+	handler := RateLimitMiddleware(rate.Limit(1), burst, true)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	fire := func(forwardedFor string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/verify", nil)
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// The burst is served, the next request from the same client is rejected
+	for i := 0; i < burst; i++ {
+		if rec := fire("198.51.100.7"); rec.Code != http.StatusOK {
+			t.Fatalf("Expected request %d within burst to pass, got %d", i+1, rec.Code)
+		}
+	}
+	rec := fire("198.51.100.7")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429 after burst, got %d", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") != "1" {
+		t.Fatalf("Expected Retry-After of 1 second, got %q", rec.Header().Get("Retry-After"))
+	}
+	log.Printf("✅ Request past burst rejected with 429, Retry-After %s", rec.Header().Get("Retry-After"))
+
+	// The limit is keyed on the last X-Forwarded-For entry, so a spoofed leading entry doesn't help
+	if rec := fire("203.0.113.1, 198.51.100.7"); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected spoofed X-Forwarded-For to be limited as the same client, got %d", rec.Code)
+	}
+	if rec := fire("198.51.100.8"); rec.Code != http.StatusOK {
+		t.Fatalf("Expected a different client to have its own bucket, got %d", rec.Code)
+	}
+	log.Printf("✅ Clients keyed on the proxy-appended X-Forwarded-For entry")
+}
+
+func TestRateLimiter_LimitBatch(t *testing.T) {
+	log.Printf("🧪 Starting TestRateLimiter_LimitBatch")
+
+	limiter := NewRateLimiter(rate.Limit(1), 5, false)
+	var received []string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, string(body))
+		w.WriteHeader(http.StatusOK)
+	})
+	verify := limiter.Limit(next)
+	verifyBatch := limiter.LimitBatch(next)
+
+	fire := func(handler http.Handler, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/verify-batch", strings.NewReader(body)))
+		return rec
+	}
+
+	// A batch of three takes three of the five tokens and reaches the handler intact
+	batch := `[{"msg":"a"},{"msg":"b"},{"msg":"c"}]`
+	if rec := fire(verifyBatch, batch); rec.Code != http.StatusOK {
+		t.Fatalf("Expected a batch within the burst to pass, got %d", rec.Code)
+	}
+	if len(received) != 1 || received[0] != batch {
+		t.Fatalf("Expected the handler to read the batch unchanged, got %q", received)
+	}
+	log.Printf("✅ Batch charged per item and passed through unchanged")
+
+	// /verify draws from the same bucket, so only two requests remain
+	for i := 0; i < 2; i++ {
+		if rec := fire(verify, `{}`); rec.Code != http.StatusOK {
+			t.Fatalf("Expected request %d within the remaining tokens to pass, got %d", i+1, rec.Code)
+		}
+	}
+	if rec := fire(verifyBatch, `[{"msg":"a"}]`); rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" {
+		t.Fatalf("Expected 429 with Retry-After once the bucket is empty, got %d", rec.Code)
+	}
+	log.Printf("✅ Batch and single requests share the client's bucket")
+
+	// A body that isn't an array costs one token and is left for the handler to reject
+	other := NewRateLimiter(rate.Limit(1), 5, false).LimitBatch(next)
+	if rec := fire(other, `not json`); rec.Code != http.StatusOK {
+		t.Fatalf("Expected a malformed body to reach the handler, got %d", rec.Code)
+	}
+	log.Printf("✅ Malformed batch charged one token")
+}
+
+func TestRateLimiter_LimitBatch_DefaultConfig(t *testing.T) {
+	log.Printf("🧪 Starting TestRateLimiter_LimitBatch_DefaultConfig")
+
+	now := time.Unix(1700000000, 0)
+	limiter := NewRateLimiter(DefaultRateLimit, DefaultRateBurst, false)
+	limiter.limiter.now = func() time.Time { return now }
+	verifyBatch := limiter.LimitBatch(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	items := make([]string, MaxVerifyBatchSize)
+	for i := range items {
+		items[i] = `{"msg":"m"}`
+	}
+	batch := "[" + strings.Join(items, ",") + "]"
+	fire := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		verifyBatch.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/verify-batch", strings.NewReader(batch)))
+		return rec
+	}
+
+	// A full batch outgrows the default burst but is charged a full bucket
+	if rec := fire(); rec.Code != http.StatusOK {
+		t.Fatalf("Expected a %d-item batch to pass with the default burst of %d, got %d", MaxVerifyBatchSize, DefaultRateBurst, rec.Code)
+	}
+	rec := fire()
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected the drained bucket to reject the next batch, got %d", rec.Code)
+	}
+	log.Printf("✅ Full batch served, the next rejected with Retry-After %s", rec.Header().Get("Retry-After"))
+
+	// Once the bucket has refilled, the next full batch is served
+	now = now.Add(time.Duration(DefaultRateBurst/DefaultRateLimit) * time.Second)
+	if rec := fire(); rec.Code != http.StatusOK {
+		t.Fatalf("Expected a full batch to pass after the bucket refilled, got %d", rec.Code)
+	}
+	log.Printf("✅ Full batch served again after the bucket refilled")
+}
+
+func TestIPRateLimiter_SweepsIdleClients(t *testing.T) {
+	log.Printf("🧪 Starting TestIPRateLimiter_SweepsIdleClients")
+
+	now := time.Unix(1700000000, 0)
+	limiter := newIPRateLimiter(rate.Limit(5), 10)
+	limiter.now = func() time.Time { return now }
+
+	for _, ip := range []string{"198.51.100.1", "198.51.100.2", "198.51.100.3"} {
+		limiter.allow(ip, 1)
+	}
+	if len(limiter.clients) != 3 {
+		t.Fatalf("Expected 3 tracked clients, got %d", len(limiter.clients))
+	}
+
+	now = now.Add(rateLimiterIdleTTL)
+	limiter.allow("198.51.100.4", 1)
+	if len(limiter.clients) != 1 {
+		t.Fatalf("Expected idle clients to be swept, %d still tracked", len(limiter.clients))
+	}
+	log.Printf("✅ Idle client buckets swept")
+}
+
+func TestClientIP(t *testing.T) {
+	log.Printf("🧪 Starting TestClientIP")
+
+	req := httptest.NewRequest(http.MethodPost, "/verify", nil)
+	req.RemoteAddr = "192.0.2.10:54321"
+	req.Header.Set("X-Forwarded-For", "198.51.100.7")
+
+	if ip := clientIP(req, false); ip != "192.0.2.10" {
+		t.Fatalf("Expected remote address without a trusted proxy, got %s", ip)
+	}
+	if ip := clientIP(req, true); ip != "198.51.100.7" {
+		t.Fatalf("Expected X-Forwarded-For behind a trusted proxy, got %s", ip)
+	}
+	log.Printf("✅ Client IP resolved from remote address and X-Forwarded-For")
+}
+
+func TestRequireAPIKeyMiddleware(t *testing.T) {
+	log.Printf("🧪 Starting TestRequireAPIKeyMiddleware")
+
+	handler := RequireAPIKeyMiddleware(ParseAPIKeys("key-one, key-two,"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, tc := range []struct {
+		name          string
+		authorization string
+		expected      int
+	}{
+		{"missing", "", http.StatusUnauthorized},
+		{"wrong", "Bearer key-three", http.StatusUnauthorized},
+		{"prefix of a valid key", "Bearer key-", http.StatusUnauthorized},
+		{"not a bearer token", "key-one", http.StatusUnauthorized},
+		{"valid", "Bearer key-one", http.StatusOK},
+		{"second valid", "Bearer key-two", http.StatusOK},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/verify", nil)
+		if tc.authorization != "" {
+			req.Header.Set("Authorization", tc.authorization)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != tc.expected {
+			t.Fatalf("%s key: expected %d, got %d", tc.name, tc.expected, rec.Code)
+		}
+		log.Printf("✅ %s key: %d", tc.name, rec.Code)
+	}
+
+	// Preflight requests carry no credentials and must reach the handler's CORS response
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodOptions, "/verify", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected preflight to pass without a key, got %d", rec.Code)
+	}
+	log.Printf("✅ Preflight passed without a key")
+}
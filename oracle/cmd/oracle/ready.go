@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultReadyTimeout bounds the RPC call behind /ready so a hung endpoint fails the probe quickly
+const DefaultReadyTimeout = 3 * time.Second
+
+// ReadinessChecker makes a live call to the upstream RPC endpoint
+type ReadinessChecker interface {
+	CheckReady(ctx context.Context) error
+	RPCURL() string
+}
+
+// ReadyHandler reports whether the oracle can currently reach its RPC endpoint, answering 503
+// with the error when it can't so orchestrators stop routing to it. /health stays a pure liveness check.
+func ReadyHandler(checker ReadinessChecker, timeout time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := checker.CheckReady(ctx); err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			json.NewEncoder(w).Encode(map[string]string{
+				"status":  "unavailable",
+				"error":   err.Error(),
+				"rpc_url": redactURL(checker.RPCURL()),
+			})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]string{"status": "ready"})
+	}
+}
+
+// redactURL hides any password embedded in an endpoint URL
+func redactURL(raw string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return parsed.Redacted()
+}
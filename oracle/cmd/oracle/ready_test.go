@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"oracle/pkg/delegation"
+)
+
+func TestReadyHandler(t *testing.T) {
+	log.Printf("🧪 Starting TestReadyHandler")
+
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","result":{"number":"0x10"},"id":1}`))
+	}))
+	defer healthy.Close()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	}))
+	defer failing.Close()
+
+	cases := []struct {
+		name       string
+		rpcURL     string
+		wantStatus int
+	}{
+		{"healthy RPC", healthy.URL, http.StatusOK},
+		{"failing RPC", failing.URL, http.StatusServiceUnavailable},
+	}
+
+	for _, tc := range cases {
+		verifier := delegation.NewVerifierWithConfig(delegation.VerifierConfig{RPCURL: tc.rpcURL, MaxRetries: -1})
+		handler := ReadyHandler(verifier, time.Second)
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+		if recorder.Code != tc.wantStatus {
+			t.Fatalf("%s: expected status %d, got %d: %s", tc.name, tc.wantStatus, recorder.Code, recorder.Body.String())
+		}
+
+		var body map[string]string
+		if err := json.NewDecoder(recorder.Body).Decode(&body); err != nil {
+			t.Fatalf("%s: failed to decode response: %v", tc.name, err)
+		}
+		if tc.wantStatus == http.StatusOK {
+			if body["status"] != "ready" {
+				t.Fatalf("%s: expected status ready, got %v", tc.name, body)
+			}
+		} else {
+			if body["status"] != "unavailable" || body["error"] == "" || body["rpc_url"] != tc.rpcURL {
+				t.Fatalf("%s: expected the error and RPC URL, got %v", tc.name, body)
+			}
+		}
+		log.Printf("✅ %s: %d %v", tc.name, recorder.Code, body)
+	}
+}
+
+func TestReadyHandler_RedactsRPCCredentials(t *testing.T) {
+	log.Printf("🧪 Starting TestReadyHandler_RedactsRPCCredentials")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	rpcURL := strings.Replace(server.URL, "http://", "http://user:secret@", 1)
+	verifier := delegation.NewVerifierWithConfig(delegation.VerifierConfig{RPCURL: rpcURL, MaxRetries: -1})
+
+	recorder := httptest.NewRecorder()
+	ReadyHandler(verifier, time.Second).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected 503, got %d", recorder.Code)
+	}
+	if strings.Contains(recorder.Body.String(), "secret") {
+		t.Fatalf("Expected the RPC password to be redacted, got %s", recorder.Body.String())
+	}
+	log.Printf("✅ RPC URL redacted: %s", strings.TrimSpace(recorder.Body.String()))
+}
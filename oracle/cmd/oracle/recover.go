@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	signatureverifier "oracle/pkg/signature_verifier"
+)
+
+// RecoverRequest is a signed triplet whose signer should be recovered. Era, Nonce and Deadline
+// are set to whatever the signature commits to; /verify signatures always carry a nonce and deadline.
+type RecoverRequest struct {
+	ValidatorAddress string  `json:"validator_address"`
+	NominatorAddress string  `json:"nominator_address"`
+	Msg              string  `json:"msg"`
+	Signature        string  `json:"signature"`
+	Era              *uint32 `json:"era,omitempty"`
+	Nonce            *uint64 `json:"nonce,omitempty"`
+	Deadline         *int64  `json:"deadline,omitempty"`
+}
+
+// RecoverResponse reports the recovered signer and whether it is this oracle
+type RecoverResponse struct {
+	Address       string `json:"address"`
+	OracleAddress string `json:"oracle_address"`
+	MatchesOracle bool   `json:"matches_oracle"`
+}
+
+// hashingConfig is the part of the signing oracle that determines how triplets are hashed
+type hashingConfig interface {
+	GetAddress() string
+	GetMessagePrefix() string
+	GetDomain() string
+	GetNormalizeMsg() bool
+	GetChainID() uint64
+}
+
+// newTripletVerifier creates a signature verifier that hashes triplets the way the oracle signs them
+func newTripletVerifier(config hashingConfig) (*signatureverifier.OracleVerifiedDelegation, error) {
+	verifier, err := signatureverifier.NewOracleVerifiedDelegationWithPrefix(config.GetAddress(), config.GetMessagePrefix())
+	if err != nil {
+		return nil, err
+	}
+	verifier.PackMode = signatureverifier.PackModeEncoded
+	verifier.Domain = config.GetDomain()
+	verifier.NormalizeNFC = config.GetNormalizeMsg()
+	verifier.ChainID = config.GetChainID()
+	return verifier, nil
+}
+
+// RecoverHandler handles POST /recover, recovering the address that signed a triplet with the
+// oracle's own hashing so operators can see which key produced a signature
+func RecoverHandler(config hashingConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		var req RecoverRequest
+		if errorResp := decodeRequestBody(w, r, &req); errorResp != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(errorResp)
+			return
+		}
+		if req.ValidatorAddress == "" || req.NominatorAddress == "" || req.Msg == "" || req.Signature == "" {
+			http.Error(w, "Missing required fields", http.StatusBadRequest)
+			return
+		}
+
+		verifier, err := newTripletVerifier(config)
+		if err != nil {
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		// Malformed hex and wrong lengths are reported as such, before any hashing
+		if _, _, _, err := signatureverifier.ParseSignature(req.Signature); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(ErrorResponse{
+				Error:   "invalid_signature",
+				Message: fmt.Sprintf("Signature must be 65 hex-encoded bytes, or 64 in the compact EIP-2098 form: %v", err),
+			})
+			return
+		}
+
+		fields := signatureverifier.SignedFields{Era: req.Era, Nonce: req.Nonce, Deadline: req.Deadline}
+		address, err := verifier.RecoverSigner(req.ValidatorAddress, req.NominatorAddress, req.Msg, fields, req.Signature)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(ErrorResponse{
+				Error:   "recovery_failed",
+				Message: fmt.Sprintf("Failed to recover signer: %v", err),
+			})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(RecoverResponse{
+			Address:       address.Hex(),
+			OracleAddress: verifier.GetOracleAddress().Hex(),
+			MatchesOracle: address == verifier.GetOracleAddress(),
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func postRecover(t *testing.T, handler http.Handler, req RecoverRequest) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/recover", bytes.NewReader(body)))
+	return rec
+}
+
+func TestRecoverHandler(t *testing.T) {
+	log.Printf("🧪 Starting TestRecoverHandler")
+
+	oracle := newTestSigningOracle(t)
+	handler := RecoverHandler(oracle)
+
+	// A /verify signature commits to its nonce and deadline
+	signed, err := oracle.SignVerifiedDelegation(selfTestValidator, selfTestNominator, selfTestMsg, nil)
+	if err != nil {
+		t.Fatalf("Failed to sign: %v", err)
+	}
+	req := RecoverRequest{
+		ValidatorAddress: selfTestValidator,
+		NominatorAddress: selfTestNominator,
+		Msg:              selfTestMsg,
+		Signature:        "0x" + hex.EncodeToString(signed.Signature),
+		Nonce:            &signed.Nonce,
+		Deadline:         &signed.Deadline,
+	}
+
+	rec := postRecover(t, handler, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp RecoverResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if !resp.MatchesOracle || !strings.EqualFold(resp.Address, oracle.GetAddress()) {
+		t.Fatalf("Expected the oracle %s to be recovered, got %+v", oracle.GetAddress(), resp)
+	}
+	log.Printf("✅ Recovered oracle %s from a /verify signature", resp.Address)
+
+	// Leaving out the deadline hashes a different message, so some other address is recovered
+	req.Deadline = nil
+	rec = postRecover(t, handler, req)
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if resp.MatchesOracle {
+		t.Fatalf("Expected a different signer when the deadline is left out")
+	}
+	log.Printf("✅ Mismatched fields recover %s, not the oracle", resp.Address)
+
+	// A chain-bound signature recovers under the oracle's own chain ID
+	t.Setenv("CHAIN_ID", "8453")
+	chainBound := newTestSigningOracle(t)
+	signed, err = chainBound.SignVerifiedDelegation(selfTestValidator, selfTestNominator, selfTestMsg, nil)
+	if err != nil {
+		t.Fatalf("Failed to sign: %v", err)
+	}
+	chainReq := RecoverRequest{
+		ValidatorAddress: selfTestValidator,
+		NominatorAddress: selfTestNominator,
+		Msg:              selfTestMsg,
+		Signature:        "0x" + hex.EncodeToString(signed.Signature),
+		Nonce:            &signed.Nonce,
+		Deadline:         &signed.Deadline,
+	}
+	for _, tc := range []struct {
+		name    string
+		handler http.Handler
+		matches bool
+	}{
+		{"same chain", RecoverHandler(chainBound), true},
+		{"unbound oracle", handler, false},
+	} {
+		rec = postRecover(t, tc.handler, chainReq)
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", tc.name, rec.Code, rec.Body.String())
+		}
+		if resp.MatchesOracle != tc.matches {
+			t.Fatalf("%s: expected matches_oracle=%v, got %+v", tc.name, tc.matches, resp)
+		}
+		log.Printf("✅ Chain-bound signature, %s: matches_oracle=%v", tc.name, resp.MatchesOracle)
+	}
+
+	// A plain triplet signature needs no extra fields
+	signature, err := oracle.SignTriplet(selfTestValidator, selfTestNominator, selfTestMsg)
+	if err != nil {
+		t.Fatalf("Failed to sign triplet: %v", err)
+	}
+	rec = postRecover(t, handler, RecoverRequest{
+		ValidatorAddress: selfTestValidator,
+		NominatorAddress: selfTestNominator,
+		Msg:              selfTestMsg,
+		Signature:        hex.EncodeToString(signature),
+	})
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || !resp.MatchesOracle {
+		t.Fatalf("Expected the oracle to be recovered from a triplet signature, got %d: %s", rec.Code, rec.Body.String())
+	}
+	log.Printf("✅ Recovered oracle from a plain triplet signature")
+}
+
+func TestRecoverHandler_MalformedSignature(t *testing.T) {
+	log.Printf("🧪 Starting TestRecoverHandler_MalformedSignature")
+
+	handler := RecoverHandler(newTestSigningOracle(t))
+
+	for _, tc := range []struct {
+		name      string
+		signature string
+	}{
+		{"not hex", "0xzz"},
+		{"too short", "0x" + strings.Repeat("ab", 63)},
+		{"too long", "0x" + strings.Repeat("ab", 66)},
+	} {
+		rec := postRecover(t, handler, RecoverRequest{
+			ValidatorAddress: selfTestValidator,
+			NominatorAddress: selfTestNominator,
+			Msg:              selfTestMsg,
+			Signature:        tc.signature,
+		})
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", tc.name, rec.Code)
+		}
+		var errResp ErrorResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &errResp); err != nil || errResp.Error != "invalid_signature" {
+			t.Fatalf("%s: expected invalid_signature, got %s", tc.name, rec.Body.String())
+		}
+		log.Printf("✅ %s signature rejected: %s", tc.name, errResp.Message)
+	}
+}
+
+func TestRecoverHandler_RejectsMalformedBodies(t *testing.T) {
+	log.Printf("🧪 Starting TestRecoverHandler_RejectsMalformedBodies")
+
+	handler := RecoverHandler(newTestSigningOracle(t))
+
+	cases := []struct {
+		name      string
+		body      []byte
+		wantError string
+	}{
+		{"oversize body", append([]byte(`{"msg":"`), append(bytes.Repeat([]byte("a"), MaxRequestBodyBytes), `"}`...)...), "request_too_large"},
+		{"unknown field", []byte(`{"validator_address":"` + selfTestValidator + `","nominator_address":"` + selfTestNominator + `","msg":"hello","signature":"0x00","era_id":1}`), "invalid_request_body"},
+	}
+
+	for _, tc := range cases {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/recover", bytes.NewReader(tc.body)))
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d: %s", tc.name, rec.Code, rec.Body.String())
+		}
+		var resp ErrorResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("%s: failed to decode error response: %v", tc.name, err)
+		}
+		if resp.Error != tc.wantError || resp.Message == "" {
+			t.Fatalf("%s: expected error %s with a message, got %+v", tc.name, tc.wantError, resp)
+		}
+		log.Printf("✅ %s rejected: %s", tc.name, resp.Message)
+	}
+}
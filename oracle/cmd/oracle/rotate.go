@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+
+	"oracle/pkg/signingoracle"
+)
+
+// RotateKeyRequest names the key to rotate to: either an inline hex private key, or a V3
+// keystore and its password file on the server's filesystem, so the key needn't be sent at all
+type RotateKeyRequest struct {
+	PrivateKey           string `json:"private_key,omitempty"`
+	KeystoreFile         string `json:"keystore_file,omitempty"`
+	KeystorePasswordFile string `json:"keystore_password_file,omitempty"`
+}
+
+// RotateKeyResponse reports the address signatures now recover to and the one they replaced
+type RotateKeyResponse struct {
+	Status          string `json:"status"`
+	Address         string `json:"address"`
+	PreviousAddress string `json:"previous_address"`
+}
+
+// signerFromRotateRequest loads the signer a rotation request names
+func signerFromRotateRequest(req RotateKeyRequest) (signingoracle.Signer, error) {
+	switch {
+	case req.PrivateKey != "" && req.KeystoreFile != "":
+		return nil, fmt.Errorf("set either private_key or keystore_file, not both")
+	case req.PrivateKey != "":
+		return signingoracle.NewLocalSignerFromHex(req.PrivateKey)
+	case req.KeystoreFile != "":
+		password, err := os.ReadFile(req.KeystorePasswordFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read keystore_password_file: %w", err)
+		}
+		return signingoracle.NewKeystoreSigner(req.KeystoreFile, strings.TrimRight(string(password), "\r\n"))
+	default:
+		return nil, fmt.Errorf("private_key or keystore_file is required")
+	}
+}
+
+// AdminRotateKeyHandler handles POST /admin/rotate-key, swapping the oracle's signing key without
+// a restart. Requests already signing complete with the previous key; later ones use the new one.
+func AdminRotateKeyHandler(rotator KeyRotator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		var req RotateKeyRequest
+		if errorResp := decodeRequestBody(w, r, &req); errorResp != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(errorResp)
+			return
+		}
+
+		signer, err := signerFromRotateRequest(req)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(ErrorResponse{
+				Error:   "invalid_key",
+				Message: fmt.Sprintf("Failed to load the new key: %v", err),
+			})
+			return
+		}
+
+		previousAddress, err := rotator.RotateSigner(signer)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to rotate signing key", "event", "key_rotation_failed", "address", signer.Address().Hex(), "error", err)
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(ErrorResponse{
+				Error:   "invalid_key",
+				Message: fmt.Sprintf("The new key was refused: %v", err),
+			})
+			return
+		}
+		slog.WarnContext(r.Context(), "signing key rotated", "event", "key_rotated", "previous_address", previousAddress, "address", signer.Address().Hex())
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(RotateKeyResponse{
+			Status:          "rotated",
+			Address:         signer.Address().Hex(),
+			PreviousAddress: previousAddress,
+		})
+	}
+}
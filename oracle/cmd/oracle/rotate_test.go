@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	signatureverifier "oracle/pkg/signature_verifier"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// postRotateKey posts a rotation request to handler, authenticated with apiKey when it isn't empty
+func postRotateKey(t *testing.T, handler http.Handler, apiKey string, req RotateKeyRequest) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+	httpReq := httptest.NewRequest(http.MethodPost, "/admin/rotate-key", bytes.NewReader(body))
+	if apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httpReq)
+	return rec
+}
+
+func TestAdminRotateKeyHandler(t *testing.T) {
+	log.Printf("🧪 Starting TestAdminRotateKeyHandler")
+
+	oracle := newTestSigningOracle(t)
+	oldAddress := oracle.GetAddress()
+	handler := RequireAPIKeyMiddleware([]string{"secret"})(AdminRotateKeyHandler(oracle))
+
+	newKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	newAddress := crypto.PubkeyToAddress(newKey.PublicKey).Hex()
+	rotation := RotateKeyRequest{PrivateKey: "0x" + hex.EncodeToString(crypto.FromECDSA(newKey))}
+
+	if rec := postRotateKey(t, handler, "", rotation); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 without an API key, got %d", rec.Code)
+	}
+	if oracle.GetAddress() != oldAddress {
+		t.Fatalf("Expected the key to be unchanged after an unauthenticated request")
+	}
+	log.Printf("✅ Unauthenticated rotation refused")
+
+	rec := postRotateKey(t, handler, "secret", rotation)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp RotateKeyResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Address != newAddress || resp.PreviousAddress != oldAddress || oracle.GetAddress() != newAddress {
+		t.Fatalf("Expected a rotation from %s to %s, got %+v", oldAddress, newAddress, resp)
+	}
+	log.Printf("✅ Rotated from %s to %s", resp.PreviousAddress, resp.Address)
+
+	// Signatures made after the rotation recover to the new key only
+	signature, err := oracle.SignTriplet(selfTestValidator, selfTestNominator, "hello")
+	if err != nil {
+		t.Fatalf("Failed to sign after rotation: %v", err)
+	}
+	for address, wantValid := range map[string]bool{newAddress: true, oldAddress: false} {
+		verifier, err := signatureverifier.NewOracleVerifiedDelegation(address)
+		if err != nil {
+			t.Fatalf("Failed to create verifier: %v", err)
+		}
+		verifier.PackMode = signatureverifier.PackModeEncoded
+		err = verifier.SubmitMessage(selfTestValidator, selfTestNominator, "hello", hex.EncodeToString(signature))
+		if (err == nil) != wantValid {
+			t.Fatalf("Expected the signature to verify against %s: %v, got: %v", address, wantValid, err)
+		}
+	}
+	log.Printf("✅ New signatures recover to %s", newAddress)
+
+	for name, req := range map[string]RotateKeyRequest{
+		"no key":        {},
+		"malformed key": {PrivateKey: "0xnothex"},
+		"two keys":      {PrivateKey: rotation.PrivateKey, KeystoreFile: "/etc/oracle/keystore.json"},
+	} {
+		if rec := postRotateKey(t, handler, "secret", req); rec.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d: %s", name, rec.Code, rec.Body.String())
+		}
+		log.Printf("✅ %s refused", name)
+	}
+	if oracle.GetAddress() != newAddress {
+		t.Fatalf("Expected refused rotations to keep the key %s, got %s", newAddress, oracle.GetAddress())
+	}
+}
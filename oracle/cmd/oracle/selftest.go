@@ -0,0 +1,48 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"oracle/pkg/signingoracle"
+)
+
+// Canonical triplet signed and verified by the startup self-test
+const (
+	selfTestValidator = "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+	selfTestNominator = "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty"
+	selfTestMsg       = "oracle startup self-test"
+	selfTestEra       = uint32(1)
+)
+
+// tripletSigner is the part of the signing oracle exercised by the self-test
+type tripletSigner interface {
+	hashingConfig
+	SignVerifiedDelegation(validator, nominator, msg string, era *uint32) (*signingoracle.SignedDelegation, error)
+}
+
+// runSelfTest signs the canonical triplet with the loaded key the way /verify does, with and
+// without an era, and verifies each signature the way the contract's submitMessage does, using
+// OracleVerifiedDelegation configured with the oracle's own address. Each signature consumes a
+// nonce of the self-test nominator.
+func runSelfTest(signer tripletSigner) error {
+	verifier, err := newTripletVerifier(signer)
+	if err != nil {
+		return fmt.Errorf("failed to create self-test verifier: %w", err)
+	}
+
+	bound := selfTestEra
+	for _, era := range []*uint32{nil, &bound} {
+		signed, err := signer.SignVerifiedDelegation(selfTestValidator, selfTestNominator, selfTestMsg, era)
+		if err != nil {
+			return fmt.Errorf("failed to sign self-test triplet: %w", err)
+		}
+
+		signatureHex := hex.EncodeToString(signed.Signature)
+		if err := verifier.SubmitVerifiedDelegation(selfTestValidator, selfTestNominator, selfTestMsg, era, signed.Nonce, signed.Deadline, signatureHex); err != nil {
+			return fmt.Errorf("self-test signature did not verify: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	"oracle/pkg/signingoracle"
+)
+
+// mismatchedSigner signs with one oracle but reports another oracle's address
+type mismatchedSigner struct {
+	*signingoracle.SigningOracle
+	address string
+}
+
+func (s mismatchedSigner) GetAddress() string {
+	return s.address
+}
+
+func newTestSigningOracle(t *testing.T) *signingoracle.SigningOracle {
+	t.Helper()
+
+	os.Setenv("PRIVATE_KEY", "1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")
+	defer os.Unsetenv("PRIVATE_KEY")
+
+	oracle, err := signingoracle.NewSigningOracle()
+	if err != nil {
+		t.Fatalf("Failed to create signing oracle: %v", err)
+	}
+	return oracle
+}
+
+func TestRunSelfTest_Passes(t *testing.T) {
+	log.Printf("🧪 Starting TestRunSelfTest_Passes")
+
+	oracle := newTestSigningOracle(t)
+	if err := runSelfTest(oracle); err != nil {
+		t.Fatalf("Expected self-test to pass, got: %v", err)
+	}
+	// The self-test signs as /verify does, each signature under a fresh nonce
+	if last := oracle.LastNonce(selfTestNominator); last != 2 {
+		t.Fatalf("Expected the self-test to sign under nonces 1 and 2, last nonce is %d", last)
+	}
+	log.Printf("✅ Self-test passed")
+}
+
+func TestRunSelfTest_FailsOnAddressMismatch(t *testing.T) {
+	log.Printf("🧪 Starting TestRunSelfTest_FailsOnAddressMismatch")
+
+	signer := mismatchedSigner{
+		SigningOracle: newTestSigningOracle(t),
+		address:       "0xb513496Cf374fbDF37F370d841A6F9023f68F4b0",
+	}
+
+	if err := runSelfTest(signer); err == nil {
+		t.Fatalf("Expected self-test to fail when the signing key doesn't match the address")
+	} else {
+		log.Printf("✅ Self-test failed as expected: %v", err)
+	}
+}
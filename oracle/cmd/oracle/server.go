@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+)
+
+// DefaultShutdownGrace is how long in-flight requests may take to finish once shutdown starts
+const DefaultShutdownGrace = 15 * time.Second
+
+// runServer serves handler on listener until ctx is cancelled, then stops accepting connections
+// and waits up to grace for in-flight requests to finish. It returns nil on a clean shutdown and
+// an error when the server fails or requests are still running after the grace period.
+func runServer(ctx context.Context, listener net.Listener, handler http.Handler, grace time.Duration) error {
+	server := &http.Server{Handler: handler}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("server failed: %w", err)
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down, draining in-flight requests", "event", "shutdown_started", "grace_ms", grace.Milliseconds())
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), grace)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutdown did not complete within %s: %w", grace, err)
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("server failed: %w", err)
+	}
+
+	slog.Info("in-flight requests drained, server stopped", "event", "shutdown_complete")
+	return nil
+}
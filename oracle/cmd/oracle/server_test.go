@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// slowHandler signals when a request arrives and answers once release is closed
+func slowHandler(started chan<- struct{}, release <-chan struct{}) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		io.WriteString(w, "done")
+	})
+}
+
+func TestRunServer_DrainsInFlightRequests(t *testing.T) {
+	log.Printf("🧪 Starting TestRunServer_DrainsInFlightRequests")
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- runServer(ctx, listener, slowHandler(started, release), 5*time.Second)
+	}()
+
+	responded := make(chan string, 1)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String() + "/verify")
+		if err != nil {
+			responded <- "error: " + err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		responded <- string(body)
+	}()
+
+	<-started
+	cancel()
+
+	// The in-flight request holds the shutdown open until it completes
+	select {
+	case err := <-stopped:
+		t.Fatalf("Server stopped with a request still in flight: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	if body := <-responded; body != "done" {
+		t.Fatalf("Expected the in-flight request to complete, got %q", body)
+	}
+	if err := <-stopped; err != nil {
+		t.Fatalf("Expected a clean shutdown, got: %v", err)
+	}
+	log.Printf("✅ In-flight request drained and server shut down cleanly")
+}
+
+func TestRunServer_ShutdownTimeout(t *testing.T) {
+	log.Printf("🧪 Starting TestRunServer_ShutdownTimeout")
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	defer close(release)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- runServer(ctx, listener, slowHandler(started, release), 50*time.Millisecond)
+	}()
+
+	go http.Get("http://" + listener.Addr().String() + "/verify")
+	<-started
+	cancel()
+
+	if err := <-stopped; err == nil {
+		t.Fatalf("Expected shutdown to time out with a request still in flight")
+	} else {
+		log.Printf("✅ Shutdown timed out: %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+
+	"oracle/pkg/delegation"
+	"oracle/pkg/signingoracle"
+)
+
+// MessageSigner is the signing side of the oracle that the HTTP handlers depend on
+type MessageSigner interface {
+	// SignVerifiedDelegation signs a (validator, nominator, msg) triplet whose delegation has
+	// already been verified under the nominator's next nonce and a fresh deadline, committing to
+	// era when it is non-nil
+	SignVerifiedDelegation(validator, nominator, msg string, era *uint32) (*signingoracle.SignedDelegation, error)
+	// Address returns the Ethereum address contracts should accept signatures from
+	Address() string
+}
+
+// AttestationIssuer is implemented by signers that can mint JWT attestations of a verification
+type AttestationIssuer interface {
+	IssueAttestation(result delegation.DelegationVerificationResult) (string, error)
+}
+
+// BlockSigner is implemented by signers that can bind a triplet to the block its delegation was
+// confirmed at
+type BlockSigner interface {
+	SignTripletAtBlock(validator, nominator, msg, blockHash string) ([]byte, error)
+}
+
+// KeyRotator is implemented by signers whose key can be replaced at runtime
+type KeyRotator interface {
+	RotateSigner(signer signingoracle.Signer) (previousAddress string, err error)
+}
+
+// DelegationSigner verifies delegations on-chain and signs the ones that pass, as /verify does
+type DelegationSigner interface {
+	VerifyAndSignWithOptions(ctx context.Context, validator, nominator, msg string, opts signingoracle.VerifyOptions) (*signingoracle.Response, error)
+}
+
+// DelegationChecker verifies nominations on-chain before anything is signed
+type DelegationChecker = signingoracle.DelegationChecker
+
+var (
+	_ MessageSigner     = (*signingoracle.SigningOracle)(nil)
+	_ DelegationSigner  = (*signingoracle.SigningOracle)(nil)
+	_ AttestationIssuer = (*signingoracle.SigningOracle)(nil)
+	_ BlockSigner       = (*signingoracle.SigningOracle)(nil)
+	_ KeyRotator        = (*signingoracle.SigningOracle)(nil)
+	_ DelegationChecker = (*delegation.Verifier)(nil)
+	_ ProgressVerifier  = (*delegation.Verifier)(nil)
+	_ HealthStatus      = (*delegation.Verifier)(nil)
+	_ ReadinessChecker  = (*delegation.Verifier)(nil)
+	_ NominationsReader = (*delegation.Verifier)(nil)
+)
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"oracle/pkg/delegation"
+)
+
+// ProgressVerifier runs a delegation verification while reporting each stage that passes
+type ProgressVerifier interface {
+	VerifyV2WithProgress(ctx context.Context, nominatorAddress, validatorAddress string, progress func(delegation.VerifyStage)) (*delegation.DelegationVerificationResult, error)
+}
+
+// writeSSE writes a single Server-Sent Event with a JSON payload and flushes it to the client
+func writeSSE(w http.ResponseWriter, flusher http.Flusher, event string, data interface{}) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		slog.Error("failed to encode stream event", "event", "encode_failed", "stream_event", event, "error", err)
+		return
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+	flusher.Flush()
+}
+
+// StreamVerifyHandler handles GET /verify-delegation/stream?nominator=...&validator=...
+// It emits address_ok, storage_ok and era_ok events as VerifyV2's stages pass, followed by a
+// done event carrying the full result. A client disconnect cancels the verification.
+func StreamVerifyHandler(verifier ProgressVerifier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		nominator := r.URL.Query().Get("nominator")
+		validator := r.URL.Query().Get("validator")
+		if nominator == "" || validator == "" {
+			http.Error(w, "Missing nominator or validator query parameter", http.StatusBadRequest)
+			return
+		}
+
+		// Reject malformed addresses before opening the stream, as /verify does
+		if errorResp := validateAddresses(Request{NominatorAddress: nominator, ValidatorAddress: validator}); errorResp != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(errorResp)
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		// The request context is cancelled when the client goes away
+		ctx := r.Context()
+
+		result, err := verifier.VerifyV2WithProgress(ctx, nominator, validator, func(stage delegation.VerifyStage) {
+			writeSSE(w, flusher, string(stage), map[string]bool{"ok": true})
+		})
+		if ctx.Err() != nil {
+			slog.DebugContext(ctx, "client disconnected, verification stream cancelled", "event", "stream_cancelled", "nominator", nominator, "validator", validator)
+			return
+		}
+		if err != nil {
+			writeSSE(w, flusher, "error", ErrorResponse{
+				Error:   "verification_failed",
+				Message: fmt.Sprintf("Failed to verify delegation: %v", err),
+			})
+			return
+		}
+
+		writeSSE(w, flusher, "done", result)
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"oracle/pkg/delegation"
+)
+
+// fakeProgressVerifier reports every stage and returns a valid result, or blocks until
+// cancelled when block is set
+type fakeProgressVerifier struct {
+	block     bool
+	cancelled chan struct{}
+}
+
+func (f *fakeProgressVerifier) VerifyV2WithProgress(ctx context.Context, nominatorAddress, validatorAddress string, progress func(delegation.VerifyStage)) (*delegation.DelegationVerificationResult, error) {
+	progress(delegation.StageAddressOK)
+	if f.block {
+		<-ctx.Done()
+		close(f.cancelled)
+		return nil, ctx.Err()
+	}
+	progress(delegation.StageStorageOK)
+	progress(delegation.StageEraOK)
+	return &delegation.DelegationVerificationResult{
+		NominatorAddress: nominatorAddress,
+		ValidatorAddress: validatorAddress,
+		IsValid:          true,
+	}, nil
+}
+
+// readSSE reads events from an SSE stream until it ends, returning event names and data lines
+func readSSE(t *testing.T, scanner *bufio.Scanner) (events []string, data []string) {
+	t.Helper()
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			events = append(events, strings.TrimPrefix(line, "event: "))
+		case strings.HasPrefix(line, "data: "):
+			data = append(data, strings.TrimPrefix(line, "data: "))
+		}
+	}
+	return events, data
+}
+
+func TestStreamVerifyHandler_EmitsStages(t *testing.T) {
+	log.Printf("🧪 Starting TestStreamVerifyHandler_EmitsStages")
+
+	server := httptest.NewServer(StreamVerifyHandler(&fakeProgressVerifier{}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "?nominator=" + selfTestNominator + "&validator=" + selfTestValidator)
+	if err != nil {
+		t.Fatalf("Failed to open stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Expected text/event-stream, got %q", ct)
+	}
+
+	events, data := readSSE(t, bufio.NewScanner(resp.Body))
+	log.Printf("📋 Events: %v", events)
+
+	expected := []string{"address_ok", "storage_ok", "era_ok", "done"}
+	if strings.Join(events, ",") != strings.Join(expected, ",") {
+		t.Fatalf("Expected events %v, got %v", expected, events)
+	}
+
+	var result delegation.DelegationVerificationResult
+	if err := json.Unmarshal([]byte(data[len(data)-1]), &result); err != nil {
+		t.Fatalf("Failed to decode done payload: %v", err)
+	}
+	if !result.IsValid || result.NominatorAddress != selfTestNominator {
+		t.Errorf("Unexpected result in done event: %+v", result)
+	}
+	log.Printf("✅ Stream emitted every stage and the final result")
+}
+
+func TestStreamVerifyHandler_MissingParams(t *testing.T) {
+	log.Printf("🧪 Starting TestStreamVerifyHandler_MissingParams")
+
+	rec := httptest.NewRecorder()
+	StreamVerifyHandler(&fakeProgressVerifier{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/verify-delegation/stream?nominator=x", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected 400, got %d", rec.Code)
+	}
+	log.Printf("✅ Missing query parameters rejected")
+}
+
+func TestStreamVerifyHandler_InvalidAddress(t *testing.T) {
+	log.Printf("🧪 Starting TestStreamVerifyHandler_InvalidAddress")
+
+	rec := httptest.NewRecorder()
+	StreamVerifyHandler(&fakeProgressVerifier{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/verify-delegation/stream?nominator=not-an-address&validator="+selfTestValidator, nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected 400, got %d", rec.Code)
+	}
+
+	var errorResp ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &errorResp); err != nil {
+		t.Fatalf("Failed to decode error response: %v", err)
+	}
+	if errorResp.Error != "invalid_nominator_address" {
+		t.Fatalf("Expected invalid_nominator_address, got %q", errorResp.Error)
+	}
+	log.Printf("✅ Malformed nominator rejected before streaming: %s", errorResp.Message)
+}
+
+func TestStreamVerifyHandler_ClientDisconnectCancels(t *testing.T) {
+	log.Printf("🧪 Starting TestStreamVerifyHandler_ClientDisconnectCancels")
+
+	verifier := &fakeProgressVerifier{block: true, cancelled: make(chan struct{})}
+	server := httptest.NewServer(StreamVerifyHandler(verifier))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"?nominator="+selfTestNominator+"&validator="+selfTestValidator, nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to open stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// Wait for the first event, then disconnect
+	scanner := bufio.NewScanner(resp.Body)
+	if !scanner.Scan() || scanner.Text() != "event: address_ok" {
+		t.Fatalf("Expected address_ok event, got %q", scanner.Text())
+	}
+	cancel()
+
+	select {
+	case <-verifier.cancelled:
+		log.Printf("✅ Verification cancelled after client disconnect")
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Expected verification context to be cancelled on disconnect")
+	}
+}
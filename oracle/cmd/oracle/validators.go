@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"oracle/pkg/delegation"
+)
+
+// NominationsReader reads the validators a nominator currently nominates, and when the era they
+// were submitted in started
+type NominationsReader interface {
+	GetNominatedValidators(ctx context.Context, nominatorAddress string) (*delegation.NominatedValidators, error)
+	EraToTime(ctx context.Context, era uint32) (time.Time, error)
+}
+
+// ValidatorsResponse lists the validators a nominator currently nominates
+type ValidatorsResponse struct {
+	NominatorAddress string   `json:"nominator_address"`
+	Validators       []string `json:"validators"`
+	SubmittedIn      uint32   `json:"submitted_in"`
+	// SubmittedAt is approximately when the SubmittedIn era started, omitted when it can't be placed in time
+	SubmittedAt *time.Time `json:"submitted_at,omitempty"`
+	Suppressed  bool       `json:"suppressed"`
+}
+
+// ValidatorsHandler handles GET /validators?nominator=...
+// It responds with the nominator's current targets, SS58-encoded for the configured network, and
+// the era they were submitted in and roughly when, or 404 when the nominator has no nominations.
+// Lookup failures are reported as /verify reports them. Failing to place the era in time only
+// drops submitted_at.
+func ValidatorsHandler(reader NominationsReader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+
+		nominator := r.URL.Query().Get("nominator")
+		if nominator == "" {
+			http.Error(w, "Missing nominator query parameter", http.StatusBadRequest)
+			return
+		}
+		if errorResp := validateAddress(nominator, "nominator address", "invalid_nominator_address"); errorResp != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(errorResp)
+			return
+		}
+
+		nominations, err := reader.GetNominatedValidators(r.Context(), nominator)
+		if err != nil {
+			status, errorCode := verificationErrorStatus(err)
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(ErrorResponse{
+				Error:   errorCode,
+				Message: fmt.Sprintf("Failed to read nominations: %v", err),
+			})
+			return
+		}
+		if nominations == nil {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(ErrorResponse{
+				Error:   "no_nominations",
+				Message: "The nominator has no nominations",
+			})
+			return
+		}
+
+		response := ValidatorsResponse{
+			NominatorAddress: nominator,
+			Validators:       nominations.Validators,
+			SubmittedIn:      nominations.SubmittedIn,
+			Suppressed:       nominations.Suppressed,
+		}
+		if submittedAt, err := reader.EraToTime(r.Context(), nominations.SubmittedIn); err != nil {
+			slog.WarnContext(r.Context(), "failed to place nomination era in time", "event", "era_to_time_failed", "era", nominations.SubmittedIn, "error", err)
+		} else {
+			response.SubmittedAt = &submittedAt
+		}
+		json.NewEncoder(w).Encode(response)
+	}
+}
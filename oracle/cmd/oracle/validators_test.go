@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"oracle/pkg/delegation"
+)
+
+func TestValidatorsHandler(t *testing.T) {
+	log.Printf("🧪 Starting TestValidatorsHandler")
+
+	validatorID, _, _ := delegation.DecodeSS58(selfTestValidator)
+	nominating := newStakingRPCServer(t, validatorID)
+
+	// A chain where no account is bonded or has a Staking.Nominators entry
+	empty := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&request)
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": request["id"], "result": nil}
+		if request["method"] == "chain_getFinalizedHead" {
+			response["result"] = "0x" + "ab"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer empty.Close()
+
+	cases := []struct {
+		name       string
+		rpcURL     string
+		nominator  string
+		wantStatus int
+	}{
+		{"nominator with targets", nominating.URL, selfTestNominator, http.StatusOK},
+		{"unbonded nominator", empty.URL, selfTestNominator, http.StatusBadRequest},
+		{"missing nominator", nominating.URL, "", http.StatusBadRequest},
+		{"invalid nominator", nominating.URL, "not-an-address", http.StatusBadRequest},
+	}
+
+	for _, tc := range cases {
+		verifier := delegation.NewVerifierWithConfig(delegation.VerifierConfig{RPCURL: tc.rpcURL, Network: delegation.Substrate, MaxRetries: -1})
+
+		recorder := httptest.NewRecorder()
+		ValidatorsHandler(verifier).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/validators?nominator="+url.QueryEscape(tc.nominator), nil))
+		if recorder.Code != tc.wantStatus {
+			t.Fatalf("%s: expected status %d, got %d: %s", tc.name, tc.wantStatus, recorder.Code, recorder.Body.String())
+		}
+
+		if tc.wantStatus == http.StatusOK {
+			var resp ValidatorsResponse
+			if err := json.NewDecoder(recorder.Body).Decode(&resp); err != nil {
+				t.Fatalf("%s: failed to decode response: %v", tc.name, err)
+			}
+			// The target is re-encoded with the configured network's prefix
+			if len(resp.Validators) != 1 || resp.Validators[0] != selfTestValidator || resp.SubmittedIn != 9 || resp.NominatorAddress != selfTestNominator {
+				t.Fatalf("%s: expected %s nominated in era 9, got %+v", tc.name, selfTestValidator, resp)
+			}
+			// Substrate's session timing is unknown, so the past era can't be placed in time
+			if resp.SubmittedAt != nil {
+				t.Fatalf("%s: expected submitted_at to be omitted, got %s", tc.name, resp.SubmittedAt)
+			}
+		}
+		log.Printf("✅ %s: %d %s", tc.name, recorder.Code, recorder.Body.String())
+	}
+}
+
+// fakeNominationsReader reports fixed nominations or a fixed error, placing every era at eraStart
+type fakeNominationsReader struct {
+	nominations *delegation.NominatedValidators
+	err         error
+	eraStart    time.Time
+}
+
+func (f fakeNominationsReader) GetNominatedValidators(ctx context.Context, nominatorAddress string) (*delegation.NominatedValidators, error) {
+	return f.nominations, f.err
+}
+
+func (f fakeNominationsReader) EraToTime(ctx context.Context, era uint32) (time.Time, error) {
+	return f.eraStart, nil
+}
+
+func TestValidatorsHandler_SubmittedAt(t *testing.T) {
+	log.Printf("🧪 Starting TestValidatorsHandler_SubmittedAt")
+
+	eraStart := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	reader := fakeNominationsReader{
+		nominations: &delegation.NominatedValidators{Validators: []string{selfTestValidator}, SubmittedIn: 9},
+		eraStart:    eraStart,
+	}
+
+	recorder := httptest.NewRecorder()
+	ValidatorsHandler(reader).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/validators?nominator="+url.QueryEscape(selfTestNominator), nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var body map[string]interface{}
+	if err := json.NewDecoder(recorder.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if body["submitted_at"] != "2025-06-01T12:00:00Z" {
+		t.Fatalf("Expected submitted_at to be the era's start, got %v", body["submitted_at"])
+	}
+	log.Printf("✅ Nomination era placed in time: %v", body["submitted_at"])
+}
+
+func TestValidatorsHandler_ErrorStatus(t *testing.T) {
+	log.Printf("🧪 Starting TestValidatorsHandler_ErrorStatus")
+
+	cases := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{"no nominations", nil, http.StatusNotFound, "no_nominations"},
+		{"not bonded", fmt.Errorf("%w: neither a stash nor a controller", delegation.ErrNotBonded), http.StatusBadRequest, "nominator_not_bonded"},
+		{"invalid address", &delegation.AddressError{Address: "x", Err: errors.New("bad checksum")}, http.StatusBadRequest, "invalid_address"},
+		{"rpc unavailable", fmt.Errorf("%w: connection refused", delegation.ErrRPCUnavailable), http.StatusBadGateway, "upstream_rpc_failed"},
+		{"other failure", errors.New("decode failed"), http.StatusInternalServerError, "verification_failed"},
+	}
+
+	for _, tc := range cases {
+		recorder := httptest.NewRecorder()
+		ValidatorsHandler(fakeNominationsReader{err: tc.err}).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/validators?nominator="+url.QueryEscape(selfTestNominator), nil))
+		if recorder.Code != tc.wantStatus {
+			t.Fatalf("%s: expected status %d, got %d: %s", tc.name, tc.wantStatus, recorder.Code, recorder.Body.String())
+		}
+
+		var errorResp ErrorResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&errorResp); err != nil {
+			t.Fatalf("%s: failed to decode error response: %v", tc.name, err)
+		}
+		if errorResp.Error != tc.wantError {
+			t.Fatalf("%s: expected error %q, got %q", tc.name, tc.wantError, errorResp.Error)
+		}
+		log.Printf("✅ %s: %d %s", tc.name, recorder.Code, errorResp.Error)
+	}
+}
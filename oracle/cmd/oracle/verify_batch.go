@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"oracle/pkg/signingoracle"
+)
+
+// MaxVerifyBatchSize caps the items a single /verify-batch request may carry
+const MaxVerifyBatchSize = 50
+
+// BatchItemResult is the outcome of one /verify-batch item: a signature when Status is "ok",
+// otherwise the error that stopped it
+type BatchItemResult struct {
+	ValidatorAddress string  `json:"validator_address"`
+	NominatorAddress string  `json:"nominator_address"`
+	Msg              string  `json:"msg"`
+	Status           string  `json:"status"`
+	Signature        string  `json:"signature,omitempty"`
+	Era              *uint32 `json:"era,omitempty"`
+	Nonce            uint64  `json:"nonce,omitempty"`
+	Deadline         int64   `json:"deadline,omitempty"`
+	Error            string  `json:"error,omitempty"`
+	Message          string  `json:"message,omitempty"`
+}
+
+// VerifyBatchHandler handles the /verify-batch endpoint, which verifies and signs a JSON array of
+// requests. Each item succeeds or fails on its own and the response is always 200 with one result
+// per item, in order. Items are verified and signed as /verify does them. With ?bind_era=true every
+// signature commits to the same active era, looked up once through verifier.
+func VerifyBatchHandler(signer DelegationSigner, verifier DelegationChecker, denyList *DenyList) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID")
+		w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID")
+
+		if r.Method == "OPTIONS" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		if r.Method != "POST" {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var reqs []Request
+		if errorResp := decodeRequestBody(w, r, &reqs); errorResp != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(errorResp)
+			return
+		}
+		if len(reqs) == 0 {
+			http.Error(w, "Empty batch", http.StatusBadRequest)
+			return
+		}
+		if len(reqs) > MaxVerifyBatchSize {
+			errorResp := ErrorResponse{
+				Error:   "batch_too_large",
+				Message: fmt.Sprintf("A batch may contain at most %d items, got %d", MaxVerifyBatchSize, len(reqs)),
+			}
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(errorResp)
+			return
+		}
+
+		ctx := r.Context()
+
+		// Every item in the batch commits to the same era, so look it up once
+		var era *uint32
+		var eraErr error
+		if r.URL.Query().Get("bind_era") == "true" {
+			activeEra, err := verifier.ActiveEra(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to look up active era", "event", "era_lookup_failed", "error", err)
+				eraErr = err
+			} else {
+				era = &activeEra
+			}
+		}
+
+		results := make([]BatchItemResult, 0, len(reqs))
+		for _, req := range reqs {
+			result := BatchItemResult{
+				ValidatorAddress: req.ValidatorAddress,
+				NominatorAddress: req.NominatorAddress,
+				Msg:              req.Msg,
+				Status:           "error",
+			}
+
+			addressErr := validateAddresses(req)
+			switch {
+			case req.ValidatorAddress == "" || req.NominatorAddress == "" || req.Msg == "":
+				result.Error = "missing_fields"
+				result.Message = "Missing required fields"
+			case addressErr != nil:
+				result.Error = addressErr.Error
+				result.Message = addressErr.Message
+			case eraErr != nil:
+				result.Error = "era_lookup_failed"
+				result.Message = fmt.Sprintf("Failed to look up active era: %v", eraErr)
+			default:
+				if errorResp := checkDenyList(ctx, denyList, req); errorResp != nil {
+					result.Error = errorResp.Error
+					result.Message = errorResp.Message
+					break
+				}
+
+				signed, err := signer.VerifyAndSignWithOptions(ctx, req.ValidatorAddress, req.NominatorAddress, req.Msg, signingoracle.VerifyOptions{Era: era})
+				if errors.Is(err, signingoracle.ErrSigningFailed) {
+					slog.ErrorContext(ctx, "failed to sign triplet", "event", "signing_failed", "nominator", req.NominatorAddress, "validator", req.ValidatorAddress, "error", err)
+					signingErrorsTotal.Inc()
+					result.Error = "signing_failed"
+					result.Message = "Internal server error"
+					break
+				}
+				if err != nil {
+					_, errorResp := delegationErrorResponse(ctx, req, err)
+					result.Error = errorResp.Error
+					result.Message = errorResp.Message
+					break
+				}
+
+				result.Status = "ok"
+				result.Signature = fmt.Sprintf("0x%x", signed.Signature)
+				result.Era = signed.Era
+				result.Nonce = signed.Nonce
+				result.Deadline = signed.Deadline
+			}
+
+			results = append(results, result)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(results); err != nil {
+			slog.ErrorContext(ctx, "failed to encode batch response", "event", "encode_failed", "error", err)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"oracle/pkg/delegation"
+)
+
+// perNominatorChecker reports a delegation only for the listed nominators
+type perNominatorChecker struct {
+	fakeChecker
+	delegatedNominators map[string]bool
+}
+
+func (c perNominatorChecker) VerifyDelegationDetail(ctx context.Context, nominatorAddress, validatorAddress string) (*delegation.VerificationResult, error) {
+	return fakeVerification(nominatorAddress, validatorAddress, c.delegatedNominators[nominatorAddress], nil), nil
+}
+
+func postVerifyBatch(t *testing.T, handler http.Handler, target string, reqs []Request) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, err := json.Marshal(reqs)
+	if err != nil {
+		t.Fatalf("Failed to marshal batch: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, target, bytes.NewReader(body)))
+	return rec
+}
+
+func TestVerifyBatchHandler_PerItemResults(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyBatchHandler_PerItemResults")
+
+	checker := perNominatorChecker{
+		fakeChecker:         fakeChecker{era: 1523},
+		delegatedNominators: map[string]bool{selfTestNominator: true},
+	}
+	signer := newCheckedSigningOracle(t, checker)
+
+	reqs := []Request{
+		testVerifyRequest,
+		{ValidatorAddress: selfTestValidator, NominatorAddress: "5DAAnrj7VHTznn2AWBemMuyBwZWs6FNFjdyVXUeYum3PTXFy", Msg: "hello"},
+		{ValidatorAddress: selfTestValidator, NominatorAddress: selfTestNominator},
+		{ValidatorAddress: selfTestValidator, NominatorAddress: selfTestNominator, Msg: "again"},
+	}
+	rec := postVerifyBatch(t, VerifyBatchHandler(signer, checker, nil), "/verify-batch?bind_era=true", reqs)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var results []BatchItemResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &results); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(results) != len(reqs) {
+		t.Fatalf("Expected %d results, got %d", len(reqs), len(results))
+	}
+
+	expected := []struct {
+		status string
+		error  string
+	}{
+		{"ok", ""},
+		{"error", "delegation_not_found"},
+		{"error", "missing_fields"},
+		{"ok", ""},
+	}
+	for i, want := range expected {
+		got := results[i]
+		if got.Status != want.status || got.Error != want.error {
+			t.Errorf("Item %d: expected %s/%q, got %s/%q", i, want.status, want.error, got.Status, got.Error)
+		}
+		if want.status == "ok" && (len(got.Signature) != len("0x")+2*65 || got.Era == nil || *got.Era != 1523) {
+			t.Errorf("Item %d: expected an era-bound signature, got %+v", i, got)
+		}
+		if want.status == "ok" {
+			response := Response{ValidatorAddress: got.ValidatorAddress, NominatorAddress: got.NominatorAddress, Msg: got.Msg, Signature: got.Signature, Era: got.Era, Nonce: got.Nonce, Deadline: got.Deadline}
+			if recovered := responseSigner(t, response); recovered != signer.GetAddress() {
+				t.Errorf("Item %d: expected the signature to commit to era 1523, recovered %s", i, recovered)
+			}
+		}
+		if want.status == "error" && got.Signature != "" {
+			t.Errorf("Item %d: failed item must not carry a signature", i)
+		}
+	}
+	if results[3].Nonce != 2 {
+		t.Errorf("Expected items after a failure to still be signed under the next nonce, got %d", results[3].Nonce)
+	}
+	log.Printf("✅ Batch returned per-item results with failures isolated")
+}
+
+func TestVerifyBatchHandler_BondBelowThreshold(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyBatchHandler_BondBelowThreshold")
+
+	checker := fakeChecker{delegated: true, bonded: big.NewInt(5), minBonded: big.NewInt(10)}
+	rec := postVerifyBatch(t, VerifyBatchHandler(newCheckedSigningOracle(t, checker), checker, nil), "/verify-batch", []Request{testVerifyRequest})
+
+	var results []BatchItemResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &results); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if rec.Code != http.StatusOK || len(results) != 1 || results[0].Error != "bond_below_threshold" {
+		t.Fatalf("Expected a 200 with a bond_below_threshold item, got %d: %s", rec.Code, rec.Body.String())
+	}
+	log.Printf("✅ Bond threshold reported per item")
+}
+
+func TestVerifyBatchHandler_TooLarge(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyBatchHandler_TooLarge")
+
+	reqs := make([]Request, MaxVerifyBatchSize+1)
+	for i := range reqs {
+		reqs[i] = testVerifyRequest
+	}
+	signer := newCheckedSigningOracle(t, fakeChecker{delegated: true})
+	rec := postVerifyBatch(t, VerifyBatchHandler(signer, fakeChecker{delegated: true}, nil), "/verify-batch", reqs)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected 400, got %d", rec.Code)
+	}
+	var errorResp ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &errorResp); err != nil || errorResp.Error != "batch_too_large" {
+		t.Fatalf("Expected batch_too_large, got %s", rec.Body.String())
+	}
+	if signer.LastNonce(testVerifyRequest.NominatorAddress) != 0 {
+		t.Fatalf("Expected nothing to be signed for an oversized batch")
+	}
+	log.Printf("✅ Oversized batch rejected: %s", errorResp.Message)
+
+	rec = postVerifyBatch(t, VerifyBatchHandler(signer, fakeChecker{delegated: true}, nil), "/verify-batch", reqs[:MaxVerifyBatchSize])
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected a full batch of %d to be accepted, got %d", MaxVerifyBatchSize, rec.Code)
+	}
+	log.Printf("✅ Batch of %d accepted", MaxVerifyBatchSize)
+}
+
+func TestVerifyBatchHandler_RejectsMalformedBodies(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyBatchHandler_RejectsMalformedBodies")
+
+	cases := []struct {
+		name      string
+		body      []byte
+		wantError string
+	}{
+		{"oversize body", append([]byte(`[{"msg":"`), append(bytes.Repeat([]byte("a"), MaxRequestBodyBytes), `"}]`...)...), "request_too_large"},
+		{"unknown field", []byte(`[{"validator_address":"` + selfTestValidator + `","nominator_address":"` + selfTestNominator + `","msg":"hello","nominator":"typo"}]`), "invalid_request_body"},
+	}
+
+	for _, tc := range cases {
+		// Served behind the batch rate limiter, which reads the body first, as in main
+		signer := newCheckedSigningOracle(t, fakeChecker{delegated: true})
+		handler := NewRateLimiter(DefaultRateLimit, DefaultRateBurst, false).LimitBatch(VerifyBatchHandler(signer, fakeChecker{delegated: true}, nil))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/verify-batch", bytes.NewReader(tc.body)))
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d: %s", tc.name, rec.Code, rec.Body.String())
+		}
+		var resp ErrorResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("%s: failed to decode error response: %v", tc.name, err)
+		}
+		if resp.Error != tc.wantError || resp.Message == "" {
+			t.Fatalf("%s: expected error %s with a message, got %+v", tc.name, tc.wantError, resp)
+		}
+		if signer.LastNonce(testVerifyRequest.NominatorAddress) != 0 {
+			t.Fatalf("%s: expected nothing to be signed", tc.name)
+		}
+		log.Printf("✅ %s rejected: %s", tc.name, resp.Message)
+	}
+}
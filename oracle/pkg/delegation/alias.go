@@ -0,0 +1,182 @@
+package delegation
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNotBonded is returned when an account is neither a bonded stash nor the controller of one
+var ErrNotBonded = errors.New("account is not bonded")
+
+// TargetMatch reports which form of the queried validator address a nomination targets
+type TargetMatch string
+
+const (
+	// TargetMatchNone means the nomination doesn't target the validator in any form
+	TargetMatchNone TargetMatch = ""
+	// TargetMatchDirect means the nomination targets the queried address itself
+	TargetMatchDirect TargetMatch = "direct"
+	// TargetMatchStash means the queried address is a controller and the nomination targets its stash
+	TargetMatchStash TargetMatch = "stash"
+	// TargetMatchController means the queried address is a stash and the nomination targets its controller
+	TargetMatchController TargetMatch = "controller"
+)
+
+// getBondedController reads Staking.Bonded for a stash, returning nil when the account isn't bonded
+func (v *Verifier) getBondedController(ctx context.Context, stashID []byte) ([]byte, error) {
+	raw, err := v.getStorage(ctx, bondedStorageKey(stashID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query bonded controller: %w", err)
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	controller, err := newScaleDecoder(raw).readBytes(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode bonded controller: %w", err)
+	}
+	return controller, nil
+}
+
+// getLedgerStash reads Staking.Ledger for a controller and returns the stash it controls,
+// or nil when the account isn't a controller
+func (v *Verifier) getLedgerStash(ctx context.Context, controllerID []byte) ([]byte, error) {
+	ledger, err := v.getLedger(ctx, controllerID)
+	if err != nil || ledger == nil {
+		return nil, err
+	}
+	return ledger.Stash, nil
+}
+
+// resolveStash returns the stash whose nominations apply to address: the address itself when it
+// is a bonded stash (Staking.Bonded), or the stash its Staking.Ledger names when it is a
+// controller. The stash is returned in the form address was given in, SS58 under the same prefix
+// or 0x-prefixed hex. An account that is neither returns ErrNotBonded.
+func (v *Verifier) resolveStash(ctx context.Context, address string) (string, error) {
+	accountID, err := accountIDFromAddress(address)
+	if err != nil {
+		return "", fmt.Errorf("invalid address: %w", err)
+	}
+
+	controller, err := v.getBondedController(ctx, accountID)
+	if err != nil {
+		return "", err
+	}
+	if controller != nil {
+		return address, nil
+	}
+
+	stash, err := v.getLedgerStash(ctx, accountID)
+	if err != nil {
+		return "", err
+	}
+	if stash == nil {
+		return "", fmt.Errorf("%w: %s is neither a stash nor a controller", ErrNotBonded, address)
+	}
+	if strings.HasPrefix(address, "0x") {
+		return "0x" + hex.EncodeToString(stash), nil
+	}
+	_, prefix, _ := DecodeSS58(address)
+	return EncodeSS58(stash, prefix)
+}
+
+// getStashNominations reads the Staking.Nominators entry that applies to a nominator. Nominations
+// are keyed by stash, so when the nominator has no entry of its own it may have been given as its
+// controller, and the entry of the stash its ledger names is read instead. The nominator is read
+// directly first as a stash is by far the common case. A bonded stash without an entry has no
+// nominations; a nominator that is neither a stash nor a controller returns ErrNotBonded.
+func (v *Verifier) getStashNominations(ctx context.Context, nominatorAddress string, nominatorID []byte) (*Nominations, error) {
+	nominations, _, err := v.getStashNominationsWithBlock(ctx, nominatorAddress, nominatorID)
+	return nominations, err
+}
+
+// getStashNominationsWithBlock is getStashNominations also returning the hash of the block the
+// entry was read at
+func (v *Verifier) getStashNominationsWithBlock(ctx context.Context, nominatorAddress string, nominatorID []byte) (*Nominations, string, error) {
+	nominations, blockHash, err := v.getNominationsWithBlock(ctx, nominatorID)
+	if err != nil || nominations != nil {
+		return nominations, blockHash, err
+	}
+
+	stash, err := v.resolveStash(ctx, nominatorAddress)
+	if err != nil {
+		if errors.Is(err, ErrNotBonded) {
+			v.log().DebugContext(ctx, "nominator is not bonded", "event", "nomination_alias", "nominator", nominatorAddress)
+		}
+		return nil, "", err
+	}
+	if stash == nominatorAddress {
+		return nil, blockHash, nil
+	}
+
+	v.log().DebugContext(ctx, "nominator is a controller, reading its stash's nominations", "event", "nomination_alias", "nominator", nominatorAddress, "stash", stash)
+	stashID, err := accountIDFromAddress(stash)
+	if err != nil {
+		return nil, "", err
+	}
+	return v.getNominationsWithBlock(ctx, stashID)
+}
+
+// FindNominationTarget checks whether the nominator targets the validator, resolving the
+// controller/stash split through Staking.Ledger and Staking.Bonded so that a nomination of
+// either form is recognized. The returned TargetMatch reports which form matched.
+func (v *Verifier) FindNominationTarget(ctx context.Context, nominatorAddress, validatorAddress string) (TargetMatch, error) {
+	nominatorID, err := accountIDFromAddress(nominatorAddress)
+	if err != nil {
+		return TargetMatchNone, fmt.Errorf("invalid nominator address: %w", err)
+	}
+	validatorID, err := accountIDFromAddress(validatorAddress)
+	if err != nil {
+		return TargetMatchNone, fmt.Errorf("invalid validator address: %w", err)
+	}
+
+	nominations, err := v.getStashNominations(ctx, nominatorAddress, nominatorID)
+	if err != nil {
+		return TargetMatchNone, err
+	}
+	if nominations == nil || len(nominations.Targets) == 0 {
+		return TargetMatchNone, nil
+	}
+	targets := nominations.Targets
+
+	if containsAccount(targets, validatorID) {
+		return TargetMatchDirect, nil
+	}
+
+	// The queried address may be the controller of a targeted stash
+	stash, err := v.getLedgerStash(ctx, validatorID)
+	if err != nil {
+		return TargetMatchNone, err
+	}
+	if stash != nil && containsAccount(targets, stash) {
+		v.log().DebugContext(ctx, "validator is the controller of a nominated stash", "event", "nomination_alias", "nominator", nominatorAddress, "validator", validatorAddress)
+		return TargetMatchStash, nil
+	}
+
+	// Or the stash of a targeted controller
+	controller, err := v.getBondedController(ctx, validatorID)
+	if err != nil {
+		return TargetMatchNone, err
+	}
+	if controller != nil && containsAccount(targets, controller) {
+		v.log().DebugContext(ctx, "validator is the stash of a nominated controller", "event", "nomination_alias", "nominator", nominatorAddress, "validator", validatorAddress)
+		return TargetMatchController, nil
+	}
+
+	return TargetMatchNone, nil
+}
+
+// containsAccount reports whether accounts contains the given AccountId
+func containsAccount(accounts [][]byte, accountID []byte) bool {
+	for _, account := range accounts {
+		if bytes.Equal(account, accountID) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package delegation
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestAliasStorageKeys(t *testing.T) {
+	log.Printf("🧪 Starting TestAliasStorageKeys")
+
+	accountID := bytes.Repeat([]byte{0x01}, 32)
+
+	// Storage prefixes as reported by polkadot.js for Staking.Bonded and Staking.Ledger
+	bondedPrefix := "0x5f3e4907f716ac89b6347d15ececedca3ed14b45ed20d054f05e37e2542cfe70"
+	ledgerPrefix := "0x5f3e4907f716ac89b6347d15ececedca422adb579f1dbf4f3886c5cfa3bb8cc4"
+
+	if key := bondedStorageKey(accountID); !strings.HasPrefix(key, bondedPrefix) || !strings.HasSuffix(key, hex.EncodeToString(accountID)) {
+		t.Errorf("Unexpected Bonded key: %s", key)
+	}
+	if key := ledgerStorageKey(accountID); !strings.HasPrefix(key, ledgerPrefix) || len(key) != len(ledgerPrefix)+2*(16+32) {
+		t.Errorf("Unexpected Ledger key: %s", key)
+	}
+	log.Printf("✅ Bonded and Ledger keys use the expected prefixes and hashers")
+}
+
+func TestFindNominationTarget_ControllerResolvesToStash(t *testing.T) {
+	log.Printf("🧪 Starting TestFindNominationTarget_ControllerResolvesToStash")
+
+	nominatorID := bytes.Repeat([]byte{0x01}, 32)
+	stashID := bytes.Repeat([]byte{0x02}, 32)
+	controllerID := bytes.Repeat([]byte{0x03}, 32)
+
+	// The ledger holds the stash followed by the bonded amounts, which aren't decoded
+	ledger := "0x" + hex.EncodeToString(stashID) + "0b00407a10f35a0b00407a10f35a000000"
+
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		switch method {
+		case "chain_getFinalizedHead":
+			return "0x" + hex.EncodeToString(bytes.Repeat([]byte{0xaa}, 32)), nil
+		case "state_getStorage":
+			switch params[0] {
+			case nominatorsStorageKey(nominatorID):
+				return nominationsHex([][]byte{stashID}, 1000, false), nil
+			case ledgerStorageKey(controllerID):
+				return ledger, nil
+			case bondedStorageKey(stashID):
+				return "0x" + hex.EncodeToString(controllerID), nil
+			}
+			return nil, nil
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+	verifier := NewVerifier(server.URL)
+
+	nominator := "0x" + hex.EncodeToString(nominatorID)
+	cases := []struct {
+		name      string
+		validator []byte
+		expected  TargetMatch
+	}{
+		{"stash queried directly", stashID, TargetMatchDirect},
+		{"controller resolved to nominated stash", controllerID, TargetMatchStash},
+		{"unrelated validator", bytes.Repeat([]byte{0x04}, 32), TargetMatchNone},
+	}
+
+	for _, tc := range cases {
+		match, err := verifier.FindNominationTarget(context.Background(), nominator, "0x"+hex.EncodeToString(tc.validator))
+		if err != nil {
+			t.Fatalf("%s: expected no error, got: %v", tc.name, err)
+		}
+		if match != tc.expected {
+			t.Errorf("%s: expected match %q, got %q", tc.name, tc.expected, match)
+		} else {
+			log.Printf("✅ %s: matched %q", tc.name, match)
+		}
+	}
+}
+
+func TestResolveStash(t *testing.T) {
+	log.Printf("🧪 Starting TestResolveStash")
+
+	stashID := bytes.Repeat([]byte{0x02}, 32)
+	controllerID := bytes.Repeat([]byte{0x03}, 32)
+	ledger := "0x" + hex.EncodeToString(stashID) + "0b00407a10f35a0b00407a10f35a000000"
+
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		switch method {
+		case "chain_getFinalizedHead":
+			return "0x" + hex.EncodeToString(bytes.Repeat([]byte{0xaa}, 32)), nil
+		case "state_getStorage":
+			switch params[0] {
+			case bondedStorageKey(stashID):
+				return "0x" + hex.EncodeToString(controllerID), nil
+			case ledgerStorageKey(controllerID):
+				return ledger, nil
+			case nominatorsStorageKey(stashID):
+				return nominationsHex([][]byte{aliceAccountID}, 1000, false), nil
+			}
+			return nil, nil
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+	verifier := NewVerifier(server.URL)
+	ctx := context.Background()
+
+	stash, _ := EncodeSS58(stashID, 42)
+	controller, _ := EncodeSS58(controllerID, 42)
+	cases := []struct {
+		name    string
+		address string
+		want    string
+	}{
+		{"bonded stash", stash, stash},
+		{"controller in SS58", controller, stash},
+		{"controller in hex", "0x" + hex.EncodeToString(controllerID), "0x" + hex.EncodeToString(stashID)},
+	}
+	for _, tc := range cases {
+		got, err := verifier.resolveStash(ctx, tc.address)
+		if err != nil || got != tc.want {
+			t.Fatalf("%s: expected stash %s, got %s: %v", tc.name, tc.want, got, err)
+		}
+		log.Printf("✅ %s resolved to %s", tc.name, got)
+	}
+
+	unbonded, _ := EncodeSS58(bytes.Repeat([]byte{0x05}, 32), 42)
+	if _, err := verifier.resolveStash(ctx, unbonded); !errors.Is(err, ErrNotBonded) {
+		t.Fatalf("Expected ErrNotBonded for an unbonded account, got: %v", err)
+	}
+	log.Printf("✅ Unbonded account reported as ErrNotBonded")
+
+	// The stash's nominations apply when the nominator is given as its controller
+	for _, nominator := range []string{stash, controller} {
+		nominated, err := verifier.checkIfNominated(ctx, nominator, "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+		if err != nil || !nominated {
+			t.Fatalf("Expected %s to nominate Alice through its stash, got %v: %v", nominator, nominated, err)
+		}
+	}
+	log.Printf("✅ Controller resolved to its stash's nominations")
+
+	// Every nomination lookup resolves a controller the same way
+	alice := "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+	nominated, err := verifier.GetNominatedValidators(ctx, controller)
+	if err != nil || nominated == nil || len(nominated.Validators) != 1 {
+		t.Fatalf("Expected the controller's nominated validators to be its stash's, got %+v: %v", nominated, err)
+	}
+	results, err := verifier.VerifyDelegationsCtx(ctx, controller, []string{alice})
+	if err != nil || !results[alice] {
+		t.Fatalf("Expected VerifyDelegations to match Alice through the stash, got %v: %v", results, err)
+	}
+	if match, err := verifier.FindNominationTarget(ctx, controller, alice); err != nil || match != TargetMatchDirect {
+		t.Fatalf("Expected FindNominationTarget to match Alice through the stash, got %v: %v", match, err)
+	}
+	if _, err := verifier.nominationSuppressed(ctx, controller); err != nil {
+		t.Fatalf("Expected the suppression check to resolve the controller, got: %v", err)
+	}
+	if _, err := verifier.GetNominatedValidators(ctx, unbonded); !errors.Is(err, ErrNotBonded) {
+		t.Fatalf("Expected ErrNotBonded listing an unbonded nominator's validators, got: %v", err)
+	}
+	log.Printf("✅ Nominated validators, batch checks and target matching resolve the controller")
+
+	if nominated, err := verifier.checkIfNominated(ctx, unbonded, "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"); !errors.Is(err, ErrNotBonded) || nominated {
+		t.Fatalf("Expected ErrNotBonded for an unbonded nominator, got %v: %v", nominated, err)
+	}
+	log.Printf("✅ Unbonded nominator reported as ErrNotBonded")
+}
@@ -0,0 +1,147 @@
+package delegation
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// makeBatchRPCCall sends requests to the node in a single JSON-RPC batch and returns their
+// responses in request order, whatever order the node answers in. An element that fails
+// carries its RPCError in its response; the returned error is only for the batch as a whole.
+func (v *Verifier) makeBatchRPCCall(requests []RPCRequest) ([]RPCResponse, error) {
+	return v.makeBatchRPCCallCtx(context.Background(), requests)
+}
+
+// makeBatchRPCCallCtx is makeBatchRPCCall bounded by ctx
+func (v *Verifier) makeBatchRPCCallCtx(ctx context.Context, requests []RPCRequest) ([]RPCResponse, error) {
+	if len(requests) == 0 {
+		return nil, nil
+	}
+
+	// Number the elements so responses can be matched back to them
+	numbered := make([]RPCRequest, len(requests))
+	for i, request := range requests {
+		numbered[i] = request
+		numbered[i].ID = i + 1
+	}
+
+	var received []RPCResponse
+	start := time.Now()
+	err := v.withRetry(ctx, "batch", func() error {
+		var err error
+		received, err = v.transport.roundTripBatch(ctx, numbered)
+		return err
+	})
+	elapsed := time.Since(start)
+	if err != nil {
+		for _, request := range requests {
+			v.metrics.observe(request.Method, elapsed, err)
+			v.stats.record(request.Method, false)
+			recordRPCCall(ctx, request, nil, err)
+		}
+		return nil, fmt.Errorf("batch RPC call failed: %w", err)
+	}
+
+	responses := make([]RPCResponse, len(requests))
+	answered := make([]bool, len(requests))
+	for _, response := range received {
+		index := response.ID - 1
+		if index < 0 || index >= len(requests) || answered[index] {
+			v.log().WarnContext(ctx, "ignoring batch response with unexpected id", "event", "rpc_batch", "id", response.ID)
+			continue
+		}
+		response.ID = requests[index].ID
+		responses[index] = response
+		answered[index] = true
+	}
+
+	for i, request := range requests {
+		if !answered[i] {
+			responses[i] = RPCResponse{
+				JSONRPC: "2.0",
+				Error:   &RPCError{Code: -32603, Message: "no response in batch"},
+				ID:      request.ID,
+			}
+		}
+
+		var elementErr error
+		if responses[i].Error != nil {
+			elementErr = fmt.Errorf("RPC error: %s", responses[i].Error.Message)
+		}
+		v.metrics.observe(request.Method, elapsed, elementErr)
+		v.stats.record(request.Method, elementErr == nil)
+		recordRPCCall(ctx, request, responses[i].Result, elementErr)
+	}
+
+	return responses, nil
+}
+
+// prefetchEraAndNominations reads Staking.ActiveEra and the nominator's Staking.Nominators
+// entry at the finalized head, or the pinned block, in one batch. The nominations are cached for getNominations and
+// the active era is returned in a context for getActiveEraInfo. Any failure is logged and the
+// original context returned, leaving the individual queries to fetch the values instead.
+func (v *Verifier) prefetchEraAndNominations(ctx context.Context, nominatorAddress string) context.Context {
+	nominatorID, err := accountIDFromAddress(nominatorAddress)
+	if err != nil {
+		return ctx
+	}
+
+	blockHash, err := v.storageBlock(ctx)
+	if err != nil {
+		v.log().WarnContext(ctx, "skipping batched prefetch", "event", "prefetch", "error", err)
+		return ctx
+	}
+
+	responses, err := v.makeBatchRPCCallCtx(ctx, []RPCRequest{
+		{JSONRPC: "2.0", Method: "state_getStorage", Params: []interface{}{activeEraStorageKey(), blockHash}, ID: 1},
+		{JSONRPC: "2.0", Method: "state_getStorage", Params: []interface{}{nominatorsStorageKey(nominatorID), blockHash}, ID: 2},
+	})
+	if err != nil {
+		v.log().WarnContext(ctx, "skipping batched prefetch", "event", "prefetch", "error", err)
+		return ctx
+	}
+
+	if raw, err := decodeStorageResult(responses[1]); err != nil {
+		v.log().WarnContext(ctx, "batched nominations query failed", "event", "prefetch", "error", err)
+	} else {
+		var nominations *Nominations
+		if raw != nil {
+			nominations, err = decodeNominations(raw)
+		}
+		if err != nil {
+			v.log().WarnContext(ctx, "batched nominations could not be decoded", "event", "prefetch", "error", err)
+		} else {
+			v.targetsCache.put(hex.EncodeToString(nominatorID), blockHash, nominations)
+		}
+	}
+
+	raw, err := decodeStorageResult(responses[0])
+	if err != nil || raw == nil {
+		v.log().WarnContext(ctx, "batched active era query failed", "event", "prefetch", "error", err)
+		return ctx
+	}
+	info, err := decodeActiveEraInfo(raw)
+	if err != nil {
+		v.log().WarnContext(ctx, "batched active era could not be decoded", "event", "prefetch", "error", err)
+		return ctx
+	}
+	return context.WithValue(ctx, prefetchedActiveEraKey{}, info)
+}
+
+// decodeStorageResult decodes a state_getStorage element of a batch response
+func decodeStorageResult(response RPCResponse) ([]byte, error) {
+	if response.Error != nil {
+		return nil, fmt.Errorf("RPC error: %s", response.Error.Message)
+	}
+	return decodeStorageValue(response.Result)
+}
+
+type prefetchedActiveEraKey struct{}
+
+// prefetchedActiveEra returns the active era prefetched into ctx, or nil
+func prefetchedActiveEra(ctx context.Context) *ActiveEraInfo {
+	info, _ := ctx.Value(prefetchedActiveEraKey{}).(*ActiveEraInfo)
+	return info
+}
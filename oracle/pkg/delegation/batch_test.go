@@ -0,0 +1,168 @@
+package delegation
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newReversingBatchServer answers batches in reverse order through handler, counting batch
+// posts and single storage queries for key
+func newReversingBatchServer(t *testing.T, handler mockRPCHandler, batches *atomic.Int32, singleStorage func(key string)) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		answer := func(request mockRPCRequest) RPCResponse {
+			result, rpcErr := handler(request.Method, request.Params)
+			return RPCResponse{JSONRPC: "2.0", Result: result, Error: rpcErr, ID: request.ID}
+		}
+
+		var batch []mockRPCRequest
+		if err := json.Unmarshal(body, &batch); err == nil {
+			batches.Add(1)
+			responses := make([]RPCResponse, 0, len(batch))
+			for i := len(batch) - 1; i >= 0; i-- {
+				responses = append(responses, answer(batch[i]))
+			}
+			json.NewEncoder(w).Encode(responses)
+			return
+		}
+
+		var request mockRPCRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		if request.Method == "state_getStorage" && singleStorage != nil {
+			singleStorage(request.Params[0].(string))
+		}
+		json.NewEncoder(w).Encode(answer(request))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestMakeBatchRPCCall_OutOfOrderAndPartialError(t *testing.T) {
+	log.Printf("🧪 Starting TestMakeBatchRPCCall_OutOfOrderAndPartialError")
+
+	var batches atomic.Int32
+	server := newReversingBatchServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		switch params[0] {
+		case "0x01":
+			return "0xaa", nil
+		case "0x03":
+			return "0xcc", nil
+		}
+		return nil, &RPCError{Code: -32000, Message: "unknown storage"}
+	}, &batches, nil)
+	verifier := NewVerifier(server.URL)
+
+	responses, err := verifier.makeBatchRPCCall([]RPCRequest{
+		{JSONRPC: "2.0", Method: "state_getStorage", Params: []interface{}{"0x01"}, ID: 7},
+		{JSONRPC: "2.0", Method: "state_getStorage", Params: []interface{}{"0x02"}, ID: 7},
+		{JSONRPC: "2.0", Method: "state_getStorage", Params: []interface{}{"0x03"}, ID: 9},
+	})
+	if err != nil {
+		t.Fatalf("Expected the batch to succeed, got: %v", err)
+	}
+	if batches.Load() != 1 {
+		t.Fatalf("Expected a single batch post, got %d", batches.Load())
+	}
+	if len(responses) != 3 {
+		t.Fatalf("Expected 3 responses, got %d", len(responses))
+	}
+	if responses[0].Result != "0xaa" || responses[2].Result != "0xcc" {
+		t.Fatalf("Responses not matched to their requests: %+v", responses)
+	}
+	if responses[1].Error == nil || responses[1].Result != nil {
+		t.Fatalf("Expected only the second element to fail, got: %+v", responses[1])
+	}
+	if responses[0].ID != 7 || responses[1].ID != 7 || responses[2].ID != 9 {
+		t.Fatalf("Expected the callers' IDs to be restored, got %d, %d, %d", responses[0].ID, responses[1].ID, responses[2].ID)
+	}
+	log.Printf("✅ Reordered responses matched by ID, failed element isolated")
+}
+
+func TestMakeBatchRPCCall_MissingResponse(t *testing.T) {
+	log.Printf("🧪 Starting TestMakeBatchRPCCall_MissingResponse")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]RPCResponse{{JSONRPC: "2.0", Result: "0x02", ID: 2}})
+	}))
+	t.Cleanup(server.Close)
+	verifier := NewVerifier(server.URL)
+
+	responses, err := verifier.makeBatchRPCCall([]RPCRequest{
+		{JSONRPC: "2.0", Method: "state_getStorage", Params: []interface{}{"0x01"}, ID: 1},
+		{JSONRPC: "2.0", Method: "state_getStorage", Params: []interface{}{"0x02"}, ID: 2},
+	})
+	if err != nil {
+		t.Fatalf("Expected the batch to succeed, got: %v", err)
+	}
+	if responses[0].Error == nil || !strings.Contains(responses[0].Error.Message, "no response") {
+		t.Fatalf("Expected the unanswered element to carry an error, got: %+v", responses[0])
+	}
+	if responses[1].Result != "0x02" {
+		t.Fatalf("Unexpected result for the answered element: %+v", responses[1])
+	}
+	log.Printf("✅ Unanswered element reported as an error")
+}
+
+func TestVerifyV2_BatchesEraAndNominations(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyV2_BatchesEraAndNominations")
+
+	bobAccountID, _ := accountIDFromAddress("5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty")
+	eraKey := activeEraStorageKey()
+	nominatorsKey := nominatorsStorageKey(bobAccountID)
+
+	var batches atomic.Int32
+	var singleQueries atomic.Int32
+	server := newReversingBatchServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		switch method {
+		case "chain_getFinalizedHead":
+			return testBlockHash, nil
+		case "state_getStorage":
+			switch params[0] {
+			case eraKey:
+				return activeEraHex(5, 0), nil
+			case nominatorsKey:
+				return nominationsHex([][]byte{aliceAccountID}, 1, false), nil
+			case bondedStorageKey(bobAccountID):
+				return "0x" + hex.EncodeToString(bobAccountID), nil
+			case ledgerStorageKey(bobAccountID):
+				return ledgerHex(bobAccountID, big.NewInt(1_000_000_000_000)), nil
+			}
+			return nil, nil
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	}, &batches, func(key string) {
+		if key == eraKey || key == nominatorsKey {
+			singleQueries.Add(1)
+		}
+	})
+	verifier := NewVerifier(server.URL)
+	verifier.SetNetwork(Substrate)
+
+	result, err := verifier.VerifyV2("0x"+hex.EncodeToString(bobAccountID), "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+	if err != nil {
+		t.Fatalf("VerifyV2 returned error: %v", err)
+	}
+	if !result.IsValid {
+		t.Fatalf("Expected the delegation to verify, got: %+v", result)
+	}
+	if batches.Load() != 1 {
+		t.Fatalf("Expected ActiveEra and Nominators in one batch, got %d batches", batches.Load())
+	}
+	if singleQueries.Load() != 0 {
+		t.Fatalf("Expected no separate ActiveEra or Nominators queries, got %d", singleQueries.Load())
+	}
+	log.Printf("✅ ActiveEra and Nominators fetched in a single batch")
+}
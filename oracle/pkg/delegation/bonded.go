@@ -0,0 +1,113 @@
+package delegation
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+)
+
+// StakingLedger holds the leading fields of a Staking.Ledger entry
+type StakingLedger struct {
+	Stash  []byte
+	Total  *big.Int
+	Active *big.Int
+}
+
+// decodeStakingLedger SCALE-decodes the stash, total and active fields of a StakingLedger.
+// The remaining fields (unlocking chunks, claimed rewards) are not needed and left undecoded.
+func decodeStakingLedger(raw []byte) (*StakingLedger, error) {
+	decoder := newScaleDecoder(raw)
+
+	stash, err := decoder.readBytes(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode ledger stash: %w", err)
+	}
+
+	total, err := decoder.readBalance()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode ledger total: %w", err)
+	}
+
+	active, err := decoder.readBalance()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode ledger active: %w", err)
+	}
+	// The total counts the active bond and every unlocking chunk
+	if active.Cmp(total) > 0 {
+		return nil, fmt.Errorf("ledger active %s exceeds total %s", active, total)
+	}
+
+	return &StakingLedger{Stash: append([]byte{}, stash...), Total: total, Active: active}, nil
+}
+
+// Unbonding reports whether part of the bond is unlocking. The total counts the unlocking chunks
+// as well as the active bond, so it exceeds the active bond exactly while any chunk is pending.
+func (l *StakingLedger) Unbonding() bool {
+	return l.Total.Cmp(l.Active) > 0
+}
+
+// getLedger reads and decodes Staking.Ledger for a controller, returning nil when the account isn't a controller
+func (v *Verifier) getLedger(ctx context.Context, controllerID []byte) (*StakingLedger, error) {
+	raw, err := v.getStorage(ctx, ledgerStorageKey(controllerID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query staking ledger: %w", err)
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	ledger, err := decodeStakingLedger(raw)
+	if err != nil {
+		return nil, err
+	}
+	recordDecoded(ctx, "ledger", ledger)
+	return ledger, nil
+}
+
+// SetMinBonded sets the minimum active bond, in planck, a nominator needs for its delegation
+// to be honored. A nil threshold disables the check.
+func (v *Verifier) SetMinBonded(minBonded *big.Int) {
+	v.minBonded = minBonded
+}
+
+// getNominatorLedger reads the staking ledger of a nominator's stash, resolving its controller
+// through Staking.Bonded. It returns nil when the account isn't bonded.
+func (v *Verifier) getNominatorLedger(ctx context.Context, nominatorAddress string) (*StakingLedger, error) {
+	stashID, err := accountIDFromAddress(nominatorAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nominator address: %w", err)
+	}
+
+	controllerID, err := v.getBondedController(ctx, stashID)
+	if err != nil || controllerID == nil {
+		return nil, err
+	}
+	return v.getLedger(ctx, controllerID)
+}
+
+// GetActiveBond returns the nominator's active bond in planck, resolving its controller through
+// Staking.Bonded. An account that isn't bonded has an active bond of zero.
+func (v *Verifier) GetActiveBond(ctx context.Context, nominatorAddress string) (*big.Int, error) {
+	ledger, err := v.getNominatorLedger(ctx, nominatorAddress)
+	if err != nil {
+		return nil, err
+	}
+	if ledger == nil {
+		return new(big.Int), nil
+	}
+	return ledger.Active, nil
+}
+
+// CheckBondedThreshold reports whether the nominator's active bond meets the configured MinBonded,
+// along with the active bond. Without a threshold every nominator passes and no bond is read.
+func (v *Verifier) CheckBondedThreshold(ctx context.Context, nominatorAddress string) (bool, *big.Int, error) {
+	if v.minBonded == nil {
+		return true, nil, nil
+	}
+
+	active, err := v.GetActiveBond(ctx, nominatorAddress)
+	if err != nil {
+		return false, nil, err
+	}
+	return active.Cmp(v.minBonded) >= 0, active, nil
+}
@@ -0,0 +1,222 @@
+package delegation
+
+import (
+	"bytes"
+	"encoding/hex"
+	"log"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// compactBigHex SCALE-encodes an unsigned integer in compact big-integer mode
+func compactBigHex(value *big.Int) string {
+	be := value.Bytes()
+	if len(be) < 4 {
+		be = append(make([]byte, 4-len(be)), be...)
+	}
+	encoded := []byte{byte((len(be)-4)<<2) | 0x03}
+	for i := len(be) - 1; i >= 0; i-- {
+		encoded = append(encoded, be[i])
+	}
+	return hex.EncodeToString(encoded)
+}
+
+// ledgerHex encodes the Staking.Ledger entry of stash with an active bond and one chunk
+// unlocking at era 1010 per unlocking value, so the total is their sum
+func ledgerHex(stash []byte, active *big.Int, unlocking ...*big.Int) string {
+	total := new(big.Int).Set(active)
+	chunks := hex.EncodeToString([]byte{byte(len(unlocking) << 2)})
+	for _, value := range unlocking {
+		total.Add(total, value)
+		chunks += compactBigHex(value) + compactBigHex(big.NewInt(1010))
+	}
+	return "0x" + hex.EncodeToString(stash) + compactBigHex(total) + compactBigHex(active) + chunks + "00"
+}
+
+func TestReadCompactBig(t *testing.T) {
+	log.Printf("🧪 Starting TestReadCompactBig")
+
+	huge, _ := new(big.Int).SetString("340282366920938463463374607431768211455", 10) // u128::MAX
+	for _, value := range []*big.Int{big.NewInt(42), big.NewInt(1 << 20), huge} {
+		var raw []byte
+		if value.IsUint64() && value.Uint64() < 1<<6 {
+			raw = []byte{byte(value.Uint64() << 2)}
+		} else {
+			raw, _ = hex.DecodeString(compactBigHex(value))
+		}
+
+		got, err := newScaleDecoder(raw).readCompactBig()
+		if err != nil {
+			t.Fatalf("Expected no error decoding %s, got: %v", value, err)
+		}
+		if got.Cmp(value) != 0 {
+			t.Errorf("Expected %s, got %s", value, got)
+		}
+	}
+	log.Printf("✅ Compact integers up to u128 decoded")
+}
+
+func TestDecodeStakingLedger_KnownBlob(t *testing.T) {
+	log.Printf("🧪 Starting TestDecodeStakingLedger_KnownBlob")
+
+	// Alice's stash with 1,234.5678901234 DOT in total, 1,000 DOT of it active and the rest
+	// unlocking in one chunk at era 1,500, then no claimed rewards
+	raw, _ := hex.DecodeString("d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d" +
+		"0bf22fce733a0b" + // total: compact big integer, 6 bytes
+		"0b00a0724e1809" + // active: compact big integer, 6 bytes
+		"04" + "0bf28f5b252202" + "7117" + // one unlocking chunk of 234.5678901234 DOT at era 1,500
+		"00")
+
+	ledger, err := decodeStakingLedger(raw)
+	if err != nil {
+		t.Fatalf("Expected the ledger to decode, got: %v", err)
+	}
+	if !bytes.Equal(ledger.Stash, aliceAccountID) {
+		t.Errorf("Expected Alice's stash, got %x", ledger.Stash)
+	}
+	if ledger.Total.String() != "12345678901234" || ledger.Active.String() != "10000000000000" {
+		t.Errorf("Expected total 12345678901234 and active 10000000000000 planck, got %s and %s", ledger.Total, ledger.Active)
+	}
+	if !ledger.Unbonding() {
+		t.Errorf("Expected the ledger to be unbonding")
+	}
+	log.Printf("✅ Decoded total %s and active %s planck", ledger.Total, ledger.Active)
+
+	for name, blob := range map[string]string{
+		"active above total": "0b00a0724e1809" + "0bf22fce733a0b",
+		"balance past u128":  "37" + strings.Repeat("ff", 17) + "00",
+		"truncated active":   "0bf22fce733a0b" + "0b00a072",
+	} {
+		raw, _ := hex.DecodeString(hex.EncodeToString(aliceAccountID) + blob)
+		if _, err := decodeStakingLedger(raw); err == nil {
+			t.Errorf("%s: expected a decoding error", name)
+		} else {
+			log.Printf("✅ %s rejected: %v", name, err)
+		}
+	}
+}
+
+func TestVerifyV2_BondedThreshold(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyV2_BondedThreshold")
+
+	nominatorID := bytes.Repeat([]byte{0x01}, 32)
+	validatorID := bytes.Repeat([]byte{0x02}, 32)
+	active := big.NewInt(5_000_000_000_000) // 500 DOT
+
+	ledger := ledgerHex(nominatorID, active)
+
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		switch method {
+		case "chain_getFinalizedHead":
+			return "0x" + hex.EncodeToString(bytes.Repeat([]byte{0xaa}, 32)), nil
+		case "state_getStorage":
+			switch params[0] {
+			case nominatorsStorageKey(nominatorID):
+				return nominationsHex([][]byte{validatorID}, 1000, false), nil
+			case bondedStorageKey(nominatorID):
+				return "0x" + hex.EncodeToString(nominatorID), nil
+			case ledgerStorageKey(nominatorID):
+				return ledger, nil
+			case activeEraStorageKey():
+				return activeEraHex(1000, 0), nil
+			}
+			return "0x00", nil
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+
+	nominator := "0x" + hex.EncodeToString(nominatorID)
+	validator := "0x" + hex.EncodeToString(validatorID)
+
+	cases := []struct {
+		name      string
+		minBonded *big.Int
+		expected  bool
+	}{
+		{"bond above threshold", big.NewInt(1_000_000_000_000), true},
+		{"bond equal to threshold", active, true},
+		{"bond below threshold", big.NewInt(10_000_000_000_000), false},
+	}
+
+	for _, tc := range cases {
+		verifier := NewVerifier(server.URL)
+		verifier.SetMinBonded(tc.minBonded)
+
+		result, err := verifier.VerifyV2(nominator, validator)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got: %v", tc.name, err)
+		}
+		if result.BondedThresholdValidation != tc.expected || result.IsValid != tc.expected {
+			t.Errorf("%s: expected threshold validation and validity %v, got %v/%v (%s)",
+				tc.name, tc.expected, result.BondedThresholdValidation, result.IsValid, result.Error)
+		}
+		if result.BondedAmount != active.String() {
+			t.Errorf("%s: expected bonded amount %s, got %q", tc.name, active, result.BondedAmount)
+		}
+		log.Printf("✅ %s: bonded %s, valid=%v", tc.name, result.BondedAmount, result.IsValid)
+	}
+}
+
+func TestVerifyV2_UnbondingAndChill(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyV2_UnbondingAndChill")
+
+	nominatorID := bytes.Repeat([]byte{0x01}, 32)
+	validatorID := bytes.Repeat([]byte{0x02}, 32)
+	dot := big.NewInt(10_000_000_000)
+
+	cases := []struct {
+		name          string
+		ledger        interface{} // nil when the nominator isn't bonded
+		expectedStake string
+		unbonding     bool
+		valid         bool
+	}{
+		{"fully active", ledgerHex(nominatorID, new(big.Int).Mul(dot, big.NewInt(500))), "5000000000000", false, true},
+		{"partially unbonding", ledgerHex(nominatorID, new(big.Int).Mul(dot, big.NewInt(300)), new(big.Int).Mul(dot, big.NewInt(200))), "3000000000000", true, true},
+		{"fully unbonding", ledgerHex(nominatorID, new(big.Int), new(big.Int).Mul(dot, big.NewInt(500))), "0", true, false},
+		{"not bonded", nil, "0", false, false},
+	}
+
+	for _, tc := range cases {
+		server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+			switch method {
+			case "chain_getFinalizedHead":
+				return testBlockHash, nil
+			case "state_getStorage":
+				switch params[0] {
+				case nominatorsStorageKey(nominatorID):
+					return nominationsHex([][]byte{validatorID}, 1000, false), nil
+				case activeEraStorageKey():
+					return activeEraHex(1000, 0), nil
+				case bondedStorageKey(nominatorID):
+					if tc.ledger == nil {
+						return nil, nil
+					}
+					return "0x" + hex.EncodeToString(nominatorID), nil
+				case ledgerStorageKey(nominatorID):
+					return tc.ledger, nil
+				}
+				return nil, nil
+			}
+			return nil, &RPCError{Code: -32601, Message: "method not found"}
+		})
+		verifier := NewVerifier(server.URL)
+
+		result, err := verifier.VerifyV2("0x"+hex.EncodeToString(nominatorID), "0x"+hex.EncodeToString(validatorID))
+		if err != nil {
+			t.Fatalf("%s: expected no error, got: %v", tc.name, err)
+		}
+		if !result.StorageValidation || !result.ActiveEraValidation {
+			t.Fatalf("%s: expected the nomination itself to pass, got %+v", tc.name, result)
+		}
+		if result.ActiveStake.String() != tc.expectedStake || result.Unbonding != tc.unbonding || result.IsValid != tc.valid {
+			t.Errorf("%s: expected stake %s, unbonding %v and validity %v, got %s, %v and %v (%s)",
+				tc.name, tc.expectedStake, tc.unbonding, tc.valid, result.ActiveStake, result.Unbonding, result.IsValid, result.AdditionalInfo)
+		}
+		if result.TokenDecimals != Polkadot.TokenDecimals {
+			t.Errorf("%s: expected Polkadot's %d token decimals, got %d", tc.name, Polkadot.TokenDecimals, result.TokenDecimals)
+		}
+		log.Printf("✅ %s: active stake %s, unbonding=%v, valid=%v", tc.name, result.ActiveStake, result.Unbonding, result.IsValid)
+	}
+}
@@ -0,0 +1,116 @@
+package delegation
+
+import (
+	"log/slog"
+	"time"
+)
+
+// VerifierConfig gathers the options of a Verifier. The zero value of every field selects
+// its default, so only the options a caller cares about need to be set.
+type VerifierConfig struct {
+	// RPCURL is the http(s):// or ws(s):// endpoint, or a comma-separated list of endpoints to fail
+	// over between in order; empty uses the network's DefaultRPCURL
+	RPCURL string
+	// Timeout bounds each RPC call; zero means DefaultRPCTimeout
+	Timeout time.Duration
+	// MaxRetries is how many times a transient RPC failure is retried; zero means
+	// DefaultMaxRetries and a negative value disables retries
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry; zero means DefaultRetryBackoff
+	RetryBackoff time.Duration
+	// Network is the chain addresses must belong to; the zero Network means Polkadot
+	Network Network
+	// ResultCacheTTL is how long passing results are reused; zero means DefaultResultCacheTTL
+	// and a negative value disables the cache
+	ResultCacheTTL time.Duration
+	// ActiveEraCacheTTL is how long the decoded active era is reused; zero means
+	// DefaultActiveEraCacheTTL and a negative value disables the cache
+	ActiveEraCacheTTL time.Duration
+	// ScanRange is how many blocks behind the latest a block scan searches; zero means DefaultScanRange
+	ScanRange int64
+	// ScanMaxResults is how many extrinsics end a block scan; zero means DefaultScanMaxResults
+	ScanMaxResults int
+	// MaxRPCCallsPerVerify caps the RPC calls of a block scan; zero means unlimited
+	MaxRPCCallsPerVerify int
+	// ProofSource is an indexer GetStakingExtrinsics prefers over block scanning; nil scans blocks
+	ProofSource ProofSource
+	// Logger receives structured logs; nil means slog.Default()
+	Logger *slog.Logger
+	// MaxIdleConns, MaxIdleConnsPerHost and IdleConnTimeout size the keep-alive pool of an HTTP
+	// endpoint; zero means DefaultMaxIdleConns, DefaultMaxIdleConnsPerHost and DefaultIdleConnTimeout
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+}
+
+// NewVerifierWithConfig creates a delegation verifier from cfg, filling unset options with their defaults
+func NewVerifierWithConfig(cfg VerifierConfig) *Verifier {
+	if cfg.Network.Name == "" {
+		cfg.Network = Polkadot
+	}
+	if cfg.RPCURL == "" {
+		cfg.RPCURL = cfg.Network.DefaultRPCURL
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultRPCTimeout
+	}
+	switch {
+	case cfg.MaxRetries == 0:
+		cfg.MaxRetries = DefaultMaxRetries
+	case cfg.MaxRetries < 0:
+		cfg.MaxRetries = 0
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = DefaultRetryBackoff
+	}
+	switch {
+	case cfg.ResultCacheTTL == 0:
+		cfg.ResultCacheTTL = DefaultResultCacheTTL
+	case cfg.ResultCacheTTL < 0:
+		cfg.ResultCacheTTL = 0
+	}
+	switch {
+	case cfg.ActiveEraCacheTTL == 0:
+		cfg.ActiveEraCacheTTL = DefaultActiveEraCacheTTL
+	case cfg.ActiveEraCacheTTL < 0:
+		cfg.ActiveEraCacheTTL = 0
+	}
+	if cfg.ScanRange <= 0 {
+		cfg.ScanRange = DefaultScanRange
+	}
+	if cfg.ScanMaxResults <= 0 {
+		cfg.ScanMaxResults = DefaultScanMaxResults
+	}
+	if cfg.MaxIdleConns <= 0 {
+		cfg.MaxIdleConns = DefaultMaxIdleConns
+	}
+	if cfg.MaxIdleConnsPerHost <= 0 {
+		cfg.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	}
+	if cfg.IdleConnTimeout <= 0 {
+		cfg.IdleConnTimeout = DefaultIdleConnTimeout
+	}
+
+	v := &Verifier{
+		rpcURL:               cfg.RPCURL,
+		transport:            newRPCTransport(cfg),
+		targetsCache:         newTargetsCache(defaultTargetsCacheSize),
+		stats:                newRPCStats(),
+		metrics:              newRPCMetrics(),
+		logger:               cfg.Logger,
+		maxRPCCallsPerVerify: cfg.MaxRPCCallsPerVerify,
+		maxNominatorRewarded: DefaultMaxNominatorRewardedPerValidator,
+		maxRetries:           cfg.MaxRetries,
+		retryBackoff:         cfg.RetryBackoff,
+		resultCache:          newResultCache(cfg.ResultCacheTTL),
+		activeEraCache:       newActiveEraCache(cfg.ActiveEraCacheTTL),
+		network:              cfg.Network,
+		scanRange:            cfg.ScanRange,
+		scanMaxResults:       cfg.ScanMaxResults,
+		proofSource:          cfg.ProofSource,
+	}
+	if failover, ok := v.transport.(*failoverTransport); ok {
+		failover.log = v.log
+	}
+	return v
+}
@@ -0,0 +1,84 @@
+package delegation
+
+import (
+	"log"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewVerifierWithConfig(t *testing.T) {
+	log.Printf("🧪 Starting TestNewVerifierWithConfig")
+
+	logger := slog.Default().With("component", "test")
+	verifier := NewVerifierWithConfig(VerifierConfig{
+		RPCURL:               "http://127.0.0.1:9933",
+		Timeout:              3 * time.Second,
+		MaxRetries:           4,
+		RetryBackoff:         50 * time.Millisecond,
+		Network:              Kusama,
+		ResultCacheTTL:       time.Minute,
+		ActiveEraCacheTTL:    2 * time.Minute,
+		ScanRange:            25,
+		ScanMaxResults:       7,
+		MaxRPCCallsPerVerify: 30,
+		Logger:               logger,
+		MaxIdleConns:         64,
+		MaxIdleConnsPerHost:  16,
+		IdleConnTimeout:      30 * time.Second,
+	})
+
+	transport, ok := verifier.transport.(*httpTransport)
+	if !ok {
+		t.Fatalf("Expected an HTTP transport, got %T", verifier.transport)
+	}
+	if verifier.rpcURL != "http://127.0.0.1:9933" || transport.client.Timeout != 3*time.Second {
+		t.Fatalf("Expected endpoint and timeout from config, got %s and %v", verifier.rpcURL, transport.client.Timeout)
+	}
+	if verifier.maxRetries != 4 || verifier.retryBackoff != 50*time.Millisecond {
+		t.Fatalf("Expected 4 retries after 50ms, got %d after %v", verifier.maxRetries, verifier.retryBackoff)
+	}
+	if verifier.Network().Name != Kusama.Name {
+		t.Fatalf("Expected network kusama, got %s", verifier.Network().Name)
+	}
+	if verifier.resultCache.ttl != time.Minute || verifier.activeEraCache.ttl != 2*time.Minute {
+		t.Fatalf("Expected cache TTLs 1m and 2m, got %v and %v", verifier.resultCache.ttl, verifier.activeEraCache.ttl)
+	}
+	if verifier.scanRange != 25 || verifier.scanMaxResults != 7 || verifier.maxRPCCallsPerVerify != 30 {
+		t.Fatalf("Expected scan limits 25/7/30, got %d/%d/%d", verifier.scanRange, verifier.scanMaxResults, verifier.maxRPCCallsPerVerify)
+	}
+	if verifier.log() != logger {
+		t.Fatalf("Expected the configured logger")
+	}
+	pool, ok := transport.client.Transport.(*http.Transport)
+	if !ok || pool.MaxIdleConns != 64 || pool.MaxIdleConnsPerHost != 16 || pool.IdleConnTimeout != 30*time.Second || pool.DisableKeepAlives {
+		t.Fatalf("Expected a keep-alive pool of 64/16 idling 30s, got %+v", transport.client.Transport)
+	}
+	log.Printf("✅ Every configured option applied")
+}
+
+func TestNewVerifierWithConfig_Defaults(t *testing.T) {
+	log.Printf("🧪 Starting TestNewVerifierWithConfig_Defaults")
+
+	verifier := NewVerifierWithConfig(VerifierConfig{})
+	if verifier.rpcURL != Polkadot.DefaultRPCURL || verifier.Network().Name != Polkadot.Name {
+		t.Fatalf("Expected Polkadot and its default endpoint, got %s at %s", verifier.Network().Name, verifier.rpcURL)
+	}
+	if verifier.maxRetries != DefaultMaxRetries || verifier.retryBackoff != DefaultRetryBackoff {
+		t.Fatalf("Expected default retries, got %d after %v", verifier.maxRetries, verifier.retryBackoff)
+	}
+	if verifier.resultCache.ttl != DefaultResultCacheTTL || verifier.activeEraCache.ttl != DefaultActiveEraCacheTTL {
+		t.Fatalf("Expected default cache TTLs, got %v and %v", verifier.resultCache.ttl, verifier.activeEraCache.ttl)
+	}
+	if verifier.scanRange != DefaultScanRange || verifier.scanMaxResults != DefaultScanMaxResults {
+		t.Fatalf("Expected default scan limits, got %d/%d", verifier.scanRange, verifier.scanMaxResults)
+	}
+	log.Printf("✅ Zero config selects the defaults")
+
+	verifier = NewVerifierWithConfig(VerifierConfig{MaxRetries: -1, ResultCacheTTL: -1, ActiveEraCacheTTL: -1})
+	if verifier.maxRetries != 0 || verifier.resultCache.ttl != 0 || verifier.activeEraCache.ttl != 0 {
+		t.Fatalf("Expected negative values to disable retries and the caches, got %d, %v and %v", verifier.maxRetries, verifier.resultCache.ttl, verifier.activeEraCache.ttl)
+	}
+	log.Printf("✅ Negative values disable retries and the caches")
+}
@@ -0,0 +1,145 @@
+package delegation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrActiveEraEmpty is returned when the Staking.ActiveEra storage value is unset
+var ErrActiveEraEmpty = errors.New("active era storage is empty")
+
+// ActiveEraInfo is the decoded Staking.ActiveEra storage value
+type ActiveEraInfo struct {
+	Index uint32  `json:"index"`
+	Start *uint64 `json:"start,omitempty"` // unix milliseconds, set once the era has started
+}
+
+// decodeActiveEraInfo SCALE-decodes an ActiveEraInfo { index: u32, start: Option<u64> }
+func decodeActiveEraInfo(raw []byte) (*ActiveEraInfo, error) {
+	decoder := newScaleDecoder(raw)
+
+	index, err := decoder.readU32()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode active era index: %w", err)
+	}
+
+	start, err := decoder.readOptionU64()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode active era start: %w", err)
+	}
+
+	return &ActiveEraInfo{Index: index, Start: start}, nil
+}
+
+// getActiveEraInfo reads and decodes the Staking.ActiveEra storage value, served from the
+// active era cache within its TTL
+func (v *Verifier) getActiveEraInfo(ctx context.Context) (*ActiveEraInfo, error) {
+	if info := prefetchedActiveEra(ctx); info != nil {
+		recordDecoded(ctx, "activeEra", info)
+		return info, nil
+	}
+	if cacheable(ctx) {
+		if info, ok := v.activeEraCache.get(); ok {
+			v.log().DebugContext(ctx, "using cached active era", "event", "active_era_cache_hit", "era", info.Index)
+			return info, nil
+		}
+	}
+
+	raw, err := v.getStorage(ctx, activeEraStorageKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active era: %w", err)
+	}
+	if raw == nil {
+		return nil, ErrActiveEraEmpty
+	}
+
+	info, err := decodeActiveEraInfo(raw)
+	if err != nil {
+		return nil, err
+	}
+	if cacheable(ctx) {
+		v.activeEraCache.put(info)
+	}
+	recordDecoded(ctx, "activeEra", info)
+	return info, nil
+}
+
+// ActiveEra returns the index of the currently active staking era
+func (v *Verifier) ActiveEra(ctx context.Context) (uint32, error) {
+	info, err := v.getActiveEraInfo(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return info.Index, nil
+}
+
+// getEraStartSessionIndex reads Staking.ErasStartSessionIndex for an era.
+// The boolean is false when the era is outside the retained history.
+func (v *Verifier) getEraStartSessionIndex(ctx context.Context, era uint32) (uint32, bool, error) {
+	raw, err := v.getStorage(ctx, erasStartSessionIndexStorageKey(era))
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get start session of era %d: %w", era, err)
+	}
+	if raw == nil {
+		return 0, false, nil
+	}
+
+	session, err := newScaleDecoder(raw).readU32()
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to decode start session of era %d: %w", era, err)
+	}
+
+	return session, true, nil
+}
+
+// EraToTime returns the approximate wall-clock time at which an era started.
+// The active era's start timestamp is read from chain and earlier eras are placed
+// relative to it using Staking.ErasStartSessionIndex and the network's session duration,
+// falling back to whole era durations once the era is outside the retained history.
+// Only the active era can be placed on a network whose session timing isn't known.
+func (v *Verifier) EraToTime(ctx context.Context, era uint32) (time.Time, error) {
+	v.log().DebugContext(ctx, "converting era to wall-clock time", "event", "era_to_time", "era", era)
+
+	activeEra, err := v.getActiveEraInfo(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if activeEra.Start == nil {
+		return time.Time{}, fmt.Errorf("active era %d has not started yet", activeEra.Index)
+	}
+
+	activeStart := time.UnixMilli(int64(*activeEra.Start)).UTC()
+	if era == activeEra.Index {
+		return activeStart, nil
+	}
+
+	sessionDuration := v.network.SessionDuration
+	if sessionDuration == 0 || v.network.SessionsPerEra == 0 {
+		return time.Time{}, fmt.Errorf("session timing of network %s is unknown, only the active era %d can be placed in time", v.network.Name, activeEra.Index)
+	}
+	eraDuration := sessionDuration * time.Duration(v.network.SessionsPerEra)
+
+	// Future eras (e.g. a planned era) are extrapolated forward
+	if era > activeEra.Index {
+		return activeStart.Add(time.Duration(era-activeEra.Index) * eraDuration), nil
+	}
+
+	eraSession, eraFound, err := v.getEraStartSessionIndex(ctx, era)
+	if err != nil {
+		return time.Time{}, err
+	}
+	activeSession, activeFound, err := v.getEraStartSessionIndex(ctx, activeEra.Index)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if eraFound && activeFound && activeSession >= eraSession {
+		sessions := time.Duration(activeSession - eraSession)
+		return activeStart.Add(-sessions * sessionDuration), nil
+	}
+
+	v.log().DebugContext(ctx, "era outside the retained history, extrapolating from era duration", "event", "era_to_time", "era", era)
+	return activeStart.Add(-time.Duration(activeEra.Index-era) * eraDuration), nil
+}
@@ -0,0 +1,83 @@
+package delegation
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultActiveEraCacheTTL keeps the active era for a few minutes: it only changes once per era,
+// and a short TTL still picks up an era boundary promptly
+const DefaultActiveEraCacheTTL = 5 * time.Minute
+
+// activeEraCache holds the last decoded Staking.ActiveEra value, so verifications within the TTL
+// skip its RPC round-trip
+type activeEraCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	info    *ActiveEraInfo
+	expires time.Time
+}
+
+// newActiveEraCache creates a cache keeping the active era for ttl; a non-positive ttl disables it
+func newActiveEraCache(ttl time.Duration) *activeEraCache {
+	return &activeEraCache{ttl: ttl, now: time.Now}
+}
+
+// get returns a copy of the cached active era while it is fresh
+func (c *activeEraCache) get() (*ActiveEraInfo, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.info == nil || !c.now().Before(c.expires) {
+		return nil, false
+	}
+	info := *c.info
+	return &info, true
+}
+
+// put stores a copy of info for the TTL
+func (c *activeEraCache) put(info *ActiveEraInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ttl <= 0 {
+		return
+	}
+	stored := *info
+	c.info = &stored
+	c.expires = c.now().Add(c.ttl)
+}
+
+// clear drops the cached active era
+func (c *activeEraCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.info = nil
+}
+
+// setTTL changes how long the active era is kept; a non-positive ttl disables caching and drops
+// the cached value
+func (c *activeEraCache) setTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ttl = ttl
+	if ttl <= 0 {
+		c.info = nil
+	}
+}
+
+// SetActiveEraCacheTTL sets how long the decoded active era is reused between verifications.
+// Zero disables the cache.
+func (v *Verifier) SetActiveEraCacheTTL(ttl time.Duration) {
+	v.activeEraCache.setTTL(ttl)
+}
+
+// RefreshActiveEra reads the active era from chain, bypassing and then refilling the cache
+func (v *Verifier) RefreshActiveEra(ctx context.Context) (uint32, error) {
+	v.activeEraCache.clear()
+	return v.ActiveEra(ctx)
+}
@@ -0,0 +1,63 @@
+package delegation
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestActiveEra_CachedWithinTTL(t *testing.T) {
+	log.Printf("🧪 Starting TestActiveEra_CachedWithinTTL")
+
+	var reads atomic.Int32
+	era := atomic.Uint32{}
+	era.Store(1000)
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		if method == "state_getStorage" && params[0] == activeEraStorageKey() {
+			reads.Add(1)
+			return activeEraHex(era.Load(), 0), nil
+		}
+		return nil, nil
+	})
+
+	verifier := NewVerifierWithConfig(VerifierConfig{RPCURL: server.URL, ActiveEraCacheTTL: time.Minute})
+	now := time.Now()
+	verifier.activeEraCache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if got, err := verifier.ActiveEra(ctx); err != nil || got != 1000 {
+			t.Fatalf("Expected era 1000, got %d: %v", got, err)
+		}
+	}
+	if reads.Load() != 1 {
+		t.Fatalf("Expected the second call within the TTL to skip the RPC, got %d reads", reads.Load())
+	}
+	log.Printf("✅ Second call within the TTL served from cache")
+
+	era.Store(1001)
+	if got, err := verifier.RefreshActiveEra(ctx); err != nil || got != 1001 || reads.Load() != 2 {
+		t.Fatalf("Expected a forced refresh to read era 1001, got %d after %d reads: %v", got, reads.Load(), err)
+	}
+	if got, _ := verifier.ActiveEra(ctx); got != 1001 || reads.Load() != 2 {
+		t.Fatalf("Expected the refreshed era to be cached, got %d after %d reads", got, reads.Load())
+	}
+	log.Printf("✅ RefreshActiveEra bypassed and refilled the cache")
+
+	era.Store(1002)
+	now = now.Add(time.Minute)
+	if got, err := verifier.ActiveEra(ctx); err != nil || got != 1002 || reads.Load() != 3 {
+		t.Fatalf("Expected an expired entry to be re-read as era 1002, got %d after %d reads: %v", got, reads.Load(), err)
+	}
+	log.Printf("✅ Expired entry re-read from chain")
+
+	verifier.SetActiveEraCacheTTL(0)
+	verifier.ActiveEra(ctx)
+	verifier.ActiveEra(ctx)
+	if reads.Load() != 5 {
+		t.Fatalf("Expected every call to read the chain with the cache disabled, got %d reads", reads.Load())
+	}
+	log.Printf("✅ Disabled cache reads the chain every time")
+}
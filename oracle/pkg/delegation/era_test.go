@@ -0,0 +1,80 @@
+package delegation
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+)
+
+func TestEraToTime(t *testing.T) {
+	log.Printf("🧪 Starting TestEraToTime")
+
+	activeStart := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// Era 1000 is active and started at session 6000; era 995 started at session 5970
+	server := newMockStorageServer(t, map[string]string{
+		activeEraStorageKey():                 activeEraHex(1000, uint64(activeStart.UnixMilli())),
+		erasStartSessionIndexStorageKey(1000): scaleU32Hex(6000),
+		erasStartSessionIndexStorageKey(995):  scaleU32Hex(5970),
+	})
+	verifier := NewVerifier(server.URL)
+
+	cases := []struct {
+		name     string
+		era      uint32
+		expected time.Time
+	}{
+		{"active era", 1000, activeStart},
+		{"past era from session index", 995, activeStart.Add(-30 * 4 * time.Hour)},
+		{"pruned era extrapolated", 900, activeStart.Add(-100 * 24 * time.Hour)},
+		{"next era extrapolated", 1001, activeStart.Add(24 * time.Hour)},
+	}
+
+	for _, tc := range cases {
+		got, err := verifier.EraToTime(context.Background(), tc.era)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got: %v", tc.name, err)
+		}
+		if !got.Equal(tc.expected) {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.expected, got)
+		} else {
+			log.Printf("✅ %s: era %d started around %s", tc.name, tc.era, got)
+		}
+	}
+
+	// Kusama's sessions last an hour, so its eras last six
+	verifier.SetNetwork(Kusama)
+	for era, expected := range map[uint32]time.Time{
+		995: activeStart.Add(-30 * time.Hour),
+		900: activeStart.Add(-100 * 6 * time.Hour),
+	} {
+		got, err := verifier.EraToTime(context.Background(), era)
+		if err != nil || !got.Equal(expected) {
+			t.Fatalf("Kusama era %d: expected %s, got %s: %v", era, expected, got, err)
+		}
+	}
+	log.Printf("✅ Past eras placed with the network's session timing")
+
+	// Without known session timing only the active era can be placed
+	verifier.SetNetwork(Substrate)
+	if got, err := verifier.EraToTime(context.Background(), 1000); err != nil || !got.Equal(activeStart) {
+		t.Fatalf("Expected the active era's start without session timing, got %s: %v", got, err)
+	}
+	if _, err := verifier.EraToTime(context.Background(), 995); err == nil {
+		t.Fatalf("Expected an error placing a past era without session timing")
+	}
+	log.Printf("✅ Unknown session timing reported for past eras")
+}
+
+func TestEraToTime_EmptyActiveEra(t *testing.T) {
+	log.Printf("🧪 Starting TestEraToTime_EmptyActiveEra")
+
+	server := newMockStorageServer(t, map[string]string{})
+	verifier := NewVerifier(server.URL)
+
+	if _, err := verifier.EraToTime(context.Background(), 1); err == nil {
+		t.Fatalf("Expected error when active era storage is empty")
+	}
+	log.Printf("✅ Empty active era reported as error")
+}
@@ -0,0 +1,40 @@
+package delegation
+
+import "errors"
+
+var (
+	// ErrRPCUnavailable is matched, with errors.Is, by every failed call to the RPC endpoint:
+	// network failures, HTTP errors, timeouts and JSON-RPC errors. The fault lies upstream rather
+	// than with the request or the oracle. Use errors.As with *UpstreamError for the method.
+	ErrRPCUnavailable = errors.New("RPC endpoint unavailable")
+	// ErrInvalidAddress is matched, with errors.Is, by an address that isn't a valid SS58 or
+	// 0x-prefixed hex AccountId. Use errors.As with *AddressError for the address.
+	ErrInvalidAddress = errors.New("invalid address")
+)
+
+// UpstreamError is a failed call to the RPC endpoint
+type UpstreamError struct {
+	// Method is the JSON-RPC method that failed
+	Method string
+	Err    error
+}
+
+func (e *UpstreamError) Error() string { return e.Err.Error() }
+
+func (e *UpstreamError) Unwrap() error { return e.Err }
+
+// Is makes every UpstreamError match ErrRPCUnavailable
+func (e *UpstreamError) Is(target error) bool { return target == ErrRPCUnavailable }
+
+// AddressError is an address that couldn't be decoded to an AccountId
+type AddressError struct {
+	Address string
+	Err     error
+}
+
+func (e *AddressError) Error() string { return e.Err.Error() }
+
+func (e *AddressError) Unwrap() error { return e.Err }
+
+// Is makes every AddressError match ErrInvalidAddress
+func (e *AddressError) Is(target error) bool { return target == ErrInvalidAddress }
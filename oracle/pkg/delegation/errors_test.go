@@ -0,0 +1,36 @@
+package delegation
+
+import (
+	"context"
+	"errors"
+	"log"
+	"testing"
+)
+
+func TestVerifierErrorsAreTyped(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifierErrorsAreTyped")
+
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		return nil, &RPCError{Code: -32000, Message: "node is syncing"}
+	})
+	verifier := NewVerifierWithConfig(VerifierConfig{RPCURL: server.URL, MaxRetries: -1, ResultCacheTTL: -1, ActiveEraCacheTTL: -1})
+
+	_, err := verifier.VerifyDelegationDetail(context.Background(), "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty", "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+	var upstream *UpstreamError
+	if !errors.Is(err, ErrRPCUnavailable) || !errors.As(err, &upstream) || upstream.Method == "" {
+		t.Fatalf("Expected an UpstreamError matching ErrRPCUnavailable, got %T: %v", err, err)
+	}
+	log.Printf("✅ JSON-RPC error from %s reported as upstream: %v", upstream.Method, err)
+
+	for _, address := range []string{"0x1234", "0xzz", "not-an-address"} {
+		_, err := accountIDFromAddress(address)
+		var addressErr *AddressError
+		if !errors.Is(err, ErrInvalidAddress) || !errors.As(err, &addressErr) || addressErr.Address != address {
+			t.Fatalf("Expected an AddressError for %q, got %T: %v", address, err, err)
+		}
+		if errors.Is(err, ErrRPCUnavailable) {
+			t.Fatalf("Expected %q not to be reported as upstream", address)
+		}
+	}
+	log.Printf("✅ Undecodable addresses reported as ErrInvalidAddress")
+}
@@ -0,0 +1,85 @@
+package delegation
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Supported formats for ExportStakingExtrinsics
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatJSON = "json"
+)
+
+// exportCSVHeader is the header row written by the CSV exporter
+var exportCSVHeader = []string{"block_number", "extrinsic_index", "method", "success", "timestamp"}
+
+// ExportStakingExtrinsics runs GetStakingExtrinsics and streams the results to w
+// as CSV (with a header row) or as JSON lines, one extrinsic per line
+func (v *Verifier) ExportStakingExtrinsics(ctx context.Context, nominatorAddress, validatorAddress string, w io.Writer, format string) error {
+	format = strings.ToLower(format)
+	if format != ExportFormatCSV && format != ExportFormatJSON {
+		return fmt.Errorf("unsupported export format: %q", format)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	extrinsics, err := v.GetStakingExtrinsicsCtx(ctx, nominatorAddress, validatorAddress)
+	if err != nil {
+		return fmt.Errorf("failed to get staking extrinsics: %w", err)
+	}
+
+	return writeStakingExtrinsics(ctx, w, format, extrinsics)
+}
+
+// writeStakingExtrinsics encodes extrinsics to w in the given format
+func writeStakingExtrinsics(ctx context.Context, w io.Writer, format string, extrinsics []StakingExtrinsic) error {
+	switch format {
+	case ExportFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(exportCSVHeader); err != nil {
+			return fmt.Errorf("failed to write CSV header: %w", err)
+		}
+
+		for _, extrinsic := range extrinsics {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			record := []string{
+				extrinsic.BlockNumber,
+				strconv.Itoa(extrinsic.ExtrinsicIdx),
+				extrinsic.Method,
+				strconv.FormatBool(extrinsic.Success),
+				extrinsic.Timestamp,
+			}
+			if err := writer.Write(record); err != nil {
+				return fmt.Errorf("failed to write CSV row: %w", err)
+			}
+		}
+
+		writer.Flush()
+		return writer.Error()
+
+	case ExportFormatJSON:
+		encoder := json.NewEncoder(w)
+		for _, extrinsic := range extrinsics {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := encoder.Encode(extrinsic); err != nil {
+				return fmt.Errorf("failed to write JSON line: %w", err)
+			}
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported export format: %q", format)
+	}
+}
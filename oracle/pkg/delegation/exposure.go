@@ -0,0 +1,129 @@
+package delegation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// DefaultMaxNominatorRewardedPerValidator is Polkadot's MaxNominatorRewardedPerValidator:
+// only this many of a validator's largest backers are paid rewards
+const DefaultMaxNominatorRewardedPerValidator = 512
+
+// IndividualExposure is one nominator's stake behind a validator
+type IndividualExposure struct {
+	Who   []byte
+	Value *big.Int
+}
+
+// Exposure is the decoded Staking.ErasStakers entry of a validator in an era
+type Exposure struct {
+	Total  *big.Int
+	Own    *big.Int
+	Others []IndividualExposure
+}
+
+// decodeExposure SCALE-decodes Exposure { total: Compact<u128>, own: Compact<u128>, others: Vec<IndividualExposure> }
+func decodeExposure(raw []byte) (*Exposure, error) {
+	decoder := newScaleDecoder(raw)
+
+	total, err := decoder.readCompactBig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode exposure total: %w", err)
+	}
+
+	own, err := decoder.readCompactBig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode exposure own stake: %w", err)
+	}
+
+	count, err := decoder.readCompact()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode exposure length: %w", err)
+	}
+	// Each entry is at least a 32-byte account and a one-byte compact
+	if count > uint64(decoder.remaining()/33) {
+		return nil, fmt.Errorf("exposure length %d exceeds remaining data", count)
+	}
+
+	others := make([]IndividualExposure, 0, count)
+	for i := uint64(0); i < count; i++ {
+		who, err := decoder.readBytes(32)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode exposure account: %w", err)
+		}
+		value, err := decoder.readCompactBig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode exposure value: %w", err)
+		}
+		others = append(others, IndividualExposure{Who: append([]byte{}, who...), Value: value})
+	}
+
+	return &Exposure{Total: total, Own: own, Others: others}, nil
+}
+
+// SetCheckOverSubscribed makes VerifyV2 report whether the nominator falls outside the
+// validator's rewarded backers in the active era
+func (v *Verifier) SetCheckOverSubscribed(check bool) {
+	v.checkOverSubscribed = check
+}
+
+// SetMaxNominatorRewardedPerValidator overrides the rewarded-backer cap used by CheckOverSubscribed
+func (v *Verifier) SetMaxNominatorRewardedPerValidator(limit int) {
+	v.maxNominatorRewarded = limit
+}
+
+// CheckOverSubscribed reports whether the nominator backs the validator in the active era but
+// ranks past MaxNominatorRewardedPerValidator by stake, so it earns no rewards despite nominating.
+// The returned position is the nominator's zero-based rank among the validator's backers,
+// or -1 when it isn't in the exposure at all.
+func (v *Verifier) CheckOverSubscribed(ctx context.Context, nominatorAddress, validatorAddress string) (bool, int, error) {
+	nominatorID, err := accountIDFromAddress(nominatorAddress)
+	if err != nil {
+		return false, -1, fmt.Errorf("invalid nominator address: %w", err)
+	}
+	validatorID, err := accountIDFromAddress(validatorAddress)
+	if err != nil {
+		return false, -1, fmt.Errorf("invalid validator address: %w", err)
+	}
+
+	activeEra, err := v.getActiveEraInfo(ctx)
+	if err != nil {
+		return false, -1, err
+	}
+
+	raw, err := v.getStorage(ctx, erasStakersStorageKey(activeEra.Index, validatorID))
+	if err != nil {
+		return false, -1, fmt.Errorf("failed to query validator exposure: %w", err)
+	}
+	if raw == nil {
+		return false, -1, nil
+	}
+
+	exposure, err := decodeExposure(raw)
+	if err != nil {
+		return false, -1, err
+	}
+	recordDecoded(ctx, "exposure", exposure)
+
+	// Rewards go to the largest backers first
+	others := exposure.Others
+	sort.SliceStable(others, func(i, j int) bool {
+		return others[i].Value.Cmp(others[j].Value) > 0
+	})
+
+	for position, backer := range others {
+		if bytes.Equal(backer.Who, nominatorID) {
+			overSubscribed := position >= v.maxNominatorRewarded
+			if overSubscribed {
+				v.log().InfoContext(ctx, "nominator ranks past the rewarded cap", "event", "over_subscribed", "nominator", nominatorAddress, "validator", validatorAddress,
+					"rank", position+1, "backers", len(others), "cap", v.maxNominatorRewarded)
+			}
+			return overSubscribed, position, nil
+		}
+	}
+
+	return false, -1, nil
+}
@@ -0,0 +1,72 @@
+package delegation
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"log"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// exposureHex SCALE-encodes an Exposure with the given backers, in the given order
+func exposureHex(own int64, others []IndividualExposure) string {
+	total := big.NewInt(own)
+	for _, backer := range others {
+		total.Add(total, backer.Value)
+	}
+
+	encoded := compactBigHex(total) + compactBigHex(big.NewInt(own))
+	encoded += hex.EncodeToString([]byte{byte(len(others) << 2)})
+	for _, backer := range others {
+		encoded += hex.EncodeToString(backer.Who) + compactBigHex(backer.Value)
+	}
+	return "0x" + encoded
+}
+
+func TestCheckOverSubscribed(t *testing.T) {
+	log.Printf("🧪 Starting TestCheckOverSubscribed")
+
+	validatorID := bytes.Repeat([]byte{0xff}, 32)
+	backer := func(b byte, stake int64) IndividualExposure {
+		return IndividualExposure{Who: bytes.Repeat([]byte{b}, 32), Value: big.NewInt(stake)}
+	}
+
+	// Unsorted on chain; by stake the order is 0x02, 0x04, 0x01, 0x03
+	others := []IndividualExposure{backer(0x01, 300), backer(0x02, 900), backer(0x03, 100), backer(0x04, 500)}
+
+	server := newMockStorageServer(t, map[string]string{
+		activeEraStorageKey():                    activeEraHex(1000, uint64(time.Now().UnixMilli())),
+		erasStakersStorageKey(1000, validatorID): exposureHex(1000, others),
+	})
+	verifier := NewVerifier(server.URL)
+	verifier.SetMaxNominatorRewardedPerValidator(2)
+
+	validator := "0x" + hex.EncodeToString(validatorID)
+	cases := []struct {
+		name           string
+		nominator      byte
+		overSubscribed bool
+		position       int
+	}{
+		{"largest backer rewarded", 0x02, false, 0},
+		{"second backer at the cap", 0x04, false, 1},
+		{"third backer past the cap", 0x01, true, 2},
+		{"smallest backer past the cap", 0x03, true, 3},
+		{"not a backer", 0x05, false, -1},
+	}
+
+	for _, tc := range cases {
+		nominator := "0x" + hex.EncodeToString(bytes.Repeat([]byte{tc.nominator}, 32))
+		overSubscribed, position, err := verifier.CheckOverSubscribed(context.Background(), nominator, validator)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got: %v", tc.name, err)
+		}
+		if overSubscribed != tc.overSubscribed || position != tc.position {
+			t.Errorf("%s: expected over-subscribed=%v position=%d, got %v/%d", tc.name, tc.overSubscribed, tc.position, overSubscribed, position)
+		} else {
+			log.Printf("✅ %s: position %d, over-subscribed=%v", tc.name, position, overSubscribed)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package delegation
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// nominateCallIndex is the index of nominate among pallet_staking's calls
+const nominateCallIndex = 5
+
+// stakingCallNames names pallet_staking's calls by index
+var stakingCallNames = []string{
+	"bond",
+	"bond_extra",
+	"unbond",
+	"withdraw_unbonded",
+	"validate",
+	"nominate",
+	"chill",
+	"set_payee",
+	"set_controller",
+}
+
+// extrinsicVersion is the only extrinsic format version the decoder understands
+const extrinsicVersion = 4
+
+// DecodedExtrinsic is the signer and call of a SCALE-decoded extrinsic
+type DecodedExtrinsic struct {
+	// Signed is set for signed extrinsics
+	Signed bool
+	// Signer is the signing account, or nil when unsigned or not given as an AccountId
+	Signer      []byte
+	PalletIndex byte
+	CallIndex   byte
+	// Args holds the call's still-encoded arguments
+	Args []byte
+}
+
+// decodeExtrinsicHex decodes a hex-encoded extrinsic as returned by chain_getBlock
+func decodeExtrinsicHex(extrinsic interface{}) (*DecodedExtrinsic, error) {
+	encoded, ok := extrinsic.(string)
+	if !ok {
+		return nil, fmt.Errorf("extrinsic is not a hex string")
+	}
+	raw, err := hex.DecodeString(strings.TrimPrefix(encoded, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid extrinsic hex: %w", err)
+	}
+	return decodeExtrinsic(raw)
+}
+
+// decodeExtrinsic SCALE-decodes a length-prefixed v4 extrinsic:
+// Compact<len> ++ version ++ [address ++ signature ++ extra] ++ pallet index ++ call index ++ args.
+// The signed extra is era, Compact<nonce>, Compact<tip> and, on runtimes with CheckMetadataHash,
+// a one-byte mode. That mode is 0 or 1, which no staking pallet index is, so it's skipped when present.
+func decodeExtrinsic(raw []byte) (*DecodedExtrinsic, error) {
+	decoder := newScaleDecoder(raw)
+
+	length, err := decoder.readCompact()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode extrinsic length: %w", err)
+	}
+	if length != uint64(decoder.remaining()) {
+		return nil, fmt.Errorf("extrinsic length %d does not match %d bytes of data", length, decoder.remaining())
+	}
+
+	version, err := decoder.readU8()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode extrinsic version: %w", err)
+	}
+	if version&0x7f != extrinsicVersion {
+		return nil, fmt.Errorf("unsupported extrinsic version %d", version&0x7f)
+	}
+
+	decoded := &DecodedExtrinsic{Signed: version&0x80 != 0}
+	if decoded.Signed {
+		if decoded.Signer, err = decoder.readMultiAddress(); err != nil {
+			return nil, fmt.Errorf("failed to decode extrinsic signer: %w", err)
+		}
+		if err := decoder.skipMultiSignature(); err != nil {
+			return nil, fmt.Errorf("failed to decode extrinsic signature: %w", err)
+		}
+		if err := decoder.skipSignedExtra(); err != nil {
+			return nil, fmt.Errorf("failed to decode extrinsic extra: %w", err)
+		}
+	}
+
+	call, err := decoder.readBytes(2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode extrinsic call: %w", err)
+	}
+	decoded.PalletIndex, decoded.CallIndex = call[0], call[1]
+	decoded.Args = decoder.data[decoder.offset:]
+
+	return decoded, nil
+}
+
+// nominateTargets decodes the targets of a Staking.nominate call: Vec<MultiAddress>.
+// Targets given by account index can't be resolved without the Indices pallet and are left out.
+func (e *DecodedExtrinsic) nominateTargets() ([][]byte, error) {
+	decoder := newScaleDecoder(e.Args)
+
+	count, err := decoder.readCompact()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode nomination targets length: %w", err)
+	}
+	// Each target is at least a one-byte variant and a one-byte index
+	if count > uint64(decoder.remaining()/2) {
+		return nil, fmt.Errorf("nomination targets length %d exceeds remaining data", count)
+	}
+
+	targets := make([][]byte, 0, count)
+	for i := uint64(0); i < count; i++ {
+		target, err := decoder.readMultiAddress()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode nomination target: %w", err)
+		}
+		if target != nil {
+			targets = append(targets, target)
+		}
+	}
+	if decoder.remaining() != 0 {
+		return nil, fmt.Errorf("%d trailing bytes after nomination targets", decoder.remaining())
+	}
+	return targets, nil
+}
+
+// readMultiAddress consumes a MultiAddress, returning the AccountId it names,
+// or nil for the Index, Raw and Address20 variants
+func (d *scaleDecoder) readMultiAddress() ([]byte, error) {
+	variant, err := d.readU8()
+	if err != nil {
+		return nil, err
+	}
+
+	switch variant {
+	case 0x00, 0x03: // Id, Address32
+		account, err := d.readBytes(32)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{}, account...), nil
+	case 0x01: // Index
+		_, err := d.readCompact()
+		return nil, err
+	case 0x02: // Raw
+		length, err := d.readCompact()
+		if err != nil {
+			return nil, err
+		}
+		if length > uint64(d.remaining()) {
+			return nil, fmt.Errorf("raw address length %d exceeds remaining data", length)
+		}
+		_, err = d.readBytes(int(length))
+		return nil, err
+	case 0x04: // Address20
+		_, err := d.readBytes(20)
+		return nil, err
+	default:
+		return nil, fmt.Errorf("invalid MultiAddress variant: 0x%02x", variant)
+	}
+}
+
+// skipMultiSignature consumes a MultiSignature: Ed25519, Sr25519 or Ecdsa
+func (d *scaleDecoder) skipMultiSignature() error {
+	variant, err := d.readU8()
+	if err != nil {
+		return err
+	}
+
+	switch variant {
+	case 0x00, 0x01: // Ed25519, Sr25519
+		_, err = d.readBytes(64)
+	case 0x02: // Ecdsa
+		_, err = d.readBytes(65)
+	default:
+		err = fmt.Errorf("invalid MultiSignature variant: 0x%02x", variant)
+	}
+	return err
+}
+
+// skipSignedExtra consumes the era, nonce, tip and optional metadata hash mode of a signed extrinsic
+func (d *scaleDecoder) skipSignedExtra() error {
+	era, err := d.readU8()
+	if err != nil {
+		return err
+	}
+	// An immortal era is a single zero byte, a mortal one takes two
+	if era != 0 {
+		if _, err := d.readU8(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := d.readCompact(); err != nil { // nonce
+		return err
+	}
+	if _, err := d.readCompactBig(); err != nil { // tip
+		return err
+	}
+
+	if d.remaining() > 0 && d.data[d.offset] <= 1 {
+		d.offset++
+	}
+	return nil
+}
+
+// describeStakingCall names a staking extrinsic's call as pallet.call and collects its signer
+// and, for nominate, its targets
+func (v *Verifier) describeStakingCall(extrinsic interface{}) (string, map[string]interface{}) {
+	decoded, err := decodeExtrinsicHex(extrinsic)
+	if err != nil {
+		return "staking.unknown", nil
+	}
+
+	if v.isNominateCall(decoded) {
+		if targets, err := decoded.nominateTargets(); err == nil {
+			return "staking.nominate", v.nominationMethod(decoded, targets)
+		}
+	}
+
+	call := fmt.Sprintf("call_%d", decoded.CallIndex)
+	if int(decoded.CallIndex) < len(stakingCallNames) {
+		call = stakingCallNames[decoded.CallIndex]
+	}
+	params := map[string]interface{}{
+		"pallet": "staking",
+		"call":   call,
+	}
+	if decoded.Signer != nil {
+		params["signer"] = encodeSS58(v.network.SS58Prefix, decoded.Signer)
+	}
+	return "staking." + call, params
+}
+
+// isStakingCall reports whether the extrinsic calls the network's staking pallet
+func (v *Verifier) isStakingCall(extrinsic *DecodedExtrinsic) bool {
+	return v.network.StakingPalletIndex != 0 && extrinsic.PalletIndex == v.network.StakingPalletIndex
+}
+
+// isNominateCall reports whether the extrinsic is a Staking.nominate call
+func (v *Verifier) isNominateCall(extrinsic *DecodedExtrinsic) bool {
+	return v.isStakingCall(extrinsic) && extrinsic.CallIndex == nominateCallIndex
+}
@@ -0,0 +1,294 @@
+package delegation
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"reflect"
+	"testing"
+)
+
+const testBlockHash = "0xabababababababababababababababababababababababababababababababab"
+
+// nominateExtrinsicHex is a signed v4 Staking.nominate extrinsic in Polkadot's layout:
+// Bob, with an Sr25519 signature, mortal era, nonce 1, no tip and the CheckMetadataHash
+// mode byte, nominates Alice given as MultiAddress::Id
+const nominateExtrinsicHex = "0x310284008eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48" +
+	"01" + "11111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111" +
+	"f502040000" + "0705" + "0400d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d"
+
+// nominateExtrinsicNoModeHex is nominateExtrinsicHex from a runtime without CheckMetadataHash
+const nominateExtrinsicNoModeHex = "0x2d0284008eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48" +
+	"01" + "11111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111111" +
+	"f5020400" + "0705" + "0400d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d"
+
+func TestGetExtrinsicInfo_NotFound(t *testing.T) {
+	log.Printf("🧪 Starting TestGetExtrinsicInfo_NotFound")
+
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		switch method {
+		case "chain_getBlock":
+			return mockBlock("0x280403000b"), nil
+		case "chain_getFinalizedHead":
+			return testBlockHash, nil
+		case "state_getStorage":
+			if len(params) > 1 {
+				// Bob nominates Alice at the finalized head
+				return nominationsHex([][]byte{aliceAccountID}, 1, false), nil
+			}
+			return activeEraHex(1, 0), nil
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+	verifier := NewVerifier(server.URL)
+
+	info, err := verifier.getExtrinsicInfo(testBlockHash)
+	if !errors.Is(err, ErrNoNominationExtrinsic) {
+		t.Fatalf("Expected ErrNoNominationExtrinsic, got: %v", err)
+	}
+	if info != nil {
+		t.Fatalf("Expected nil info, got: %+v", info)
+	}
+	log.Printf("✅ getExtrinsicInfo returned ErrNoNominationExtrinsic")
+
+	// The extrinsic path is best-effort, so verification falls through to storage
+	ok, err := verifier.VerifyDelegationWithExtrinsic(testBlockHash, "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty", "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+	if err != nil {
+		t.Fatalf("Expected fall through to storage verification, got error: %v", err)
+	}
+	if !ok {
+		t.Fatalf("Expected delegation to verify via storage")
+	}
+	log.Printf("✅ VerifyDelegationWithExtrinsic fell through to storage verification")
+}
+
+func TestGetExtrinsicInfo_Found(t *testing.T) {
+	log.Printf("🧪 Starting TestGetExtrinsicInfo_Found")
+
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		switch method {
+		case "chain_getBlock":
+			return mockBlock("0x280403000b", nominateExtrinsicHex), nil
+		case "state_getStorage":
+			return "0x01000000", nil
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+	verifier := NewVerifier(server.URL)
+
+	info, err := verifier.getExtrinsicInfo(testBlockHash)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if info.ExtrinsicIdx != 1 {
+		t.Fatalf("Expected extrinsic index 1, got %d", info.ExtrinsicIdx)
+	}
+	targets, _ := info.Method["targets"].([]string)
+	if !reflect.DeepEqual(targets, []string{"15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5"}) {
+		t.Fatalf("Expected Alice as the only target, got %v", info.Method["targets"])
+	}
+	if info.Method["signer"] != "14E5nqKAp3oAJcmzgZhUD2RcptBeUBScxKHgJKU4HPNcKVf3" {
+		t.Fatalf("Expected Bob as the signer, got %v", info.Method["signer"])
+	}
+	log.Printf("✅ Found nomination extrinsic at index %d targeting %v", info.ExtrinsicIdx, targets)
+}
+
+func TestVerifyDelegationWithExtrinsic_ForeignNomination(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyDelegationWithExtrinsic_ForeignNomination")
+
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		switch method {
+		case "chain_getBlock":
+			// The block's only nomination is Bob nominating Alice
+			return mockBlock("0x280403000b", nominateExtrinsicHex), nil
+		case "chain_getFinalizedHead":
+			return testBlockHash, nil
+		case "state_getStorage":
+			if len(params) > 1 {
+				// Storage alone would accept any of the pairs below
+				return nominationsHex([][]byte{aliceAccountID, bytes.Repeat([]byte{0x05}, 32)}, 1, false), nil
+			}
+			return activeEraHex(1, 0), nil
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+	verifier := NewVerifier(server.URL)
+
+	bob := "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty"
+	alice := "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+	charlie := encodeSS58(42, bytes.Repeat([]byte{0x05}, 32))
+
+	ok, err := verifier.VerifyDelegationWithExtrinsic(testBlockHash, bob, alice)
+	if err != nil || !ok {
+		t.Fatalf("Expected Bob's own nomination of Alice to verify, got %v: %v", ok, err)
+	}
+	log.Printf("✅ Nomination signed by the nominator for the validator accepted")
+
+	cases := []struct {
+		name      string
+		nominator string
+		validator string
+	}{
+		{"signed by another account", charlie, alice},
+		{"targeting another validator", bob, charlie},
+	}
+	for _, tc := range cases {
+		ok, err := verifier.VerifyDelegationWithExtrinsic(testBlockHash, tc.nominator, tc.validator)
+		if ok || err == nil {
+			t.Fatalf("%s: expected the foreign nomination to be rejected, got %v: %v", tc.name, ok, err)
+		}
+		if errors.Is(err, ErrNoNominationExtrinsic) {
+			t.Fatalf("%s: expected a mismatch, not a missing nomination: %v", tc.name, err)
+		}
+		log.Printf("✅ Nomination %s rejected: %v", tc.name, err)
+	}
+}
+
+func TestDecodeExtrinsic_Nominate(t *testing.T) {
+	log.Printf("🧪 Starting TestDecodeExtrinsic_Nominate")
+
+	bobAccountID, _ := accountIDFromAddress("5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty")
+
+	for name, encoded := range map[string]string{
+		"with metadata hash mode":    nominateExtrinsicHex,
+		"without metadata hash mode": nominateExtrinsicNoModeHex,
+	} {
+		decoded, err := decodeExtrinsicHex(encoded)
+		if err != nil {
+			t.Fatalf("%s: expected extrinsic to decode, got: %v", name, err)
+		}
+		if !decoded.Signed || !bytes.Equal(decoded.Signer, bobAccountID) {
+			t.Fatalf("%s: expected Bob as the signer, got %x", name, decoded.Signer)
+		}
+		if decoded.PalletIndex != Polkadot.StakingPalletIndex || decoded.CallIndex != nominateCallIndex {
+			t.Fatalf("%s: expected Staking.nominate, got call %d.%d", name, decoded.PalletIndex, decoded.CallIndex)
+		}
+
+		targets, err := decoded.nominateTargets()
+		if err != nil {
+			t.Fatalf("%s: expected targets to decode, got: %v", name, err)
+		}
+		if len(targets) != 1 || !bytes.Equal(targets[0], aliceAccountID) {
+			t.Fatalf("%s: expected Alice as the only target, got %x", name, targets)
+		}
+		log.Printf("✅ %s: decoded Staking.nominate with %d target", name, len(targets))
+	}
+
+	// The timestamp inherent is unsigned and calls another pallet
+	decoded, err := decodeExtrinsicHex("0x280403000b20f9a58c0100")
+	if err != nil {
+		t.Fatalf("Expected inherent to decode, got: %v", err)
+	}
+	if decoded.Signed || decoded.PalletIndex != 3 || decoded.CallIndex != 0 {
+		t.Fatalf("Expected unsigned Timestamp.set, got %+v", decoded)
+	}
+	log.Printf("✅ Decoded unsigned inherent")
+}
+
+func TestDecodeExtrinsic_RejectsMalformed(t *testing.T) {
+	log.Printf("🧪 Starting TestDecodeExtrinsic_RejectsMalformed")
+
+	cases := map[string]interface{}{
+		"not a string":    map[string]interface{}{"method": "staking.nominate"},
+		"not hex":         "staking.nominate",
+		"length mismatch": "0x2c0403000b",
+		"truncated":       nominateExtrinsicHex[:80],
+		"version 5":       "0x280503000b",
+	}
+	for name, extrinsic := range cases {
+		if _, err := decodeExtrinsicHex(extrinsic); err == nil {
+			t.Fatalf("%s: expected decoding to fail", name)
+		}
+		log.Printf("✅ %s rejected", name)
+	}
+}
+
+func TestIsStakingExtrinsic(t *testing.T) {
+	log.Printf("🧪 Starting TestIsStakingExtrinsic")
+
+	verifier := NewVerifier("http://127.0.0.1:0")
+	bob := "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty"
+	alice := "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+	charlie := "5FLSigC9HGRKVhB9FiEo4Y3koPsNmBmLJbpXg2mp1hXcS59Y"
+
+	if !verifier.isStakingExtrinsic(nominateExtrinsicHex, bob, charlie) {
+		t.Fatalf("Expected extrinsic signed by the nominator to match")
+	}
+	if !verifier.isStakingExtrinsic(nominateExtrinsicHex, charlie, alice) {
+		t.Fatalf("Expected nomination targeting the validator to match")
+	}
+	if verifier.isStakingExtrinsic(nominateExtrinsicHex, charlie, charlie) {
+		t.Fatalf("Expected extrinsic unrelated to the addresses not to match")
+	}
+	// Text merely mentioning staking and the addresses is not an extrinsic
+	if verifier.isStakingExtrinsic("staking.nominate "+bob+" "+alice, bob, alice) {
+		t.Fatalf("Expected substring match to be rejected")
+	}
+	log.Printf("✅ Staking extrinsics matched by decoded signer and targets")
+
+	// On Kusama the staking pallet sits at another index
+	verifier.SetNetwork(Kusama)
+	if verifier.isStakingExtrinsic(nominateExtrinsicHex, bob, alice) {
+		t.Fatalf("Expected Polkadot staking call not to match on Kusama")
+	}
+	log.Printf("✅ Staking pallet index follows the network")
+}
+
+func TestVerifyDelegationWithExtrinsic_RPCFailure(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyDelegationWithExtrinsic_RPCFailure")
+
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		return nil, &RPCError{Code: -32000, Message: "unknown block"}
+	})
+	verifier := NewVerifier(server.URL)
+
+	_, err := verifier.VerifyDelegationWithExtrinsic(testBlockHash, "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty", "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+	if err == nil {
+		t.Fatalf("Expected RPC failure to be reported")
+	}
+	if errors.Is(err, ErrNoNominationExtrinsic) {
+		t.Fatalf("RPC failure must not be reported as ErrNoNominationExtrinsic")
+	}
+	log.Printf("✅ RPC failure reported distinctly: %v", err)
+}
+
+func TestRemoveDuplicateExtrinsics_KeepsDistinctHashlessExtrinsics(t *testing.T) {
+	log.Printf("🧪 Starting TestRemoveDuplicateExtrinsics_KeepsDistinctHashlessExtrinsics")
+
+	verifier := NewVerifier("http://127.0.0.1:0")
+	extrinsics := []StakingExtrinsic{
+		{BlockHash: testBlockHash, ExtrinsicIdx: 1, Method: "staking.nominate"},
+		{BlockHash: testBlockHash, ExtrinsicIdx: 2, Method: "staking.bond"},
+		{BlockHash: testBlockHash, ExtrinsicIdx: 2, Method: "staking.bond"},
+		{ExtrinsicHash: "0x01", BlockHash: testBlockHash, ExtrinsicIdx: 3},
+		{ExtrinsicHash: "0x01", BlockHash: testBlockHash, ExtrinsicIdx: 3},
+	}
+
+	unique := verifier.removeDuplicateExtrinsics(extrinsics)
+	if len(unique) != 3 {
+		t.Fatalf("Expected 3 unique extrinsics, got %d: %+v", len(unique), unique)
+	}
+	if unique[0].ExtrinsicIdx != 1 || unique[1].ExtrinsicIdx != 2 {
+		t.Fatalf("Expected both hash-less extrinsics to survive, got %+v", unique)
+	}
+	log.Printf("✅ Distinct hash-less extrinsics survived dedup")
+}
+
+func TestExtrinsicHash(t *testing.T) {
+	log.Printf("🧪 Starting TestExtrinsicHash")
+
+	// blake2b-256 of the empty input
+	if got := extrinsicHash("0x"); got != "0x0e5751c026e543b2e8ab2eb06099daa1d1e5df47778f7787faab45cdf12fe3a8" {
+		t.Fatalf("Unexpected hash of empty extrinsic: %s", got)
+	}
+	if extrinsicHash("0x280403000b") == extrinsicHash("0x280403000c") {
+		t.Fatalf("Expected distinct extrinsics to hash differently")
+	}
+	if got := extrinsicHash("not hex"); got != "" {
+		t.Fatalf("Expected empty hash for invalid hex, got %s", got)
+	}
+	if got := extrinsicHash(map[string]interface{}{}); got != "" {
+		t.Fatalf("Expected empty hash for non-string extrinsic, got %s", got)
+	}
+	log.Printf("✅ Extrinsic hashes computed from raw bytes")
+}
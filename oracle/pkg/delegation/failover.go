@@ -0,0 +1,75 @@
+package delegation
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+)
+
+// failoverTransport sends every request to the endpoint that last answered, moving on to the
+// next endpoint in order, and wrapping around, when it fails. A request only fails once every
+// endpoint has failed it, with the last endpoint's error, so retries still see transient failures.
+type failoverTransport struct {
+	endpoints []rpcTransport
+	current   atomic.Int32 // index of the last endpoint that answered
+	// log returns the logger failovers are reported to; the verifier sets it to its own
+	log func() *slog.Logger
+}
+
+func newFailoverTransport(endpoints []rpcTransport) *failoverTransport {
+	return &failoverTransport{endpoints: endpoints, log: slog.Default}
+}
+
+func (t *failoverTransport) roundTrip(ctx context.Context, request RPCRequest) (*RPCResponse, error) {
+	var response *RPCResponse
+	err := t.try(ctx, func(endpoint rpcTransport) (err error) {
+		response, err = endpoint.roundTrip(ctx, request)
+		return err
+	})
+	return response, err
+}
+
+func (t *failoverTransport) roundTripBatch(ctx context.Context, requests []RPCRequest) ([]RPCResponse, error) {
+	var responses []RPCResponse
+	err := t.try(ctx, func(endpoint rpcTransport) (err error) {
+		responses, err = endpoint.roundTripBatch(ctx, requests)
+		return err
+	})
+	return responses, err
+}
+
+// try calls send with each endpoint in turn, starting from the current one, until one succeeds,
+// which becomes the current endpoint. It gives up early once ctx is done.
+func (t *failoverTransport) try(ctx context.Context, send func(rpcTransport) error) error {
+	start := int(t.current.Load())
+
+	var err error
+	for i := range t.endpoints {
+		index := (start + i) % len(t.endpoints)
+		if err = send(t.endpoints[index]); err == nil {
+			if index != start && t.current.CompareAndSwap(int32(start), int32(index)) {
+				t.log().WarnContext(ctx, "failed over to another RPC endpoint", "event", "rpc_failover",
+					"from", t.endpoints[start].endpoint(), "to", t.endpoints[index].endpoint())
+			}
+			return nil
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+		t.log().DebugContext(ctx, "RPC endpoint failed", "event", "rpc_failover", "rpc_url", t.endpoints[index].endpoint(), "error", err)
+	}
+	return err
+}
+
+func (t *failoverTransport) endpoint() string {
+	return t.endpoints[t.current.Load()].endpoint()
+}
+
+func (t *failoverTransport) close() error {
+	var errs []error
+	for _, endpoint := range t.endpoints {
+		errs = append(errs, endpoint.close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,104 @@
+package delegation
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// newFailingRPCServer answers every request with HTTP 500, counting them in calls
+func newFailingRPCServer(t *testing.T, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Error(w, "upstream down", http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFailover_UsesNextEndpointAndRemembersIt(t *testing.T) {
+	log.Printf("🧪 Starting TestFailover_UsesNextEndpointAndRemembersIt")
+
+	var downCalls, upCalls atomic.Int32
+	down := newFailingRPCServer(t, &downCalls)
+	up := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		upCalls.Add(1)
+		return testBlockHash, nil
+	})
+
+	verifier := NewVerifierWithConfig(VerifierConfig{RPCURL: down.URL + ", " + up.URL, MaxRetries: -1})
+	defer verifier.Close()
+	if verifier.RPCURL() != down.URL {
+		t.Fatalf("Expected the first endpoint to start active, got %s", verifier.RPCURL())
+	}
+
+	request := RPCRequest{JSONRPC: "2.0", Method: "chain_getFinalizedHead", Params: []interface{}{}, ID: 1}
+	result, err := verifier.makeRPCCall(request)
+	if err != nil || result != testBlockHash {
+		t.Fatalf("Expected the second endpoint to answer, got %v, %v", result, err)
+	}
+	if downCalls.Load() != 1 || upCalls.Load() != 1 {
+		t.Fatalf("Expected one call to each endpoint, got %d and %d", downCalls.Load(), upCalls.Load())
+	}
+	if verifier.RPCURL() != up.URL {
+		t.Fatalf("Expected the second endpoint to become active, got %s", verifier.RPCURL())
+	}
+	log.Printf("✅ Failed over from %s to %s", down.URL, verifier.RPCURL())
+
+	if _, err := verifier.makeRPCCall(request); err != nil {
+		t.Fatalf("Expected the next call to succeed, got: %v", err)
+	}
+	if downCalls.Load() != 1 || upCalls.Load() != 2 {
+		t.Fatalf("Expected the next call to go straight to the last good endpoint, got %d and %d calls", downCalls.Load(), upCalls.Load())
+	}
+	log.Printf("✅ Last good endpoint remembered")
+}
+
+func TestFailover_FailsOnlyAfterEveryEndpoint(t *testing.T) {
+	log.Printf("🧪 Starting TestFailover_FailsOnlyAfterEveryEndpoint")
+
+	var firstCalls, secondCalls atomic.Int32
+	first := newFailingRPCServer(t, &firstCalls)
+	second := newFailingRPCServer(t, &secondCalls)
+
+	verifier := NewVerifierWithConfig(VerifierConfig{RPCURL: first.URL + "," + second.URL, MaxRetries: -1})
+	defer verifier.Close()
+
+	_, err := verifier.makeRPCCall(RPCRequest{JSONRPC: "2.0", Method: "chain_getFinalizedHead", Params: []interface{}{}, ID: 1})
+	if !errors.Is(err, ErrRPCUnavailable) || !isRetryable(err) {
+		t.Fatalf("Expected a retryable upstream error once both endpoints failed, got: %v", err)
+	}
+	if firstCalls.Load() != 1 || secondCalls.Load() != 1 {
+		t.Fatalf("Expected each endpoint to be tried once, got %d and %d", firstCalls.Load(), secondCalls.Load())
+	}
+	if verifier.RPCURL() != first.URL {
+		t.Fatalf("Expected the active endpoint to stay put when none answers, got %s", verifier.RPCURL())
+	}
+	log.Printf("✅ Failed after every endpoint: %v", err)
+}
+
+func TestSplitRPCURLs(t *testing.T) {
+	log.Printf("🧪 Starting TestSplitRPCURLs")
+
+	urls := splitRPCURLs(" https://a.example , wss://b.example,,")
+	if len(urls) != 2 || urls[0] != "https://a.example" || urls[1] != "wss://b.example" {
+		t.Fatalf("Expected two trimmed URLs, got %q", urls)
+	}
+
+	failover, ok := NewVerifierWithConfig(VerifierConfig{RPCURL: "https://a.example, wss://b.example"}).transport.(*failoverTransport)
+	if !ok {
+		t.Fatalf("Expected a failover transport for two endpoints")
+	}
+	if _, ok := failover.endpoints[0].(*httpTransport); !ok {
+		t.Fatalf("Expected an HTTP transport for the first endpoint, got %T", failover.endpoints[0])
+	}
+	if _, ok := failover.endpoints[1].(*wsTransport); !ok {
+		t.Fatalf("Expected a WebSocket transport for the second endpoint, got %T", failover.endpoints[1])
+	}
+	log.Printf("✅ Endpoint list split: %q", urls)
+}
@@ -0,0 +1,77 @@
+package delegation
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// rpcHealth is the result of the latest background reachability probe
+type rpcHealth struct {
+	down atomic.Bool // zero value: healthy until a probe fails
+}
+
+// probeRPC checks that the RPC endpoint answers a cheap call
+func (v *Verifier) probeRPC(ctx context.Context) error {
+	request := RPCRequest{
+		JSONRPC: "2.0",
+		Method:  "system_health",
+		Params:  []interface{}{},
+		ID:      1,
+	}
+	_, err := v.makeRPCCallCtx(ctx, request)
+	return err
+}
+
+// StartHealthPoller probes the RPC endpoint every interval until ctx is cancelled,
+// recording the outcome for RPCHealthy. The first probe runs immediately.
+func (v *Verifier) StartHealthPoller(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			probeCtx, cancel := context.WithTimeout(ctx, interval)
+			err := v.probeRPC(probeCtx)
+			cancel()
+
+			wasDown := v.health.down.Swap(err != nil)
+			if err != nil && !wasDown {
+				v.log().WarnContext(ctx, "RPC endpoint is unreachable", "event", "rpc_health", "rpc_url", v.RPCURL(), "error", err)
+			} else if err == nil && wasDown {
+				v.log().InfoContext(ctx, "RPC endpoint is reachable again", "event", "rpc_health", "rpc_url", v.RPCURL())
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// RPCHealthy reports whether the latest background probe reached the RPC endpoint.
+// Without a running poller the endpoint is assumed healthy.
+func (v *Verifier) RPCHealthy() bool {
+	return !v.health.down.Load()
+}
+
+// CheckReady makes a live chain_getHeader call, returning an error when the RPC endpoint
+// can't serve it. Unlike RPCHealthy it doesn't rely on the background poller.
+func (v *Verifier) CheckReady(ctx context.Context) error {
+	request := RPCRequest{
+		JSONRPC: "2.0",
+		Method:  "chain_getHeader",
+		Params:  []interface{}{},
+		ID:      1,
+	}
+	_, err := v.makeRPCCallCtx(ctx, request)
+	return err
+}
+
+// RPCURL returns the endpoint the verifier reads from; with several endpoints configured, the one
+// that last answered
+func (v *Verifier) RPCURL() string {
+	return v.transport.endpoint()
+}
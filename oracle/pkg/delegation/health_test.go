@@ -0,0 +1,48 @@
+package delegation
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHealthPoller_TracksReachability(t *testing.T) {
+	log.Printf("🧪 Starting TestHealthPoller_TracksReachability")
+
+	var down atomic.Bool
+	down.Store(true)
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		if down.Load() {
+			return nil, &RPCError{Code: -32000, Message: "unavailable"}
+		}
+		return map[string]interface{}{"peers": 10, "isSyncing": false}, nil
+	})
+	verifier := NewVerifier(server.URL)
+
+	if !verifier.RPCHealthy() {
+		t.Fatalf("Expected the RPC to be assumed healthy before polling")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	verifier.StartHealthPoller(ctx, 10*time.Millisecond)
+
+	waitFor := func(healthy bool) {
+		deadline := time.Now().Add(2 * time.Second)
+		for verifier.RPCHealthy() != healthy {
+			if time.Now().After(deadline) {
+				t.Fatalf("Timed out waiting for RPCHealthy() == %v", healthy)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	waitFor(false)
+	log.Printf("✅ Poller marked the RPC down")
+
+	down.Store(false)
+	waitFor(true)
+	log.Printf("✅ Poller marked the RPC healthy again")
+}
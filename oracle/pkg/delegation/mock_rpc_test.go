@@ -0,0 +1,125 @@
+package delegation
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// aliceAccountID is the AccountId of the well-known dev account Alice (5GrwvaEF...)
+var aliceAccountID, _ = hex.DecodeString("d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d")
+
+// mockRPCHandler answers a single JSON-RPC method call
+type mockRPCHandler func(method string, params []interface{}) (interface{}, *RPCError)
+
+// mockRPCRequest is a JSON-RPC request as decoded by the mock servers
+type mockRPCRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+	ID      int           `json:"id"`
+}
+
+// newMockRPCServer starts an httptest server that dispatches JSON-RPC requests, single or
+// batched, to handler
+func newMockRPCServer(t *testing.T, handler mockRPCHandler) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+
+		answer := func(request mockRPCRequest) RPCResponse {
+			result, rpcErr := handler(request.Method, request.Params)
+			return RPCResponse{JSONRPC: "2.0", Result: result, Error: rpcErr, ID: request.ID}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		// Batches arrive as a JSON array and are answered element by element
+		var batch []mockRPCRequest
+		if err := json.Unmarshal(body, &batch); err == nil {
+			responses := make([]RPCResponse, 0, len(batch))
+			for _, request := range batch {
+				responses = append(responses, answer(request))
+			}
+			json.NewEncoder(w).Encode(responses)
+			return
+		}
+
+		var request mockRPCRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(answer(request))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// mockBlock builds a chain_getBlock result containing the given extrinsics
+func mockBlock(extrinsics ...interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"block": map[string]interface{}{
+			"header":     map[string]interface{}{"number": "0x10"},
+			"extrinsics": extrinsics,
+		},
+	}
+}
+
+// newMockStorageServer starts a mock RPC server answering state_getStorage from a key/value map.
+// Keys missing from storage return a null result, like an unset storage entry.
+func newMockStorageServer(t *testing.T, storage map[string]string) *httptest.Server {
+	t.Helper()
+
+	return newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		if method != "state_getStorage" {
+			return nil, &RPCError{Code: -32601, Message: "method not found"}
+		}
+		key, _ := params[0].(string)
+		if value, ok := storage[key]; ok {
+			return value, nil
+		}
+		return nil, nil
+	})
+}
+
+// scaleU32Hex SCALE-encodes a u32 as a 0x-prefixed hex string
+func scaleU32Hex(value uint32) string {
+	encoded := make([]byte, 4)
+	binary.LittleEndian.PutUint32(encoded, value)
+	return "0x" + hex.EncodeToString(encoded)
+}
+
+// activeEraHex SCALE-encodes an ActiveEraInfo with a start timestamp in milliseconds
+func activeEraHex(index uint32, startMs uint64) string {
+	encoded := make([]byte, 13)
+	binary.LittleEndian.PutUint32(encoded[:4], index)
+	encoded[4] = 1
+	binary.LittleEndian.PutUint64(encoded[5:], startMs)
+	return "0x" + hex.EncodeToString(encoded)
+}
+
+// nominationsHex SCALE-encodes a Nominations { targets, submitted_in, suppressed } value
+func nominationsHex(targets [][]byte, submittedIn uint32, suppressed bool) string {
+	encoded := []byte{byte(len(targets) << 2)}
+	for _, target := range targets {
+		encoded = append(encoded, target...)
+	}
+	encoded = binary.LittleEndian.AppendUint32(encoded, submittedIn)
+	if suppressed {
+		encoded = append(encoded, 1)
+	} else {
+		encoded = append(encoded, 0)
+	}
+	return "0x" + hex.EncodeToString(encoded)
+}
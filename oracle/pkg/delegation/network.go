@@ -0,0 +1,72 @@
+package delegation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrWrongNetwork is returned for a well-formed address encoded for a different network
+var ErrWrongNetwork = errors.New("address belongs to a different network")
+
+// Network describes a Substrate chain the verifier can run against
+type Network struct {
+	Name string
+	// SS58Prefix is the network prefix its addresses are encoded with
+	SS58Prefix byte
+	// DefaultRPCURL is used when no RPC endpoint is configured
+	DefaultRPCURL string
+	// SubscanURL is the network's Subscan API endpoint; empty when Subscan doesn't index it
+	SubscanURL string
+	// StakingPalletIndex is the staking pallet's index in the runtime, used to recognize
+	// staking extrinsics. Zero, the System pallet's index, means it isn't known.
+	StakingPalletIndex byte
+	// TokenDecimals is how many decimal places of planck make one token, for formatting balances
+	TokenDecimals uint8
+	// SessionDuration and SessionsPerEra are the runtime's session length and the number of
+	// sessions in an era, used to place past eras in time. Zero means they aren't known.
+	SessionDuration time.Duration
+	SessionsPerEra  uint32
+}
+
+// Known networks
+var (
+	Polkadot = Network{Name: "polkadot", SS58Prefix: 0, DefaultRPCURL: "https://rpc.polkadot.io", SubscanURL: "https://polkadot.api.subscan.io", StakingPalletIndex: 7, TokenDecimals: 10, SessionDuration: 4 * time.Hour, SessionsPerEra: 6}
+	Kusama   = Network{Name: "kusama", SS58Prefix: 2, DefaultRPCURL: "https://kusama-rpc.polkadot.io", SubscanURL: "https://kusama.api.subscan.io", StakingPalletIndex: 6, TokenDecimals: 12, SessionDuration: time.Hour, SessionsPerEra: 6}
+	// Substrate dev runtimes place the staking pallet differently and pick their own session
+	// timing, so the pallet index and session timing are left unknown
+	Substrate = Network{Name: "substrate", SS58Prefix: 42, DefaultRPCURL: "ws://127.0.0.1:9944", TokenDecimals: 12}
+)
+
+// NetworkByName returns the known network with the given case-insensitive name
+func NetworkByName(name string) (Network, error) {
+	for _, network := range []Network{Polkadot, Kusama, Substrate} {
+		if strings.EqualFold(name, network.Name) {
+			return network, nil
+		}
+	}
+	return Network{}, fmt.Errorf("unknown network: %s", name)
+}
+
+// ValidateAddress checks that address is a valid SS58 address encoded for the network
+func (n Network) ValidateAddress(address string) error {
+	_, prefix, err := DecodeSS58(address)
+	if err != nil {
+		return err
+	}
+	if prefix != n.SS58Prefix {
+		return fmt.Errorf("%w: encoded for prefix %d, %s expects %d", ErrWrongNetwork, prefix, n.Name, n.SS58Prefix)
+	}
+	return nil
+}
+
+// SetNetwork sets the network the verifier runs against; it defaults to Polkadot
+func (v *Verifier) SetNetwork(network Network) {
+	v.network = network
+}
+
+// Network returns the network the verifier runs against
+func (v *Verifier) Network() Network {
+	return v.network
+}
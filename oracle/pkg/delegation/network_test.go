@@ -0,0 +1,98 @@
+package delegation
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"log"
+	"testing"
+)
+
+func TestNetworkValidateAddress(t *testing.T) {
+	log.Printf("🧪 Starting TestNetworkValidateAddress")
+
+	polkadotAlice := encodeSS58(Polkadot.SS58Prefix, aliceAccountID)
+	kusamaAlice := encodeSS58(Kusama.SS58Prefix, aliceAccountID)
+
+	for _, tc := range []struct {
+		network Network
+		address string
+		valid   bool
+	}{
+		{Polkadot, polkadotAlice, true},
+		{Polkadot, kusamaAlice, false},
+		{Kusama, kusamaAlice, true},
+		{Kusama, polkadotAlice, false},
+		{Substrate, "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY", true},
+		{Kusama, "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY", false},
+	} {
+		err := tc.network.ValidateAddress(tc.address)
+		if tc.valid && err != nil {
+			t.Fatalf("Expected %s to accept %s, got: %v", tc.network.Name, tc.address, err)
+		}
+		if !tc.valid && !errors.Is(err, ErrWrongNetwork) {
+			t.Fatalf("Expected %s to reject %s as another network's, got: %v", tc.network.Name, tc.address, err)
+		}
+		log.Printf("✅ %s: %s valid=%v", tc.network.Name, tc.address, tc.valid)
+	}
+
+	// Malformed addresses are rejected as such, not as another network's
+	if err := Kusama.ValidateAddress("not-an-address"); err == nil || errors.Is(err, ErrWrongNetwork) {
+		t.Fatalf("Expected a decoding error for a malformed address, got: %v", err)
+	}
+	log.Printf("✅ Malformed address rejected")
+}
+
+func TestNetworkByName(t *testing.T) {
+	log.Printf("🧪 Starting TestNetworkByName")
+
+	network, err := NetworkByName("Kusama")
+	if err != nil || network != Kusama {
+		t.Fatalf("Expected Kusama, got %+v: %v", network, err)
+	}
+	if _, err := NetworkByName("westend"); err == nil {
+		t.Fatalf("Expected an unknown network to be rejected")
+	}
+	if NewVerifier("http://127.0.0.1:0").Network() != Polkadot {
+		t.Fatalf("Expected verifiers to default to Polkadot")
+	}
+	log.Printf("✅ Networks resolved by name, verifier defaults to Polkadot")
+}
+
+func TestValidateAddresses(t *testing.T) {
+	log.Printf("🧪 Starting TestValidateAddresses")
+
+	verifier := NewVerifier("http://127.0.0.1:0")
+	aliceHex := "0x" + hex.EncodeToString(aliceAccountID)
+	polkadotAlice := encodeSS58(Polkadot.SS58Prefix, aliceAccountID)
+	polkadotBob := encodeSS58(Polkadot.SS58Prefix, bytes.Repeat([]byte{0x02}, 32))
+	kusamaBob := encodeSS58(Kusama.SS58Prefix, bytes.Repeat([]byte{0x02}, 32))
+
+	for _, tc := range []struct {
+		name      string
+		nominator string
+		validator string
+		valid     bool
+	}{
+		{"hex nominator", aliceHex, polkadotBob, true},
+		{"SS58 nominator", polkadotAlice, polkadotBob, true},
+		{"validator of another network", polkadotAlice, kusamaBob, false},
+		{"malformed nominator", "not-an-address", polkadotBob, false},
+		{"same account in both encodings", aliceHex, polkadotAlice, false},
+		{"empty validator", aliceHex, "", false},
+	} {
+		err := verifier.validateAddresses(tc.nominator, tc.validator)
+		if tc.valid && err != nil {
+			t.Fatalf("%s: expected the addresses to validate, got: %v", tc.name, err)
+		}
+		if !tc.valid && err == nil {
+			t.Fatalf("%s: expected the addresses to be rejected", tc.name)
+		}
+		log.Printf("✅ %s: valid=%v", tc.name, tc.valid)
+	}
+
+	if err := verifier.validateAddresses(polkadotAlice, kusamaBob); !errors.Is(err, ErrWrongNetwork) || !errors.Is(err, ErrInvalidAddress) {
+		t.Fatalf("Expected a wrong-network address to match ErrWrongNetwork and ErrInvalidAddress, got: %v", err)
+	}
+	log.Printf("✅ Wrong-network address reported as an invalid address")
+}
@@ -0,0 +1,126 @@
+package delegation
+
+import (
+	"context"
+	"fmt"
+)
+
+// Nominations is the decoded Staking.Nominators entry of a nominator
+type Nominations struct {
+	Targets     [][]byte `json:"targets"`
+	SubmittedIn uint32   `json:"submittedIn"`
+	// Suppressed is set when the nomination was suppressed (e.g. by a slash) and no longer
+	// backs its targets, even though the entry still exists
+	Suppressed bool `json:"suppressed"`
+}
+
+// decodeNominations SCALE-decodes Nominations { targets: Vec<AccountId32>, submitted_in: u32, suppressed: bool }
+func decodeNominations(raw []byte) (*Nominations, error) {
+	decoder := newScaleDecoder(raw)
+
+	targets, err := decoder.readAccountIDs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode nomination targets: %w", err)
+	}
+
+	submittedIn, err := decoder.readU32()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode nomination era: %w", err)
+	}
+
+	suppressed, err := decoder.readBool()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode nomination suppressed flag: %w", err)
+	}
+
+	return &Nominations{Targets: targets, SubmittedIn: submittedIn, Suppressed: suppressed}, nil
+}
+
+// NominatedValidators is a nominator's current nominations, with the targets SS58-encoded for
+// the verifier's network
+type NominatedValidators struct {
+	Validators  []string `json:"validators"`
+	SubmittedIn uint32   `json:"submittedIn"`
+	Suppressed  bool     `json:"suppressed"`
+}
+
+// GetNominatedValidators returns the validators the nominator currently nominates, read from its
+// stash's Staking.Nominators entry at the finalized head, or nil when the nominator has no
+// nominations. A nominator that is neither a stash nor a controller returns ErrNotBonded.
+func (v *Verifier) GetNominatedValidators(ctx context.Context, nominatorAddress string) (*NominatedValidators, error) {
+	nominatorID, err := accountIDFromAddress(nominatorAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nominator address: %w", err)
+	}
+
+	nominations, err := v.getStashNominations(ctx, nominatorAddress, nominatorID)
+	if err != nil || nominations == nil {
+		return nil, err
+	}
+
+	validators := make([]string, 0, len(nominations.Targets))
+	for _, target := range nominations.Targets {
+		validators = append(validators, encodeSS58(v.network.SS58Prefix, target))
+	}
+	return &NominatedValidators{
+		Validators:  validators,
+		SubmittedIn: nominations.SubmittedIn,
+		Suppressed:  nominations.Suppressed,
+	}, nil
+}
+
+// nominationSuppressed reports whether the nominator's nomination exists but is suppressed.
+// A nominator without nominations is not suppressed.
+func (v *Verifier) nominationSuppressed(ctx context.Context, nominatorAddress string) (bool, error) {
+	nominatorID, err := accountIDFromAddress(nominatorAddress)
+	if err != nil {
+		return false, fmt.Errorf("invalid nominator address: %w", err)
+	}
+
+	nominations, err := v.getStashNominations(ctx, nominatorAddress, nominatorID)
+	if err != nil {
+		return false, err
+	}
+
+	return nominations != nil && nominations.Suppressed, nil
+}
+
+// VerifyDelegations checks several validators against one nominator, reading the Staking.Nominators
+// entry of the nominator's stash once and matching every validator against its targets in memory.
+// Only a failure of that shared read is an error, including ErrNotBonded for a nominator that is
+// neither a stash nor a controller; a validator that isn't nominated, or whose address can't be
+// decoded, is reported as false.
+func (v *Verifier) VerifyDelegations(nominatorAddress string, validatorAddresses []string) (map[string]bool, error) {
+	return v.VerifyDelegationsCtx(context.Background(), nominatorAddress, validatorAddresses)
+}
+
+// VerifyDelegationsCtx is VerifyDelegations bounded by ctx
+func (v *Verifier) VerifyDelegationsCtx(ctx context.Context, nominatorAddress string, validatorAddresses []string) (map[string]bool, error) {
+	v.log().DebugContext(ctx, "verifying delegations", "event", "delegations_verify", "nominator", nominatorAddress, "validators", len(validatorAddresses))
+
+	nominatorID, err := accountIDFromAddress(nominatorAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nominator address: %w", err)
+	}
+
+	nominations, err := v.getStashNominations(ctx, nominatorAddress, nominatorID)
+	if err != nil {
+		return nil, err
+	}
+	var targets [][]byte
+	if nominations != nil {
+		targets = nominations.Targets
+	}
+
+	results := make(map[string]bool, len(validatorAddresses))
+	for _, validatorAddress := range validatorAddresses {
+		validatorID, err := accountIDFromAddress(validatorAddress)
+		if err != nil {
+			v.log().DebugContext(ctx, "invalid validator address", "event", "delegations_verify", "validator", validatorAddress, "error", err)
+			results[validatorAddress] = false
+			continue
+		}
+		results[validatorAddress] = containsAccount(targets, validatorID)
+	}
+	return results, nil
+}
@@ -0,0 +1,401 @@
+package delegation
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestDecodeNominations(t *testing.T) {
+	log.Printf("🧪 Starting TestDecodeNominations")
+
+	targets := [][]byte{bytes.Repeat([]byte{0x02}, 32), bytes.Repeat([]byte{0x03}, 32)}
+	raw, _ := hex.DecodeString(strings.TrimPrefix(nominationsHex(targets, 1523, true), "0x"))
+
+	nominations, err := decodeNominations(raw)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(nominations.Targets) != 2 || !bytes.Equal(nominations.Targets[1], targets[1]) {
+		t.Errorf("Unexpected targets: %x", nominations.Targets)
+	}
+	if nominations.SubmittedIn != 1523 {
+		t.Errorf("Expected submitted_in 1523, got %d", nominations.SubmittedIn)
+	}
+	if !nominations.Suppressed {
+		t.Errorf("Expected suppressed to be set")
+	}
+	log.Printf("✅ Decoded nominations: %d targets, era %d, suppressed %v", len(nominations.Targets), nominations.SubmittedIn, nominations.Suppressed)
+
+	if _, err := decodeNominations(raw[:len(raw)-1]); err == nil {
+		t.Errorf("Expected error decoding nominations without the suppressed flag")
+	}
+}
+
+func TestDecodeNominations_RealBlob(t *testing.T) {
+	log.Printf("🧪 Starting TestDecodeNominations_RealBlob")
+
+	// Staking.Nominators value of Bob on a dev chain: targets [Alice, Charlie], submitted_in 1234, not suppressed
+	blob := "0x08" +
+		"d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d" +
+		"90b5ab205c6974c9ea841be688864633dc9ca8a357843eeacf2314649965fe22" +
+		"d2040000" +
+		"00"
+	raw, _ := hex.DecodeString(strings.TrimPrefix(blob, "0x"))
+
+	nominations, err := decodeNominations(raw)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(nominations.Targets) != 2 || !bytes.Equal(nominations.Targets[0], aliceAccountID) {
+		t.Fatalf("Unexpected targets: %x", nominations.Targets)
+	}
+	if hex.EncodeToString(nominations.Targets[1]) != "90b5ab205c6974c9ea841be688864633dc9ca8a357843eeacf2314649965fe22" {
+		t.Errorf("Unexpected second target: %x", nominations.Targets[1])
+	}
+	if nominations.SubmittedIn != 1234 || nominations.Suppressed {
+		t.Errorf("Expected submitted_in 1234 and not suppressed, got %d, %v", nominations.SubmittedIn, nominations.Suppressed)
+	}
+	log.Printf("✅ Decoded real blob: %d targets, era %d", len(nominations.Targets), nominations.SubmittedIn)
+}
+
+func TestDecodeNominations_CompactLength(t *testing.T) {
+	log.Printf("🧪 Starting TestDecodeNominations_CompactLength")
+
+	// 70 targets need the two-byte compact mode: (70 << 2) | 0b01 = 0x0119
+	raw := []byte{0x19, 0x01}
+	for i := 0; i < 70; i++ {
+		raw = append(raw, bytes.Repeat([]byte{byte(i)}, 32)...)
+	}
+	raw = append(raw, 0x01, 0x00, 0x00, 0x00, 0x00)
+
+	nominations, err := decodeNominations(raw)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(nominations.Targets) != 70 || nominations.Targets[69][0] != 69 {
+		t.Fatalf("Expected 70 targets, got %d", len(nominations.Targets))
+	}
+	log.Printf("✅ Two-byte compact length decoded: %d targets", len(nominations.Targets))
+
+	// A length prefix larger than the data must not be trusted
+	if _, err := decodeNominations([]byte{0x19, 0x01, 0x00}); err == nil {
+		t.Errorf("Expected error for a length prefix exceeding the data")
+	}
+	log.Printf("✅ Oversized length prefix rejected")
+}
+
+func TestVerifyV2_SuppressedNominationInactive(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyV2_SuppressedNominationInactive")
+
+	nominatorID := bytes.Repeat([]byte{0x01}, 32)
+	validatorID := bytes.Repeat([]byte{0x02}, 32)
+	nominator := "0x" + hex.EncodeToString(nominatorID)
+	validator := "0x" + hex.EncodeToString(validatorID)
+
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		switch method {
+		case "chain_getFinalizedHead":
+			return "0x" + hex.EncodeToString(bytes.Repeat([]byte{0xaa}, 32)), nil
+		case "state_getStorage":
+			switch params[0] {
+			case nominatorsStorageKey(nominatorID):
+				return nominationsHex([][]byte{validatorID}, 1000, true), nil
+			case activeEraStorageKey():
+				return activeEraHex(1000, 0), nil
+			}
+			return "0x00", nil
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+	verifier := NewVerifier(server.URL)
+
+	result, err := verifier.VerifyV2(nominator, validator)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	log.Printf("📋 Result: %+v", result)
+
+	if result.ActiveEraValidation {
+		t.Errorf("Expected a suppressed nomination to be inactive")
+	}
+	if result.IsValid {
+		t.Errorf("Expected verification to fail for a suppressed nomination")
+	}
+	if !strings.Contains(result.AdditionalInfo, "suppressed") {
+		t.Errorf("Expected AdditionalInfo to mention suppression, got %q", result.AdditionalInfo)
+	}
+	log.Printf("✅ Suppressed nomination reported inactive: %s", result.AdditionalInfo)
+}
+
+func TestCheckIfNominated(t *testing.T) {
+	log.Printf("🧪 Starting TestCheckIfNominated")
+
+	nominator := "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty"
+	nominatorID, _, _ := DecodeSS58(nominator)
+	otherValidatorID := bytes.Repeat([]byte{0x03}, 32)
+
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		switch method {
+		case "chain_getFinalizedHead":
+			return testBlockHash, nil
+		case "state_getStorage":
+			if params[0] == nominatorsStorageKey(nominatorID) {
+				return nominationsHex([][]byte{otherValidatorID, aliceAccountID}, 1000, false), nil
+			}
+			return nil, nil
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+	verifier := NewVerifier(server.URL)
+
+	cases := []struct {
+		name      string
+		validator string
+		expected  bool
+	}{
+		{"nominated validator", "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY", true},
+		{"other validator", "0x" + strings.Repeat("04", 32), false},
+	}
+
+	for _, tc := range cases {
+		nominated, err := verifier.checkIfNominated(context.Background(), nominator, tc.validator)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got: %v", tc.name, err)
+		}
+		if nominated != tc.expected {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, nominated)
+		} else {
+			log.Printf("✅ %s: nominated=%v", tc.name, nominated)
+		}
+	}
+
+	if _, err := verifier.checkIfNominated(context.Background(), "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694tz", "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"); err == nil {
+		t.Errorf("Expected error for a nominator with a bad checksum")
+	}
+	log.Printf("✅ Bad checksum rejected")
+}
+
+func TestVerifyDelegations(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyDelegations")
+
+	nominator := "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty"
+	nominatorID, _, _ := DecodeSS58(nominator)
+	targetB := bytes.Repeat([]byte{0x0b}, 32)
+	targetC := bytes.Repeat([]byte{0x0c}, 32)
+
+	var nominatorReads int
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		switch method {
+		case "chain_getFinalizedHead":
+			return testBlockHash, nil
+		case "state_getStorage":
+			if params[0] == nominatorsStorageKey(nominatorID) {
+				nominatorReads++
+				return nominationsHex([][]byte{aliceAccountID, targetB, targetC}, 1000, false), nil
+			}
+			return nil, nil
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+	verifier := NewVerifier(server.URL)
+
+	expected := map[string]bool{
+		"5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY": true,
+		"0x" + hex.EncodeToString(targetB):                 true,
+		"0x" + hex.EncodeToString(targetC):                 true,
+		"0x" + strings.Repeat("0d", 32):                    false,
+		"not-an-address":                                   false,
+	}
+	validators := make([]string, 0, len(expected))
+	for validator := range expected {
+		validators = append(validators, validator)
+	}
+
+	results, err := verifier.VerifyDelegations(nominator, validators)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(results) != len(expected) {
+		t.Fatalf("Expected %d results, got %d", len(expected), len(results))
+	}
+	for validator, want := range expected {
+		if results[validator] != want {
+			t.Errorf("%s: expected %v, got %v", validator, want, results[validator])
+		}
+	}
+	if nominatorReads != 1 {
+		t.Fatalf("Expected the nominations to be read once, got %d reads", nominatorReads)
+	}
+	log.Printf("✅ 3 of 5 validators nominated, from a single storage read")
+
+	failing := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		return nil, &RPCError{Code: -32000, Message: "unknown block"}
+	})
+	if _, err := NewVerifier(failing.URL).VerifyDelegations(nominator, validators); err == nil {
+		t.Fatalf("Expected the shared fetch failure to be returned")
+	}
+	log.Printf("✅ Shared fetch failure reported as an error")
+}
+
+func TestCheckIfActive(t *testing.T) {
+	log.Printf("🧪 Starting TestCheckIfActive")
+
+	nominatorID := bytes.Repeat([]byte{0x01}, 32)
+	validatorID := bytes.Repeat([]byte{0x02}, 32)
+	nominator := "0x" + hex.EncodeToString(nominatorID)
+	validator := "0x" + hex.EncodeToString(validatorID)
+
+	cases := []struct {
+		name        string
+		nominations string
+		expected    bool
+	}{
+		{"submitted in active era", nominationsHex([][]byte{validatorID}, 1000, false), true},
+		{"submitted in prior era", nominationsHex([][]byte{validatorID}, 990, false), true},
+		{"submitted in future era", nominationsHex([][]byte{validatorID}, 1001, false), false},
+		{"validator no longer targeted", nominationsHex([][]byte{bytes.Repeat([]byte{0x03}, 32)}, 990, false), false},
+		{"suppressed", nominationsHex([][]byte{validatorID}, 990, true), false},
+		{"chilled", "", false},
+	}
+
+	for _, tc := range cases {
+		// The nominator is a bonded stash, chilled or not
+		storage := map[string]string{
+			activeEraStorageKey():         activeEraHex(1000, 0),
+			bondedStorageKey(nominatorID): nominator,
+		}
+		if tc.nominations != "" {
+			storage[nominatorsStorageKey(nominatorID)] = tc.nominations
+		}
+
+		server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+			switch method {
+			case "chain_getFinalizedHead":
+				return testBlockHash, nil
+			case "state_getStorage":
+				if value, ok := storage[params[0].(string)]; ok {
+					return value, nil
+				}
+				return nil, nil
+			}
+			return nil, &RPCError{Code: -32601, Message: "method not found"}
+		})
+
+		active, err := NewVerifier(server.URL).checkIfActive(context.Background(), nominator, validator)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got: %v", tc.name, err)
+		}
+		if active != tc.expected {
+			t.Errorf("%s: expected active=%v, got %v", tc.name, tc.expected, active)
+		} else {
+			log.Printf("✅ %s: active=%v", tc.name, active)
+		}
+	}
+}
+
+func TestCheckIfActive_EmptyActiveEra(t *testing.T) {
+	log.Printf("🧪 Starting TestCheckIfActive_EmptyActiveEra")
+
+	server := newMockStorageServer(t, map[string]string{})
+	verifier := NewVerifier(server.URL)
+
+	_, err := verifier.checkIfActive(context.Background(), "0x"+strings.Repeat("01", 32), "0x"+strings.Repeat("02", 32))
+	if !errors.Is(err, ErrActiveEraEmpty) {
+		t.Fatalf("Expected ErrActiveEraEmpty, got: %v", err)
+	}
+	log.Printf("✅ Empty active era reported as ErrActiveEraEmpty")
+}
+
+func TestVerifyDelegationDetail(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyDelegationDetail")
+
+	bobAccountID, _ := accountIDFromAddress("5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty")
+	otherValidatorID := bytes.Repeat([]byte{0x03}, 32)
+	charlieAccountID := bytes.Repeat([]byte{0x05}, 32)
+	daveAccountID := bytes.Repeat([]byte{0x06}, 32)
+	controllerID := bytes.Repeat([]byte{0x07}, 32)
+	ledger := "0x" + hex.EncodeToString(bobAccountID) + "0b00407a10f35a0b00407a10f35a000000"
+
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		switch method {
+		case "chain_getFinalizedHead":
+			return testBlockHash, nil
+		case "state_getStorage":
+			switch params[0] {
+			case activeEraStorageKey():
+				return activeEraHex(1000, 0), nil
+			case nominatorsStorageKey(bobAccountID):
+				return nominationsHex([][]byte{otherValidatorID, aliceAccountID}, 1000, false), nil
+			case bondedStorageKey(bobAccountID):
+				return "0x" + hex.EncodeToString(controllerID), nil
+			case ledgerStorageKey(controllerID):
+				return ledger, nil
+			case bondedStorageKey(charlieAccountID):
+				return "0x" + hex.EncodeToString(charlieAccountID), nil
+			}
+			return nil, nil
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+	verifier := NewVerifier(server.URL)
+	verifier.SetNetwork(Kusama)
+
+	bob := encodeSS58(Kusama.SS58Prefix, bobAccountID)
+	alice := encodeSS58(Kusama.SS58Prefix, aliceAccountID)
+	other := encodeSS58(Kusama.SS58Prefix, otherValidatorID)
+	charlie := encodeSS58(Kusama.SS58Prefix, charlieAccountID)
+
+	result, err := verifier.VerifyDelegationDetail(context.Background(), bob, alice)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !result.IsValid || !result.StorageValidation || !result.ActiveEraValidation || len(result.NominatedValidators) != 0 {
+		t.Fatalf("Expected an active delegation without listed targets, got %+v", result)
+	}
+	log.Printf("✅ Nominated validator passes every sub-check")
+
+	// Bob's controller stands in for Bob
+	result, err = verifier.VerifyDelegationDetail(context.Background(), encodeSS58(Kusama.SS58Prefix, controllerID), alice)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !result.IsValid || !result.StorageValidation || !result.ActiveEraValidation {
+		t.Fatalf("Expected the controller to delegate through Bob, got %+v", result)
+	}
+	log.Printf("✅ Controller resolved to its stash's nominations")
+
+	// Bob nominates two validators, but not Charlie
+	result, err = verifier.VerifyDelegationDetail(context.Background(), bob, charlie)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.IsValid || result.StorageValidation || !result.AddressValidation {
+		t.Fatalf("Expected only the address check to pass, got %+v", result)
+	}
+	if len(result.NominatedValidators) != 2 || result.NominatedValidators[0] != other || result.NominatedValidators[1] != alice {
+		t.Fatalf("Expected Bob's targets in the network's address format, got %v", result.NominatedValidators)
+	}
+	log.Printf("✅ Missing delegation lists actual targets: %v (%s)", result.NominatedValidators, result.AdditionalInfo)
+
+	// Charlie is bonded but isn't a nominator
+	result, err = verifier.VerifyDelegationDetail(context.Background(), charlie, alice)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.IsValid || len(result.NominatedValidators) != 0 || !strings.Contains(result.AdditionalInfo, "no nominations") {
+		t.Fatalf("Expected a non-nominator to be reported as such, got %+v", result)
+	}
+	log.Printf("✅ Non-nominator reported: %s", result.AdditionalInfo)
+
+	// Dave has never bonded
+	_, err = verifier.VerifyDelegationDetail(context.Background(), encodeSS58(Kusama.SS58Prefix, daveAccountID), alice)
+	if !errors.Is(err, ErrNotBonded) {
+		t.Fatalf("Expected ErrNotBonded for an unbonded nominator, got: %v", err)
+	}
+	log.Printf("✅ Unbonded nominator reported: %v", err)
+}
@@ -0,0 +1,83 @@
+package delegation
+
+import (
+	"context"
+	"fmt"
+)
+
+// RewardDestination is the variant of a Staking.Payee RewardDestination enum
+type RewardDestination string
+
+// RewardDestination variants, in SCALE enum index order
+const (
+	RewardStaked     RewardDestination = "Staked"
+	RewardStash      RewardDestination = "Stash"
+	RewardController RewardDestination = "Controller"
+	RewardAccount    RewardDestination = "Account"
+	RewardNone       RewardDestination = "None"
+)
+
+// rewardDestinationVariants maps SCALE enum indices to RewardDestination variants
+var rewardDestinationVariants = []RewardDestination{
+	RewardStaked,
+	RewardStash,
+	RewardController,
+	RewardAccount,
+	RewardNone,
+}
+
+// PayeeDestination describes where a nominator's staking rewards are paid
+type PayeeDestination struct {
+	Destination RewardDestination `json:"destination"`
+	Account     []byte            `json:"account,omitempty"` // only set for RewardAccount
+}
+
+// decodePayee SCALE-decodes a RewardDestination enum value
+func decodePayee(raw []byte) (PayeeDestination, error) {
+	decoder := newScaleDecoder(raw)
+
+	index, err := decoder.readU8()
+	if err != nil {
+		return PayeeDestination{}, fmt.Errorf("failed to decode reward destination: %w", err)
+	}
+	if int(index) >= len(rewardDestinationVariants) {
+		return PayeeDestination{}, fmt.Errorf("unknown reward destination variant: %d", index)
+	}
+
+	payee := PayeeDestination{Destination: rewardDestinationVariants[index]}
+	if payee.Destination == RewardAccount {
+		account, err := decoder.readBytes(32)
+		if err != nil {
+			return PayeeDestination{}, fmt.Errorf("failed to decode reward account: %w", err)
+		}
+		payee.Account = append([]byte{}, account...)
+	}
+
+	return payee, nil
+}
+
+// GetPayee reads and decodes the Staking.Payee reward destination of a stash account
+func (v *Verifier) GetPayee(ctx context.Context, stash string) (PayeeDestination, error) {
+	v.log().DebugContext(ctx, "querying reward destination", "event", "payee_lookup", "nominator", stash)
+
+	accountID, err := accountIDFromAddress(stash)
+	if err != nil {
+		return PayeeDestination{}, fmt.Errorf("invalid stash address: %w", err)
+	}
+
+	raw, err := v.getStorage(ctx, payeeStorageKey(accountID))
+	if err != nil {
+		return PayeeDestination{}, fmt.Errorf("failed to query payee: %w", err)
+	}
+	if raw == nil {
+		return PayeeDestination{}, fmt.Errorf("no reward destination set for %s", stash)
+	}
+
+	payee, err := decodePayee(raw)
+	if err != nil {
+		return PayeeDestination{}, err
+	}
+
+	v.log().DebugContext(ctx, "reward destination read", "event", "payee_lookup", "nominator", stash, "destination", payee.Destination)
+	return payee, nil
+}
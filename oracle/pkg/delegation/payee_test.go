@@ -0,0 +1,78 @@
+package delegation
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"log"
+	"testing"
+)
+
+func TestDecodePayee_AllVariants(t *testing.T) {
+	log.Printf("🧪 Starting TestDecodePayee_AllVariants")
+
+	account := bytes.Repeat([]byte{0xaa}, 32)
+
+	cases := []struct {
+		raw      string
+		expected RewardDestination
+		account  []byte
+	}{
+		{"00", RewardStaked, nil},
+		{"01", RewardStash, nil},
+		{"02", RewardController, nil},
+		{"03" + hex.EncodeToString(account), RewardAccount, account},
+		{"04", RewardNone, nil},
+	}
+
+	for _, tc := range cases {
+		raw, _ := hex.DecodeString(tc.raw)
+		payee, err := decodePayee(raw)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got: %v", tc.expected, err)
+		}
+		if payee.Destination != tc.expected {
+			t.Errorf("Expected %s, got %s", tc.expected, payee.Destination)
+		}
+		if !bytes.Equal(payee.Account, tc.account) {
+			t.Errorf("%s: unexpected account %x", tc.expected, payee.Account)
+		}
+		log.Printf("✅ Decoded %s", payee.Destination)
+	}
+
+	for _, raw := range []string{"", "05", "03aabb"} {
+		data, _ := hex.DecodeString(raw)
+		if _, err := decodePayee(data); err == nil {
+			t.Errorf("Expected error decoding %q", raw)
+		}
+	}
+}
+
+func TestGetPayee(t *testing.T) {
+	log.Printf("🧪 Starting TestGetPayee")
+
+	stash := "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+	accountID, _, err := DecodeSS58(stash)
+	if err != nil {
+		t.Fatalf("Failed to decode stash: %v", err)
+	}
+
+	server := newMockStorageServer(t, map[string]string{
+		payeeStorageKey(accountID): "0x01",
+	})
+	verifier := NewVerifier(server.URL)
+
+	payee, err := verifier.GetPayee(context.Background(), stash)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if payee.Destination != RewardStash {
+		t.Fatalf("Expected Stash, got %s", payee.Destination)
+	}
+
+	if _, err := verifier.GetPayee(context.Background(), "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty"); err == nil {
+		t.Fatalf("Expected error for an account without a reward destination")
+	}
+
+	log.Printf("✅ GetPayee decoded reward destination")
+}
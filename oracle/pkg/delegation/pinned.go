@@ -0,0 +1,129 @@
+package delegation
+
+import (
+	"context"
+	"fmt"
+)
+
+// PinnedDelegationResult is the outcome of VerifyDelegationAt together with the block it was read at
+type PinnedDelegationResult struct {
+	Delegated   bool   `json:"delegated"`
+	BlockHash   string `json:"blockHash"`
+	BlockNumber uint64 `json:"blockNumber"`
+}
+
+type pinnedBlockKey struct{}
+
+// withPinnedBlock makes every storage read under ctx query the given block
+func withPinnedBlock(ctx context.Context, blockHash string) context.Context {
+	return context.WithValue(ctx, pinnedBlockKey{}, blockHash)
+}
+
+// pinnedBlock returns the block hash storage reads under ctx are pinned to, or ""
+func pinnedBlock(ctx context.Context) string {
+	blockHash, _ := ctx.Value(pinnedBlockKey{}).(string)
+	return blockHash
+}
+
+// storageBlock returns the block to read nominations at: the pinned block when there is one,
+// otherwise the finalized head
+func (v *Verifier) storageBlock(ctx context.Context) (string, error) {
+	if blockHash := pinnedBlock(ctx); blockHash != "" {
+		return blockHash, nil
+	}
+	return v.getFinalizedHead(ctx)
+}
+
+// getBestBlockHash returns the hash of the best block
+func (v *Verifier) getBestBlockHash(ctx context.Context) (string, error) {
+	request := RPCRequest{
+		JSONRPC: "2.0",
+		Method:  "chain_getBlockHash",
+		Params:  []interface{}{},
+		ID:      1,
+	}
+
+	result, err := v.makeRPCCallCtx(ctx, request)
+	if err != nil {
+		return "", fmt.Errorf("failed to get best block hash: %w", err)
+	}
+
+	blockHash, ok := result.(string)
+	if !ok {
+		return "", fmt.Errorf("invalid block hash response")
+	}
+	return blockHash, nil
+}
+
+// getBlockNumber returns the number of the block with the given hash
+func (v *Verifier) getBlockNumber(ctx context.Context, blockHash string) (uint64, error) {
+	request := RPCRequest{
+		JSONRPC: "2.0",
+		Method:  "chain_getHeader",
+		Params:  []interface{}{blockHash},
+		ID:      1,
+	}
+
+	result, err := v.makeRPCCallCtx(ctx, request)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get header of block %s: %w", blockHash, err)
+	}
+	if result == nil {
+		return 0, fmt.Errorf("block %s not found", blockHash)
+	}
+
+	header, ok := result.(map[string]interface{})
+	if !ok {
+		return 0, fmt.Errorf("invalid header response")
+	}
+	numberField, ok := header["number"]
+	if !ok {
+		return 0, fmt.Errorf("header of block %s has no number", blockHash)
+	}
+
+	number, err := parseBlockNumber(numberField)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(number), nil
+}
+
+// VerifyDelegationAt is VerifyDelegation with every storage read pinned to blockHash, so the
+// same inputs always give the same answer. An empty blockHash verifies at the current best
+// block. The result names the block that was checked.
+func (v *Verifier) VerifyDelegationAt(nominatorAddress, validatorAddress, blockHash string) (*PinnedDelegationResult, error) {
+	return v.VerifyDelegationAtCtx(context.Background(), nominatorAddress, validatorAddress, blockHash)
+}
+
+// VerifyDelegationAtCtx is VerifyDelegationAt bounded by ctx
+func (v *Verifier) VerifyDelegationAtCtx(ctx context.Context, nominatorAddress, validatorAddress, blockHash string) (*PinnedDelegationResult, error) {
+	if blockHash == "" {
+		var err error
+		blockHash, err = v.getBestBlockHash(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	blockNumber, err := v.getBlockNumber(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	v.log().DebugContext(ctx, "verifying delegation at pinned block", "event", "pinned_verify", "nominator", nominatorAddress, "validator", validatorAddress,
+		"block_number", blockNumber, "block_hash", blockHash)
+
+	if transcript := transcriptFromContext(ctx); transcript != nil {
+		transcript.SetInput("blockHash", blockHash)
+	}
+
+	delegated, err := v.VerifyDelegationCtx(withPinnedBlock(ctx, blockHash), nominatorAddress, validatorAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PinnedDelegationResult{
+		Delegated:   delegated,
+		BlockHash:   blockHash,
+		BlockNumber: blockNumber,
+	}, nil
+}
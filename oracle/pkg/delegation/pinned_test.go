@@ -0,0 +1,83 @@
+package delegation
+
+import (
+	"log"
+	"testing"
+)
+
+const pinnedTestBlockHash = "0xcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcd"
+
+// newPinnedTestServer serves Bob nominating Alice at pinnedTestBlockHash (block #0x2a) only,
+// failing the test if any storage read isn't pinned to that block
+func newPinnedTestServer(t *testing.T) string {
+	t.Helper()
+
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		switch method {
+		case "chain_getBlockHash":
+			return pinnedTestBlockHash, nil
+		case "chain_getFinalizedHead":
+			return testBlockHash, nil
+		case "chain_getHeader":
+			if len(params) == 1 && params[0] == pinnedTestBlockHash {
+				return map[string]interface{}{"number": "0x2a"}, nil
+			}
+			return nil, nil
+		case "state_getStorage":
+			if len(params) != 2 || params[1] != pinnedTestBlockHash {
+				t.Errorf("Storage read not pinned to the block: %v", params)
+				return nil, nil
+			}
+			if params[0] == activeEraStorageKey() {
+				return activeEraHex(5, 0), nil
+			}
+			return nominationsHex([][]byte{aliceAccountID}, 1, false), nil
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+	return server.URL
+}
+
+func TestVerifyDelegationAt_PinsStorageReads(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyDelegationAt_PinsStorageReads")
+
+	verifier := NewVerifier(newPinnedTestServer(t))
+
+	result, err := verifier.VerifyDelegationAt("5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty", "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY", pinnedTestBlockHash)
+	if err != nil {
+		t.Fatalf("VerifyDelegationAt returned error: %v", err)
+	}
+	if !result.Delegated {
+		t.Fatalf("Expected the delegation to verify at the pinned block")
+	}
+	if result.BlockHash != pinnedTestBlockHash || result.BlockNumber != 42 {
+		t.Fatalf("Expected block #42 %s, got #%d %s", pinnedTestBlockHash, result.BlockNumber, result.BlockHash)
+	}
+	log.Printf("✅ Verified at block #%d with every read pinned", result.BlockNumber)
+}
+
+func TestVerifyDelegationAt_EmptyHashResolvesBestBlock(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyDelegationAt_EmptyHashResolvesBestBlock")
+
+	verifier := NewVerifier(newPinnedTestServer(t))
+
+	result, err := verifier.VerifyDelegationAt("5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty", "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY", "")
+	if err != nil {
+		t.Fatalf("VerifyDelegationAt returned error: %v", err)
+	}
+	if result.BlockHash != pinnedTestBlockHash || result.BlockNumber != 42 {
+		t.Fatalf("Expected the best block to be resolved and reported, got #%d %s", result.BlockNumber, result.BlockHash)
+	}
+	log.Printf("✅ Empty hash resolved to best block #%d", result.BlockNumber)
+}
+
+func TestVerifyDelegationAt_UnknownBlock(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyDelegationAt_UnknownBlock")
+
+	verifier := NewVerifier(newPinnedTestServer(t))
+
+	if _, err := verifier.VerifyDelegationAt("5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty", "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY", testBlockHash); err == nil {
+		t.Fatalf("Expected an unknown block to be rejected")
+	}
+	log.Printf("✅ Unknown block rejected")
+}
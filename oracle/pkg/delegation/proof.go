@@ -0,0 +1,104 @@
+package delegation
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrNoNomination is returned by VerifyDelegationProof when the nominator doesn't nominate the validator
+var ErrNoNomination = errors.New("nominator does not nominate validator")
+
+// DelegationProof backs a nomination found in current storage with the extrinsic that made it
+type DelegationProof struct {
+	// Extrinsic is the nominator's most recent Staking.nominate call targeting the validator,
+	// or nil when it was submitted before the blocks the scan covers
+	Extrinsic   *StakingExtrinsic `json:"extrinsic,omitempty"`
+	BlockHash   string            `json:"blockHash,omitempty"`
+	BlockNumber string            `json:"blockNumber,omitempty"`
+	// SubmittedIn is the era the nomination was submitted in
+	SubmittedIn uint32 `json:"submittedIn"`
+	ActiveEra   uint32 `json:"activeEra"`
+}
+
+// VerifyDelegationProof checks that the nominator currently nominates the validator and looks up
+// the extrinsic that established the nomination, for callers that need an audit trail.
+// It returns ErrNoNomination when storage holds no such nomination.
+func (v *Verifier) VerifyDelegationProof(nominatorAddress, validatorAddress string) (*DelegationProof, error) {
+	return v.VerifyDelegationProofCtx(context.Background(), nominatorAddress, validatorAddress)
+}
+
+// VerifyDelegationProofCtx is VerifyDelegationProof bounded by ctx
+func (v *Verifier) VerifyDelegationProofCtx(ctx context.Context, nominatorAddress, validatorAddress string) (*DelegationProof, error) {
+	v.log().DebugContext(ctx, "building delegation proof", "event", "delegation_proof", "nominator", nominatorAddress, "validator", validatorAddress)
+
+	nominatorID, err := accountIDFromAddress(nominatorAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nominator address: %w", err)
+	}
+	validatorID, err := accountIDFromAddress(validatorAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid validator address: %w", err)
+	}
+
+	nominations, err := v.getStashNominations(ctx, nominatorAddress, nominatorID)
+	if err != nil {
+		return nil, err
+	}
+	if nominations == nil || !containsAccount(nominations.Targets, validatorID) {
+		return nil, fmt.Errorf("%w: %s does not nominate %s", ErrNoNomination, nominatorAddress, validatorAddress)
+	}
+
+	activeEra, err := v.getActiveEraInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	proof := &DelegationProof{
+		SubmittedIn: nominations.SubmittedIn,
+		ActiveEra:   activeEra.Index,
+	}
+
+	scan, err := v.findExtrinsicByAddress(ctx, nominatorAddress, validatorAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up nomination extrinsic: %w", err)
+	}
+	// The scan runs newest first, so the first match is the nomination currently in storage
+	for i := range scan.Extrinsics {
+		extrinsic := &scan.Extrinsics[i]
+		if nominationExtrinsicMatches(extrinsic, nominatorID, validatorID) {
+			proof.Extrinsic = extrinsic
+			proof.BlockHash = extrinsic.BlockHash
+			proof.BlockNumber = extrinsic.BlockNumber
+			break
+		}
+	}
+
+	if proof.Extrinsic == nil {
+		v.log().InfoContext(ctx, "nomination extrinsic outside the scanned blocks", "event", "delegation_proof", "nominator", nominatorAddress, "validator", validatorAddress, "submitted_in", proof.SubmittedIn)
+	}
+	return proof, nil
+}
+
+// nominationExtrinsicMatches reports whether a scanned extrinsic is a Staking.nominate call
+// signed by the nominator and targeting the validator
+func nominationExtrinsicMatches(extrinsic *StakingExtrinsic, nominatorID, validatorID []byte) bool {
+	if extrinsic.Method != "staking.nominate" {
+		return false
+	}
+
+	signer, _ := extrinsic.Params["signer"].(string)
+	signerID, err := accountIDFromAddress(signer)
+	if err != nil || !bytes.Equal(signerID, nominatorID) {
+		return false
+	}
+
+	targets, _ := extrinsic.Params["targets"].([]string)
+	for _, target := range targets {
+		if targetID, err := accountIDFromAddress(target); err == nil && bytes.Equal(targetID, validatorID) {
+			return true
+		}
+	}
+	return false
+}
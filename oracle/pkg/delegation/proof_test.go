@@ -0,0 +1,101 @@
+package delegation
+
+import (
+	"errors"
+	"log"
+	"testing"
+)
+
+// newProofRPCServer serves a chain whose finalized storage holds Bob's nominations and whose
+// two latest blocks carry the given extrinsics
+func newProofRPCServer(t *testing.T, targets [][]byte, extrinsics ...interface{}) *Verifier {
+	t.Helper()
+
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		switch method {
+		case "chain_getFinalizedHead", "chain_getBlockHash":
+			return testBlockHash, nil
+		case "chain_getHeader":
+			return map[string]interface{}{"number": "0x2"}, nil
+		case "chain_getBlock":
+			return mockBlock(extrinsics...), nil
+		case "state_getStorage":
+			if len(params) > 1 {
+				return nominationsHex(targets, 4, false), nil
+			}
+			return activeEraHex(5, 0), nil
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+	verifier := NewVerifier(server.URL)
+	verifier.SetScanRange(1)
+	return verifier
+}
+
+func TestVerifyDelegationProof(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyDelegationProof")
+
+	// Bob nominates Alice, and the nominate call is in the scanned blocks
+	verifier := newProofRPCServer(t, [][]byte{aliceAccountID}, "0x280403000b20f9a58c0100", nominateExtrinsicHex)
+
+	proof, err := verifier.VerifyDelegationProof("5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty", "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+	if err != nil {
+		t.Fatalf("Expected proof, got error: %v", err)
+	}
+	if proof.Extrinsic == nil {
+		t.Fatalf("Expected the nominate extrinsic in the proof")
+	}
+	if proof.Extrinsic.ExtrinsicIdx != 1 || proof.Extrinsic.Method != "staking.nominate" {
+		t.Fatalf("Expected staking.nominate at index 1, got %s at %d", proof.Extrinsic.Method, proof.Extrinsic.ExtrinsicIdx)
+	}
+	if proof.BlockHash != testBlockHash || proof.BlockNumber != "2" {
+		t.Fatalf("Expected block 2 (%s), got %s (%s)", testBlockHash, proof.BlockNumber, proof.BlockHash)
+	}
+	if proof.SubmittedIn != 4 || proof.ActiveEra != 5 {
+		t.Fatalf("Expected submitted in era 4 with active era 5, got %d and %d", proof.SubmittedIn, proof.ActiveEra)
+	}
+	log.Printf("✅ Proof found extrinsic %d in block %s", proof.Extrinsic.ExtrinsicIdx, proof.BlockNumber)
+}
+
+func TestVerifyDelegationProof_ExtrinsicOutsideScan(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyDelegationProof_ExtrinsicOutsideScan")
+
+	verifier := newProofRPCServer(t, [][]byte{aliceAccountID}, "0x280403000b20f9a58c0100")
+
+	proof, err := verifier.VerifyDelegationProof("5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty", "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+	if err != nil {
+		t.Fatalf("Expected proof, got error: %v", err)
+	}
+	if proof.Extrinsic != nil || proof.BlockHash != "" {
+		t.Fatalf("Expected no extrinsic outside the scanned blocks, got %+v", proof.Extrinsic)
+	}
+	if proof.SubmittedIn != 4 {
+		t.Fatalf("Expected submitted in era 4, got %d", proof.SubmittedIn)
+	}
+	log.Printf("✅ Proof reports the storage state without an extrinsic")
+}
+
+func TestVerifyDelegationProof_NoNomination(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyDelegationProof_NoNomination")
+
+	// Bob nominates only himself, so Alice isn't a target
+	bobAccountID, _ := accountIDFromAddress("5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty")
+	verifier := newProofRPCServer(t, [][]byte{bobAccountID}, nominateExtrinsicHex)
+
+	_, err := verifier.VerifyDelegationProof("5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty", "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+	if !errors.Is(err, ErrNoNomination) {
+		t.Fatalf("Expected ErrNoNomination, got: %v", err)
+	}
+	log.Printf("✅ Missing nomination reported as ErrNoNomination")
+
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		return nil, &RPCError{Code: -32000, Message: "unknown block"}
+	})
+	verifier = NewVerifier(server.URL)
+
+	_, err = verifier.VerifyDelegationProof("5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty", "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+	if err == nil || errors.Is(err, ErrNoNomination) {
+		t.Fatalf("Expected an RPC error distinct from ErrNoNomination, got: %v", err)
+	}
+	log.Printf("✅ RPC failure reported distinctly: %v", err)
+}
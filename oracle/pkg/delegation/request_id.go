@@ -0,0 +1,50 @@
+package delegation
+
+import (
+	"context"
+	"log/slog"
+)
+
+// RequestIDLogKey is the attribute a request's ID is logged under
+const RequestIDLogKey = "request_id"
+
+type requestIDKey struct{}
+
+// WithRequestID tags ctx with the ID of the request it serves, so every log line emitted under
+// it, including those of RPC calls, can be correlated with that request
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID ctx was tagged with, or ""
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// requestIDHandler adds the request ID of a record's context to the record
+type requestIDHandler struct {
+	slog.Handler
+}
+
+// NewRequestIDHandler wraps handler so that records logged with a context tagged by
+// WithRequestID carry the ID under RequestIDLogKey. Only the *Context logging methods pass
+// a context through, so the verifier uses them wherever one is in scope.
+func NewRequestIDHandler(handler slog.Handler) slog.Handler {
+	return requestIDHandler{handler}
+}
+
+func (h requestIDHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		record.AddAttrs(slog.String(RequestIDLogKey, requestID))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h requestIDHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return requestIDHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h requestIDHandler) WithGroup(name string) slog.Handler {
+	return requestIDHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,120 @@
+package delegation
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultResultCacheTTL keeps verification results for one Polkadot era
+const DefaultResultCacheTTL = 24 * time.Hour
+
+// resultCache holds recent verification results keyed on nominator and validator, so repeat
+// requests for the same pair within the TTL skip the RPC entirely
+type resultCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]resultCacheEntry
+}
+
+type resultCacheEntry struct {
+	result  DelegationVerificationResult
+	expires time.Time
+}
+
+// newResultCache creates a cache keeping results for ttl; a non-positive ttl disables it
+func newResultCache(ttl time.Duration) *resultCache {
+	return &resultCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]resultCacheEntry),
+	}
+}
+
+// resultCacheKey separates the results of different verification methods for the same pair
+func resultCacheKey(method, nominatorAddress, validatorAddress string) string {
+	return method + "|" + nominatorAddress + "+" + validatorAddress
+}
+
+// get returns a copy of the cached result marked FromCache, evicting it once expired
+func (c *resultCache) get(key string) (*DelegationVerificationResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	result := entry.result
+	result.FromCache = true
+	return &result, true
+}
+
+// put stores a copy of result, dropping any expired entries
+func (c *resultCache) put(key string, result *DelegationVerificationResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ttl <= 0 {
+		return
+	}
+
+	now := c.now()
+	for k, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = resultCacheEntry{result: *result, expires: now.Add(c.ttl)}
+}
+
+// setTTL changes how long new results are kept; a non-positive ttl disables caching and drops
+// everything cached
+func (c *resultCache) setTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ttl = ttl
+	if ttl <= 0 {
+		c.entries = make(map[string]resultCacheEntry)
+	}
+}
+
+// SetResultCacheTTL sets how long passing verification results are reused for the same
+// nominator and validator. Zero disables the cache.
+func (v *Verifier) SetResultCacheTTL(ttl time.Duration) {
+	v.resultCache.setTTL(ttl)
+}
+
+// cacheable reports whether results under ctx may be served from or stored in the cache.
+// Transcripts must record real RPC reads and pinned reads belong to one block, so both bypass it.
+func cacheable(ctx context.Context) bool {
+	return transcriptFromContext(ctx) == nil && pinnedBlock(ctx) == ""
+}
+
+// cachedResult returns the cached result for a pair when ctx allows it
+func (v *Verifier) cachedResult(ctx context.Context, key string) (*DelegationVerificationResult, bool) {
+	if !cacheable(ctx) {
+		return nil, false
+	}
+	result, ok := v.resultCache.get(key)
+	if ok {
+		v.log().DebugContext(ctx, "using cached verification result", "event", "result_cache_hit", "key", key)
+	}
+	return result, ok
+}
+
+// cacheResult stores a passing result when ctx allows it. Failures aren't cached so a nominator
+// who has only just nominated isn't turned away for a whole TTL.
+func (v *Verifier) cacheResult(ctx context.Context, key string, result *DelegationVerificationResult) {
+	if !cacheable(ctx) || !result.IsValid {
+		return
+	}
+	v.resultCache.put(key, result)
+}
@@ -0,0 +1,126 @@
+package delegation
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResultCache_EvictsAfterTTL(t *testing.T) {
+	log.Printf("🧪 Starting TestResultCache_EvictsAfterTTL")
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	cache := newResultCache(time.Hour)
+	cache.now = func() time.Time { return now }
+
+	key := resultCacheKey("v2", "nominator", "validator")
+	cache.put(key, &DelegationVerificationResult{IsValid: true})
+
+	now = now.Add(59 * time.Minute)
+	result, ok := cache.get(key)
+	if !ok || !result.FromCache || !result.IsValid {
+		t.Fatalf("Expected a cached result within the TTL, got %+v, %v", result, ok)
+	}
+	log.Printf("✅ Result served from cache within the TTL")
+
+	now = now.Add(time.Minute)
+	if _, ok := cache.get(key); ok {
+		t.Fatalf("Expected the result to expire after the TTL")
+	}
+	if len(cache.entries) != 0 {
+		t.Fatalf("Expected the expired entry to be evicted, %d remain", len(cache.entries))
+	}
+	log.Printf("✅ Result evicted once the TTL elapsed")
+}
+
+func TestResultCache_Disabled(t *testing.T) {
+	log.Printf("🧪 Starting TestResultCache_Disabled")
+
+	cache := newResultCache(0)
+	cache.put("key", &DelegationVerificationResult{IsValid: true})
+	if _, ok := cache.get("key"); ok {
+		t.Fatalf("Expected a zero TTL to disable caching")
+	}
+	log.Printf("✅ Zero TTL caches nothing")
+}
+
+func TestResultCache_ConcurrentAccess(t *testing.T) {
+	log.Printf("🧪 Starting TestResultCache_ConcurrentAccess")
+
+	cache := newResultCache(time.Minute)
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := resultCacheKey("v2", fmt.Sprintf("nominator-%d", i%4), "validator")
+			cache.put(key, &DelegationVerificationResult{IsValid: true})
+			cache.get(key)
+		}(i)
+	}
+	wg.Wait()
+
+	if len(cache.entries) != 4 {
+		t.Fatalf("Expected 4 cached pairs, got %d", len(cache.entries))
+	}
+	log.Printf("✅ Concurrent puts and gets kept %d entries", len(cache.entries))
+}
+
+func TestVerifyV2_ServesRepeatFromCache(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyV2_ServesRepeatFromCache")
+
+	nominatorID := bytes.Repeat([]byte{0x01}, 32)
+
+	var calls atomic.Int32
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		calls.Add(1)
+		switch method {
+		case "chain_getFinalizedHead":
+			return testBlockHash, nil
+		case "state_getStorage":
+			switch params[0] {
+			case activeEraStorageKey():
+				return activeEraHex(5, 0), nil
+			case bondedStorageKey(nominatorID):
+				return "0x" + hex.EncodeToString(nominatorID), nil
+			case ledgerStorageKey(nominatorID):
+				return ledgerHex(nominatorID, big.NewInt(1_000_000_000_000)), nil
+			}
+			return nominationsHex([][]byte{aliceAccountID}, 1, false), nil
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+	verifier := NewVerifier(server.URL)
+	verifier.SetNetwork(Substrate)
+
+	nominator := "0x" + hex.EncodeToString(nominatorID)
+	validator := "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+
+	first, err := verifier.VerifyV2(nominator, validator)
+	if err != nil || !first.IsValid || first.FromCache {
+		t.Fatalf("Expected a fresh passing result, got %+v, %v", first, err)
+	}
+	callsAfterFirst := calls.Load()
+
+	second, err := verifier.VerifyV2(nominator, validator)
+	if err != nil || !second.IsValid || !second.FromCache {
+		t.Fatalf("Expected a cached passing result, got %+v, %v", second, err)
+	}
+	if calls.Load() != callsAfterFirst {
+		t.Fatalf("Expected a cache hit to skip RPC, made %d more calls", calls.Load()-callsAfterFirst)
+	}
+	log.Printf("✅ Repeat verification served from cache without RPC")
+
+	verifier.SetResultCacheTTL(0)
+	third, err := verifier.VerifyV2(nominator, validator)
+	if err != nil || third.FromCache || calls.Load() == callsAfterFirst {
+		t.Fatalf("Expected disabling the cache to query again, got %+v, %v", third, err)
+	}
+	log.Printf("✅ Disabled cache queries the chain again")
+}
@@ -0,0 +1,100 @@
+package delegation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// Retry defaults for transient RPC failures
+const (
+	DefaultMaxRetries   = 2
+	DefaultRetryBackoff = 200 * time.Millisecond
+)
+
+// transientRPCError marks a failure worth retrying: a network error or an HTTP 5xx/429 response
+type transientRPCError struct {
+	err error
+}
+
+func (e *transientRPCError) Error() string { return e.err.Error() }
+func (e *transientRPCError) Unwrap() error { return e.err }
+
+// isTransientStatus reports whether an HTTP status means the endpoint may succeed on retry
+func isTransientStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// isRetryable reports whether a failed RPC call should be retried
+func isRetryable(err error) bool {
+	var transient *transientRPCError
+	return errors.As(err, &transient)
+}
+
+// SetMaxRetries sets how many times a transient RPC failure is retried; zero disables retries
+func (v *Verifier) SetMaxRetries(maxRetries int) {
+	v.maxRetries = maxRetries
+}
+
+// SetRetryBackoff sets the delay before the first retry; it doubles on each further attempt
+func (v *Verifier) SetRetryBackoff(backoff time.Duration) {
+	v.retryBackoff = backoff
+}
+
+// retryDelay returns the exponential backoff before retry number attempt (from 1), with up to
+// one base delay of jitter so concurrent callers don't retry in lockstep
+func (v *Verifier) retryDelay(attempt int) time.Duration {
+	delay := v.retryBackoff << (attempt - 1)
+	if v.retryBackoff > 0 {
+		delay += time.Duration(rand.Int63n(int64(v.retryBackoff)))
+	}
+	return delay
+}
+
+// doRPCCallWithRetry performs an RPC call, retrying transient failures with exponential backoff.
+// JSON-RPC errors returned by the node are not retried.
+func (v *Verifier) doRPCCallWithRetry(ctx context.Context, request RPCRequest) (interface{}, error) {
+	var result interface{}
+	err := v.withRetry(ctx, request.Method, func() error {
+		var err error
+		result, err = v.doRPCCall(ctx, request)
+		return err
+	})
+	return result, err
+}
+
+// withRetry runs call until it succeeds, fails with a non-transient error or runs out of retries.
+// The final error reports how many attempts were made.
+func (v *Verifier) withRetry(ctx context.Context, label string, call func() error) error {
+	attempts := 0
+	for {
+		attempts++
+		err := call()
+		if err == nil {
+			return nil
+		}
+
+		if !isRetryable(err) || ctx.Err() != nil {
+			if attempts > 1 {
+				return fmt.Errorf("%w (after %d attempts)", err, attempts)
+			}
+			return err
+		}
+		if attempts > v.maxRetries {
+			return fmt.Errorf("%w (after %d attempts)", err, attempts)
+		}
+
+		delay := v.retryDelay(attempts)
+		v.log().WarnContext(ctx, "RPC call failed, retrying", "event", "rpc_retry", "method", label, "attempt", attempts,
+			"delay_ms", delay.Milliseconds(), "error", err)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return fmt.Errorf("%w (after %d attempts)", ctx.Err(), attempts)
+		}
+	}
+}
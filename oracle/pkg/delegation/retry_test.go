@@ -0,0 +1,87 @@
+package delegation
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newFlakyRPCServer fails the first failures requests with status, then answers successfully
+func newFlakyRPCServer(t *testing.T, failures int32, status int, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+		json.NewEncoder(w).Encode(RPCResponse{JSONRPC: "2.0", Result: "0x01", ID: 1})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestMakeRPCCall_RetriesTransientFailures(t *testing.T) {
+	log.Printf("🧪 Starting TestMakeRPCCall_RetriesTransientFailures")
+
+	var calls atomic.Int32
+	server := newFlakyRPCServer(t, 2, http.StatusServiceUnavailable, &calls)
+	verifier := NewVerifier(server.URL)
+	verifier.SetRetryBackoff(time.Millisecond)
+
+	result, err := verifier.makeRPCCall(RPCRequest{JSONRPC: "2.0", Method: "state_getStorage", Params: []interface{}{"0x00"}, ID: 1})
+	if err != nil {
+		t.Fatalf("Expected success after retries, got: %v", err)
+	}
+	if result != "0x01" {
+		t.Fatalf("Unexpected result: %v", result)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", calls.Load())
+	}
+	log.Printf("✅ Succeeded after %d attempts", calls.Load())
+}
+
+func TestMakeRPCCall_ReportsAttempts(t *testing.T) {
+	log.Printf("🧪 Starting TestMakeRPCCall_ReportsAttempts")
+
+	var calls atomic.Int32
+	server := newFlakyRPCServer(t, 10, http.StatusTooManyRequests, &calls)
+	verifier := NewVerifier(server.URL)
+	verifier.SetRetryBackoff(time.Millisecond)
+	verifier.SetMaxRetries(3)
+
+	_, err := verifier.makeRPCCall(RPCRequest{JSONRPC: "2.0", Method: "state_getStorage", Params: []interface{}{"0x00"}, ID: 1})
+	if err == nil {
+		t.Fatalf("Expected persistent 429s to fail")
+	}
+	if !strings.Contains(err.Error(), "after 4 attempts") || calls.Load() != 4 {
+		t.Fatalf("Expected 4 attempts to be made and reported, got %d: %v", calls.Load(), err)
+	}
+	log.Printf("✅ Final error reports the attempts: %v", err)
+}
+
+func TestMakeRPCCall_RPCErrorFailsFast(t *testing.T) {
+	log.Printf("🧪 Starting TestMakeRPCCall_RPCErrorFailsFast")
+
+	var calls atomic.Int32
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		calls.Add(1)
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+	verifier := NewVerifier(server.URL)
+	verifier.SetRetryBackoff(time.Millisecond)
+
+	if _, err := verifier.makeRPCCall(RPCRequest{JSONRPC: "2.0", Method: "unknown_method", Params: []interface{}{}, ID: 1}); err == nil {
+		t.Fatalf("Expected method-not-found to fail")
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("Expected a single attempt, got %d", calls.Load())
+	}
+	log.Printf("✅ JSON-RPC error failed without retrying")
+}
@@ -0,0 +1,48 @@
+package delegation
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// rpcMetrics exports upstream RPC latency and failures in Prometheus form
+type rpcMetrics struct {
+	duration *prometheus.HistogramVec
+	errors   *prometheus.CounterVec
+}
+
+// newRPCMetrics creates unregistered RPC collectors; the Verifier itself is registered
+func newRPCMetrics() *rpcMetrics {
+	return &rpcMetrics{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "oracle_rpc_duration_seconds",
+			Help:    "Latency of upstream Polkadot RPC calls, including retries.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oracle_rpc_errors_total",
+			Help: "Upstream Polkadot RPC calls that failed after retries.",
+		}, []string{"method"}),
+	}
+}
+
+// observe records one RPC call to method that took elapsed
+func (m *rpcMetrics) observe(method string, elapsed time.Duration, err error) {
+	m.duration.WithLabelValues(method).Observe(elapsed.Seconds())
+	if err != nil {
+		m.errors.WithLabelValues(method).Inc()
+	}
+}
+
+// Describe implements prometheus.Collector, so a Verifier can be registered to export its RPC metrics
+func (v *Verifier) Describe(ch chan<- *prometheus.Desc) {
+	v.metrics.duration.Describe(ch)
+	v.metrics.errors.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (v *Verifier) Collect(ch chan<- prometheus.Metric) {
+	v.metrics.duration.Collect(ch)
+	v.metrics.errors.Collect(ch)
+}
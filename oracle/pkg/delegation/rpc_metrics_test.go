@@ -0,0 +1,57 @@
+package delegation
+
+import (
+	"log"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestRPCMetrics_ExportsLatencyAndErrors(t *testing.T) {
+	log.Printf("🧪 Starting TestRPCMetrics_ExportsLatencyAndErrors")
+
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		if method == "chain_getBlock" {
+			return nil, &RPCError{Code: -32000, Message: "unknown block"}
+		}
+		return "0x00", nil
+	})
+	verifier := NewVerifier(server.URL)
+
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(verifier); err != nil {
+		t.Fatalf("Failed to register verifier: %v", err)
+	}
+
+	verifier.makeRPCCall(RPCRequest{JSONRPC: "2.0", Method: "state_getStorage", Params: []interface{}{"0x00"}, ID: 1})
+	verifier.makeRPCCall(RPCRequest{JSONRPC: "2.0", Method: "state_getStorage", Params: []interface{}{"0x00"}, ID: 1})
+	verifier.makeRPCCall(RPCRequest{JSONRPC: "2.0", Method: "chain_getBlock", Params: []interface{}{"0x00"}, ID: 1})
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+
+	observations := make(map[string]uint64)
+	errorCounts := make(map[string]float64)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			method := metric.GetLabel()[0].GetValue()
+			switch family.GetName() {
+			case "oracle_rpc_duration_seconds":
+				observations[method] = metric.GetHistogram().GetSampleCount()
+			case "oracle_rpc_errors_total":
+				errorCounts[method] = metric.GetCounter().GetValue()
+			}
+		}
+	}
+	log.Printf("📋 Latency observations: %v, errors: %v", observations, errorCounts)
+
+	if observations["state_getStorage"] != 2 || observations["chain_getBlock"] != 1 {
+		t.Errorf("Expected 2 state_getStorage and 1 chain_getBlock latency observations, got %v", observations)
+	}
+	if errorCounts["chain_getBlock"] != 1 || errorCounts["state_getStorage"] != 0 {
+		t.Errorf("Expected a single chain_getBlock error, got %v", errorCounts)
+	}
+	log.Printf("✅ RPC latency and errors exported per method")
+}
@@ -0,0 +1,59 @@
+package delegation
+
+import "sync"
+
+// MethodStats counts the outcomes of calls to a single RPC method
+type MethodStats struct {
+	Success     uint64  `json:"success"`
+	Failure     uint64  `json:"failure"`
+	SuccessRate float64 `json:"successRate"`
+}
+
+// rpcStats tracks per-method RPC outcomes and is safe for concurrent use
+type rpcStats struct {
+	mu      sync.Mutex
+	methods map[string]*MethodStats
+}
+
+// newRPCStats creates an empty stats tracker
+func newRPCStats() *rpcStats {
+	return &rpcStats{methods: make(map[string]*MethodStats)}
+}
+
+// record counts one call to method
+func (s *rpcStats) record(method string, success bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats, ok := s.methods[method]
+	if !ok {
+		stats = &MethodStats{}
+		s.methods[method] = stats
+	}
+	if success {
+		stats.Success++
+	} else {
+		stats.Failure++
+	}
+}
+
+// snapshot returns a copy of the counters with success rates filled in
+func (s *rpcStats) snapshot() map[string]MethodStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make(map[string]MethodStats, len(s.methods))
+	for method, stats := range s.methods {
+		copied := *stats
+		if total := copied.Success + copied.Failure; total > 0 {
+			copied.SuccessRate = float64(copied.Success) / float64(total)
+		}
+		out[method] = copied
+	}
+	return out
+}
+
+// RPCStats returns the success and failure counts of every RPC method called so far
+func (v *Verifier) RPCStats() map[string]MethodStats {
+	return v.stats.snapshot()
+}
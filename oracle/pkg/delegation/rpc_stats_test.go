@@ -0,0 +1,45 @@
+package delegation
+
+import (
+	"log"
+	"sync"
+	"testing"
+)
+
+func TestRPCStats_CountsPerMethod(t *testing.T) {
+	log.Printf("🧪 Starting TestRPCStats_CountsPerMethod")
+
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		if method == "chain_getBlock" {
+			return nil, &RPCError{Code: -32000, Message: "unknown block"}
+		}
+		return "0x00", nil
+	})
+	verifier := NewVerifier(server.URL)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			verifier.makeRPCCall(RPCRequest{JSONRPC: "2.0", Method: "state_getStorage", Params: []interface{}{"0x00"}, ID: 1})
+		}()
+		go func() {
+			defer wg.Done()
+			verifier.makeRPCCall(RPCRequest{JSONRPC: "2.0", Method: "chain_getBlock", Params: []interface{}{"0x00"}, ID: 1})
+		}()
+	}
+	wg.Wait()
+
+	stats := verifier.RPCStats()
+	log.Printf("📋 RPC stats: %+v", stats)
+
+	if got := stats["state_getStorage"]; got.Success != 3 || got.Failure != 0 || got.SuccessRate != 1 {
+		t.Errorf("Unexpected state_getStorage stats: %+v", got)
+	}
+	if got := stats["chain_getBlock"]; got.Success != 0 || got.Failure != 3 || got.SuccessRate != 0 {
+		t.Errorf("Unexpected chain_getBlock stats: %+v", got)
+	}
+
+	log.Printf("✅ RPC stats counted per method")
+}
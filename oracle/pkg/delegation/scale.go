@@ -0,0 +1,198 @@
+package delegation
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+)
+
+// scaleDecoder reads SCALE-encoded values from a byte slice
+type scaleDecoder struct {
+	data   []byte
+	offset int
+}
+
+// newScaleDecoder creates a decoder over raw SCALE bytes
+func newScaleDecoder(data []byte) *scaleDecoder {
+	return &scaleDecoder{data: data}
+}
+
+// remaining returns the number of bytes not yet consumed
+func (d *scaleDecoder) remaining() int {
+	return len(d.data) - d.offset
+}
+
+// readBytes consumes n raw bytes
+func (d *scaleDecoder) readBytes(n int) ([]byte, error) {
+	if n < 0 || d.remaining() < n {
+		return nil, fmt.Errorf("unexpected end of SCALE data: need %d bytes at offset %d, have %d", n, d.offset, d.remaining())
+	}
+	out := d.data[d.offset : d.offset+n]
+	d.offset += n
+	return out, nil
+}
+
+// readU8 consumes a single byte
+func (d *scaleDecoder) readU8() (byte, error) {
+	b, err := d.readBytes(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+// readBool consumes a SCALE bool (0x00 or 0x01)
+func (d *scaleDecoder) readBool() (bool, error) {
+	b, err := d.readU8()
+	if err != nil {
+		return false, err
+	}
+	switch b {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid SCALE bool byte: 0x%02x", b)
+	}
+}
+
+// readU32 consumes a little-endian u32
+func (d *scaleDecoder) readU32() (uint32, error) {
+	b, err := d.readBytes(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+// readU64 consumes a little-endian u64
+func (d *scaleDecoder) readU64() (uint64, error) {
+	b, err := d.readBytes(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(b), nil
+}
+
+// readOptionU64 consumes an Option<u64>, returning nil for None
+func (d *scaleDecoder) readOptionU64() (*uint64, error) {
+	tag, err := d.readU8()
+	if err != nil {
+		return nil, err
+	}
+	switch tag {
+	case 0:
+		return nil, nil
+	case 1:
+		value, err := d.readU64()
+		if err != nil {
+			return nil, err
+		}
+		return &value, nil
+	default:
+		return nil, fmt.Errorf("invalid SCALE option tag: 0x%02x", tag)
+	}
+}
+
+// readCompact consumes a SCALE compact-encoded unsigned integer that fits in a u64
+func (d *scaleDecoder) readCompact() (uint64, error) {
+	first, err := d.readU8()
+	if err != nil {
+		return 0, err
+	}
+
+	switch first & 0x03 {
+	case 0x00: // single-byte mode
+		return uint64(first >> 2), nil
+	case 0x01: // two-byte mode
+		second, err := d.readU8()
+		if err != nil {
+			return 0, err
+		}
+		return uint64(binary.LittleEndian.Uint16([]byte{first, second}) >> 2), nil
+	case 0x02: // four-byte mode
+		rest, err := d.readBytes(3)
+		if err != nil {
+			return 0, err
+		}
+		return uint64(binary.LittleEndian.Uint32([]byte{first, rest[0], rest[1], rest[2]}) >> 2), nil
+	default: // big-integer mode: upper six bits hold the byte length minus four
+		length := int(first>>2) + 4
+		if length > 8 {
+			return 0, fmt.Errorf("compact integer of %d bytes does not fit in u64", length)
+		}
+		raw, err := d.readBytes(length)
+		if err != nil {
+			return 0, err
+		}
+		buf := make([]byte, 8)
+		copy(buf, raw)
+		return binary.LittleEndian.Uint64(buf), nil
+	}
+}
+
+// readCompactBig consumes a SCALE compact-encoded unsigned integer of any width, such as a u128 balance
+func (d *scaleDecoder) readCompactBig() (*big.Int, error) {
+	first := d.offset
+	if d.remaining() == 0 {
+		return nil, fmt.Errorf("unexpected end of SCALE data at offset %d", d.offset)
+	}
+
+	if d.data[first]&0x03 != 0x03 {
+		value, err := d.readCompact()
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Int).SetUint64(value), nil
+	}
+
+	d.offset++
+	length := int(d.data[first]>>2) + 4
+	raw, err := d.readBytes(length)
+	if err != nil {
+		return nil, err
+	}
+
+	// Little-endian on the wire, big.Int expects big-endian
+	reversed := make([]byte, length)
+	for i, b := range raw {
+		reversed[length-1-i] = b
+	}
+	return new(big.Int).SetBytes(reversed), nil
+}
+
+// readBalance consumes a compact-encoded Balance. Balances are u128 on every supported chain,
+// so wider values mean the data isn't a balance and are rejected rather than decoded.
+func (d *scaleDecoder) readBalance() (*big.Int, error) {
+	offset := d.offset
+	value, err := d.readCompactBig()
+	if err != nil {
+		return nil, err
+	}
+	if value.BitLen() > 128 {
+		return nil, fmt.Errorf("balance at offset %d exceeds 128 bits", offset)
+	}
+	return value, nil
+}
+
+// readAccountIDs consumes a Vec<AccountId32>: a compact length followed by 32-byte ids
+func (d *scaleDecoder) readAccountIDs() ([][]byte, error) {
+	count, err := d.readCompact()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode vector length: %w", err)
+	}
+	if count > uint64(d.remaining()/32) {
+		return nil, fmt.Errorf("vector length %d exceeds remaining data", count)
+	}
+
+	accounts := make([][]byte, 0, count)
+	for i := uint64(0); i < count; i++ {
+		account, err := d.readBytes(32)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, append([]byte{}, account...))
+	}
+	return accounts, nil
+}
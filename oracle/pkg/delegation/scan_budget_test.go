@@ -0,0 +1,175 @@
+package delegation
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFindExtrinsicByAddress_StopsAtRPCBudget(t *testing.T) {
+	log.Printf("🧪 Starting TestFindExtrinsicByAddress_StopsAtRPCBudget")
+
+	var calls atomic.Int32
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		calls.Add(1)
+		switch method {
+		case "chain_getHeader":
+			return map[string]interface{}{"number": "0x64"}, nil
+		case "chain_getBlockHash":
+			return testBlockHash, nil
+		case "chain_getBlock":
+			return mockBlock(), nil
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+	verifier := NewVerifier(server.URL)
+	verifier.SetMaxRPCCallsPerVerify(5)
+
+	scan, err := verifier.findExtrinsicByAddress(context.Background(), "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty", "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+	if err != nil {
+		t.Fatalf("Expected partial results, got error: %v", err)
+	}
+
+	// One header call plus two blocks at two calls each
+	if got := calls.Load(); got != 5 {
+		t.Fatalf("Expected the scan to stop after 5 RPC calls, got %d", got)
+	}
+	if scan.Note != ScanNoteBudgetExhausted {
+		t.Fatalf("Expected note %q, got %q", ScanNoteBudgetExhausted, scan.Note)
+	}
+	log.Printf("✅ Scan stopped after %d calls with note %q", calls.Load(), scan.Note)
+}
+
+func TestFindExtrinsicByAddress_StopsOnCancel(t *testing.T) {
+	log.Printf("🧪 Starting TestFindExtrinsicByAddress_StopsOnCancel")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var blocks atomic.Int32
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		switch method {
+		case "chain_getHeader":
+			return map[string]interface{}{"number": "0x64"}, nil
+		case "chain_getBlockHash":
+			return testBlockHash, nil
+		case "chain_getBlock":
+			// Cancel while the first block is being read
+			if blocks.Add(1) == 1 {
+				cancel()
+			}
+			return mockBlock(), nil
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+	verifier := NewVerifier(server.URL)
+	verifier.SetScanRange(50)
+
+	start := time.Now()
+	_, err := verifier.findExtrinsicByAddress(ctx, "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty", "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got: %v", err)
+	}
+	if got := blocks.Load(); got > 1 {
+		t.Fatalf("Expected the scan to stop after the first block, read %d", got)
+	}
+	if elapsed >= blockScanDelay {
+		t.Fatalf("Expected an early return, took %v", elapsed)
+	}
+	log.Printf("✅ Cancelled scan returned after %d block(s) in %v", blocks.Load(), elapsed)
+}
+
+func TestFindExtrinsicByAddress_ScanLimits(t *testing.T) {
+	log.Printf("🧪 Starting TestFindExtrinsicByAddress_ScanLimits")
+
+	var blocks atomic.Int32
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		switch method {
+		case "chain_getHeader":
+			return map[string]interface{}{"number": "0x64"}, nil
+		case "chain_getBlockHash":
+			return testBlockHash, nil
+		case "chain_getBlock":
+			blocks.Add(1)
+			return mockBlock(), nil
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+	verifier := NewVerifier(server.URL)
+	verifier.SetScanRange(2)
+
+	if _, err := verifier.findExtrinsicByAddress(context.Background(), "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty", "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"); err != nil {
+		t.Fatalf("Expected scan to succeed, got: %v", err)
+	}
+	// The latest block and the two before it
+	if got := blocks.Load(); got != 3 {
+		t.Fatalf("Expected 3 blocks scanned, got %d", got)
+	}
+	log.Printf("✅ Scan range of 2 read %d blocks", blocks.Load())
+
+	verifier.SetScanRange(0)
+	verifier.SetScanMaxResults(-1)
+	if verifier.scanRange != DefaultScanRange || verifier.scanMaxResults != DefaultScanMaxResults {
+		t.Fatalf("Expected non-positive limits to restore defaults, got range %d, max results %d", verifier.scanRange, verifier.scanMaxResults)
+	}
+	log.Printf("✅ Non-positive limits restore the defaults")
+}
+
+func TestFindExtrinsicByAddress_StampsBlockTime(t *testing.T) {
+	log.Printf("🧪 Starting TestFindExtrinsicByAddress_StampsBlockTime")
+
+	var timestampReads atomic.Int32
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		switch method {
+		case "chain_getHeader":
+			return map[string]interface{}{"number": "0x64"}, nil
+		case "chain_getBlockHash":
+			return testBlockHash, nil
+		case "chain_getBlock":
+			return mockBlock("0x280403000b", nominateExtrinsicHex), nil
+		case "state_getStorage":
+			if params[0] == timestampNowStorageKey() && params[1] == testBlockHash {
+				timestampReads.Add(1)
+				// 1700000000123 ms as a little-endian u64
+				return "0x7b68e5cf8b010000", nil
+			}
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+	verifier := NewVerifier(server.URL)
+	verifier.SetScanRange(1)
+
+	scan, err := verifier.findExtrinsicByAddress(context.Background(), "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty", "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+	if err != nil {
+		t.Fatalf("Expected the scan to succeed, got: %v", err)
+	}
+	if len(scan.Extrinsics) != 2 {
+		t.Fatalf("Expected the nomination in each of the 2 blocks, got %d", len(scan.Extrinsics))
+	}
+	for _, extrinsic := range scan.Extrinsics {
+		if extrinsic.Timestamp != "2023-11-14T22:13:20Z" {
+			t.Fatalf("Expected the block's Timestamp.Now, got %q", extrinsic.Timestamp)
+		}
+	}
+	if got := timestampReads.Load(); got != 2 {
+		t.Fatalf("Expected one timestamp read per block, got %d", got)
+	}
+	log.Printf("✅ Extrinsics stamped with their block's time: %s", scan.Extrinsics[0].Timestamp)
+
+	// The budget covers the header and one block, leaving no call for its timestamp
+	timestampReads.Store(0)
+	verifier.SetMaxRPCCallsPerVerify(3)
+	scan, err = verifier.findExtrinsicByAddress(context.Background(), "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty", "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+	if err != nil {
+		t.Fatalf("Expected partial results, got error: %v", err)
+	}
+	if len(scan.Extrinsics) != 1 || scan.Extrinsics[0].Timestamp != "" || timestampReads.Load() != 0 {
+		t.Fatalf("Expected an unstamped extrinsic within the budget, got %+v after %d timestamp reads", scan.Extrinsics, timestampReads.Load())
+	}
+	log.Printf("✅ Timestamp skipped once the RPC budget is spent")
+}
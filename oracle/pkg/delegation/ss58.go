@@ -0,0 +1,136 @@
+package delegation
+
+import (
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// base58Alphabet is the Bitcoin base58 alphabet used by SS58
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// maxSS58Length bounds the addresses DecodeSS58 attempts to decode. The longest supported payload,
+// 36 bytes, encodes to at most 50 characters; rejecting anything much longer up front keeps
+// untrusted input from costing quadratic big-integer work.
+const maxSS58Length = 64
+
+// ss58Prefix is prepended to the payload when computing the SS58 checksum
+var ss58Prefix = []byte("SS58PRE")
+
+// base58Decode decodes a base58 string into bytes, preserving leading zero bytes
+func base58Decode(input string) ([]byte, error) {
+	result := big.NewInt(0)
+	radix := big.NewInt(58)
+
+	for i := 0; i < len(input); i++ {
+		idx := -1
+		for j := 0; j < len(base58Alphabet); j++ {
+			if base58Alphabet[j] == input[i] {
+				idx = j
+				break
+			}
+		}
+		if idx < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q at position %d", input[i], i)
+		}
+		result.Mul(result, radix)
+		result.Add(result, big.NewInt(int64(idx)))
+	}
+
+	decoded := result.Bytes()
+
+	// Each leading '1' encodes a leading zero byte
+	leadingZeros := 0
+	for leadingZeros < len(input) && input[leadingZeros] == base58Alphabet[0] {
+		leadingZeros++
+	}
+
+	return append(make([]byte, leadingZeros), decoded...), nil
+}
+
+// ss58Checksum computes the SS58 checksum over the prefix and account bytes
+func ss58Checksum(data []byte) []byte {
+	hash := blake2b.Sum512(append(append([]byte{}, ss58Prefix...), data...))
+	return hash[:2]
+}
+
+// encodeSS58 encodes an account payload under a single-byte network prefix
+func encodeSS58(prefix byte, account []byte) string {
+	payload := append([]byte{prefix}, account...)
+	data := append(payload, ss58Checksum(payload)...)
+
+	value := new(big.Int).SetBytes(data)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+
+	var encoded []byte
+	for value.Sign() > 0 {
+		value.DivMod(value, radix, mod)
+		encoded = append([]byte{base58Alphabet[mod.Int64()]}, encoded...)
+	}
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		encoded = append([]byte{base58Alphabet[0]}, encoded...)
+	}
+	return string(encoded)
+}
+
+// EncodeSS58 encodes a 32-byte AccountId as an SS58 address under a simple network prefix,
+// the inverse of DecodeSS58. Only the single-byte prefixes 0-63 are supported.
+func EncodeSS58(accountID []byte, prefix byte) (string, error) {
+	if len(accountID) != 32 {
+		return "", fmt.Errorf("invalid AccountId length: expected 32 bytes, got %d", len(accountID))
+	}
+	if prefix > 63 {
+		return "", fmt.Errorf("unsupported SS58 prefix byte: %d", prefix)
+	}
+	return encodeSS58(prefix, accountID), nil
+}
+
+// DecodeSS58 parses an SS58 address into its 32-byte AccountId and network prefix.
+// The blake2b checksum is validated and addresses with an unexpected length are rejected.
+// A 33-byte payload is a compressed ECDSA public key, whose AccountId is its blake2b-256 hash.
+func DecodeSS58(address string) ([]byte, byte, error) {
+	if address == "" {
+		return nil, 0, fmt.Errorf("empty SS58 address")
+	}
+	if len(address) > maxSS58Length {
+		return nil, 0, fmt.Errorf("invalid SS58 address length: got %d characters", len(address))
+	}
+
+	decoded, err := base58Decode(address)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid SS58 address: %w", err)
+	}
+
+	// Simple (single byte) prefix, 32- or 33-byte account and 2-byte checksum
+	accountLength := len(decoded) - 1 - 2
+	if accountLength != 32 && accountLength != 33 {
+		return nil, 0, fmt.Errorf("invalid SS58 address length: got %d bytes", len(decoded))
+	}
+
+	prefix := decoded[0]
+	if prefix > 63 {
+		return nil, 0, fmt.Errorf("unsupported SS58 prefix byte: %d", prefix)
+	}
+
+	payload := decoded[:1+accountLength]
+	checksum := decoded[1+accountLength:]
+	expected := ss58Checksum(payload)
+	if checksum[0] != expected[0] || checksum[1] != expected[1] {
+		return nil, 0, fmt.Errorf("invalid SS58 checksum")
+	}
+
+	if accountLength == 33 {
+		hash := blake2b.Sum256(payload[1:])
+		return hash[:], prefix, nil
+	}
+
+	accountID := make([]byte, 32)
+	copy(accountID, payload[1:])
+
+	return accountID, prefix, nil
+}
@@ -0,0 +1,159 @@
+package delegation
+
+import (
+	"bytes"
+	"encoding/hex"
+	"log"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestDecodeSS58(t *testing.T) {
+	log.Printf("🧪 Starting TestDecodeSS58")
+
+	accountID, prefix, err := DecodeSS58("5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !bytes.Equal(accountID, aliceAccountID) || prefix != 42 {
+		t.Fatalf("Unexpected decoding: %x prefix %d", accountID, prefix)
+	}
+	log.Printf("✅ Decoded Alice: %x (prefix %d)", accountID, prefix)
+
+	// The same account under the Polkadot prefix
+	if accountID, prefix, err = DecodeSS58(encodeSS58(0, aliceAccountID)); err != nil || prefix != 0 || !bytes.Equal(accountID, aliceAccountID) {
+		t.Fatalf("Unexpected Polkadot decoding: %x prefix %d, %v", accountID, prefix, err)
+	}
+	log.Printf("✅ Decoded Polkadot-prefixed Alice")
+}
+
+func TestDecodeSS58_ECDSAAccount(t *testing.T) {
+	log.Printf("🧪 Starting TestDecodeSS58_ECDSAAccount")
+
+	publicKey, _ := hex.DecodeString("020a1091341fe5664bfa1782d5e04779689068c916b04cb365ec3153755684d9a1")
+	expected := blake2b.Sum256(publicKey)
+
+	accountID, prefix, err := DecodeSS58(encodeSS58(42, publicKey))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !bytes.Equal(accountID, expected[:]) || prefix != 42 {
+		t.Fatalf("Expected AccountId %x, got %x (prefix %d)", expected, accountID, prefix)
+	}
+	log.Printf("✅ 33-byte key decoded to AccountId %x", accountID)
+}
+
+func TestDecodeSS58_Invalid(t *testing.T) {
+	log.Printf("🧪 Starting TestDecodeSS58_Invalid")
+
+	valid := encodeSS58(42, aliceAccountID)
+	badChecksum := []byte(valid)
+	badChecksum[len(badChecksum)-1] = base58Alphabet[(bytes.IndexByte([]byte(base58Alphabet), badChecksum[len(badChecksum)-1])+1)%58]
+
+	cases := map[string]string{
+		"empty":          "",
+		"bad character":  "0GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+		"bad checksum":   string(badChecksum),
+		"short account":  encodeSS58(42, aliceAccountID[:31]),
+		"long account":   encodeSS58(42, append(append([]byte{}, aliceAccountID...), 0x01, 0x02)),
+		"unknown prefix": encodeSS58(64, aliceAccountID),
+		"too long":       strings.Repeat("z", maxSS58Length+1),
+	}
+
+	for name, address := range cases {
+		if _, _, err := DecodeSS58(address); err == nil {
+			t.Errorf("%s: expected error for %q", name, address)
+		} else {
+			log.Printf("✅ %s rejected: %v", name, err)
+		}
+	}
+}
+
+func TestEncodeSS58_RoundTrip(t *testing.T) {
+	log.Printf("🧪 Starting TestEncodeSS58_RoundTrip")
+
+	for name, address := range map[string]string{
+		"Polkadot validator": "12GTt3pfM3SjTU6UL6dQ3SMgMSvdw94PnRoF6osU6hPvxbUZ",
+		"Polkadot Alice":     "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5",
+		"Kusama Alice":       "HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F",
+		"Substrate Alice":    "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+	} {
+		accountID, prefix, err := DecodeSS58(address)
+		if err != nil {
+			t.Fatalf("%s: failed to decode %s: %v", name, address, err)
+		}
+		encoded, err := EncodeSS58(accountID, prefix)
+		if err != nil {
+			t.Fatalf("%s: failed to encode: %v", name, err)
+		}
+		if encoded != address {
+			t.Fatalf("%s: expected %s, got %s", name, address, encoded)
+		}
+		log.Printf("✅ %s round-tripped under prefix %d", name, prefix)
+	}
+}
+
+func TestEncodeSS58_Invalid(t *testing.T) {
+	log.Printf("🧪 Starting TestEncodeSS58_Invalid")
+
+	cases := map[string]struct {
+		accountID []byte
+		prefix    byte
+	}{
+		"short account":  {aliceAccountID[:31], 0},
+		"ECDSA key":      {append([]byte{0x02}, aliceAccountID...), 0},
+		"unknown prefix": {aliceAccountID, 64},
+	}
+
+	for name, tc := range cases {
+		if address, err := EncodeSS58(tc.accountID, tc.prefix); err == nil {
+			t.Errorf("%s: expected an error, got %s", name, address)
+		} else {
+			log.Printf("✅ %s rejected: %v", name, err)
+		}
+	}
+}
+
+func FuzzDecodeSS58(f *testing.F) {
+	polkadot := "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5"
+	kusama := "HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F"
+	substrate := "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+	for _, seed := range []string{
+		polkadot,
+		kusama,
+		substrate,
+		"5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty",
+		polkadot[:len(polkadot)-1] + "6",     // wrong checksum
+		kusama[:len(kusama)-1] + "G",         // wrong checksum
+		substrate[:len(substrate)-4],         // truncated
+		substrate[1:],                        // missing its first character
+		substrate + "1",                      // one character too long
+		"0" + substrate[1:],                  // character outside the alphabet
+		"1111111111111111111111111111111111", // leading zero bytes only
+		"",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, address string) {
+		accountID, prefix, err := DecodeSS58(address)
+		if err != nil {
+			if accountID != nil {
+				t.Fatalf("Expected no AccountId alongside error %v, got %x", err, accountID)
+			}
+			return
+		}
+		if len(accountID) != 32 || prefix > 63 {
+			t.Fatalf("Decoded %q to an invalid AccountId %x under prefix %d", address, accountID, prefix)
+		}
+
+		// A decoded sr25519/ed25519 address encodes back to itself
+		if decoded, _ := base58Decode(address); len(decoded) == 1+32+2 {
+			if encoded, err := EncodeSS58(accountID, prefix); err != nil || encoded != address {
+				t.Fatalf("Expected %q to round-trip, got %q: %v", address, encoded, err)
+			}
+		}
+	})
+}
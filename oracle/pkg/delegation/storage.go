@@ -0,0 +1,167 @@
+package delegation
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/cespare/xxhash/v2"
+	"golang.org/x/crypto/blake2b"
+)
+
+// twox64 computes the 8-byte xxhash64 (seed 0) used by Substrate's Twox64 hasher
+func twox64(data []byte) []byte {
+	out := make([]byte, 8)
+	binary.LittleEndian.PutUint64(out, xxhash.Sum64(data))
+	return out
+}
+
+// twox128 computes Substrate's Twox128 hasher: xxhash64 with seeds 0 and 1, concatenated
+func twox128(data []byte) []byte {
+	out := make([]byte, 16)
+	binary.LittleEndian.PutUint64(out[:8], xxhash.Sum64(data))
+
+	digest := xxhash.NewWithSeed(1)
+	digest.Write(data)
+	binary.LittleEndian.PutUint64(out[8:], digest.Sum64())
+
+	return out
+}
+
+// twox64Concat computes Substrate's Twox64Concat hasher: twox64(data) ++ data
+func twox64Concat(data []byte) []byte {
+	return append(twox64(data), data...)
+}
+
+// blake2128Concat computes Substrate's Blake2_128Concat hasher: blake2b-128(data) ++ data
+func blake2128Concat(data []byte) []byte {
+	digest, _ := blake2b.New(16, nil) // only fails for an invalid size or key
+	digest.Write(data)
+	return append(digest.Sum(nil), data...)
+}
+
+// storagePrefix returns twox128(pallet) ++ twox128(item), the key of a storage value
+// and the prefix shared by every entry of a storage map
+func storagePrefix(pallet, item string) []byte {
+	return append(twox128([]byte(pallet)), twox128([]byte(item))...)
+}
+
+// storageKeyHex hex-encodes a storage key with the "0x" prefix expected by state_getStorage
+func storageKeyHex(key []byte) string {
+	return "0x" + hex.EncodeToString(key)
+}
+
+// activeEraStorageKey returns the key of the Staking.ActiveEra storage value
+func activeEraStorageKey() string {
+	return storageKeyHex(storagePrefix("Staking", "ActiveEra"))
+}
+
+// timestampNowStorageKey returns the key of the Timestamp.Now storage value
+func timestampNowStorageKey() string {
+	return storageKeyHex(storagePrefix("Timestamp", "Now"))
+}
+
+// erasStartSessionIndexStorageKey returns the Staking.ErasStartSessionIndex key for an era
+func erasStartSessionIndexStorageKey(era uint32) string {
+	encodedEra := make([]byte, 4)
+	binary.LittleEndian.PutUint32(encodedEra, era)
+	return storageKeyHex(append(storagePrefix("Staking", "ErasStartSessionIndex"), twox64Concat(encodedEra)...))
+}
+
+// getStorage reads a raw storage value via state_getStorage at the block ctx is pinned to, if any.
+// A null result (no value stored under the key) is returned as nil with no error.
+func (v *Verifier) getStorage(ctx context.Context, key string) ([]byte, error) {
+	return v.getStorageAt(ctx, key, pinnedBlock(ctx))
+}
+
+// getStorageAt reads a raw storage value at the given block hash, or at the best block when empty
+func (v *Verifier) getStorageAt(ctx context.Context, key string, blockHash string) ([]byte, error) {
+	params := []interface{}{key}
+	if blockHash != "" {
+		params = append(params, blockHash)
+	}
+
+	request := RPCRequest{
+		JSONRPC: "2.0",
+		Method:  "state_getStorage",
+		Params:  params,
+		ID:      1,
+	}
+
+	result, err := v.makeRPCCallCtx(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeStorageValue(result)
+}
+
+// decodeStorageValue converts a state_getStorage result to bytes; a null result means the entry is empty
+func decodeStorageValue(result interface{}) ([]byte, error) {
+	if result == nil {
+		return nil, nil
+	}
+
+	hexValue, ok := result.(string)
+	if !ok {
+		return nil, fmt.Errorf("unexpected storage result type %T", result)
+	}
+
+	value, err := hex.DecodeString(strings.TrimPrefix(hexValue, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid storage hex: %w", err)
+	}
+
+	return value, nil
+}
+
+// accountIDFromAddress returns the 32-byte AccountId for an SS58 address or a 0x-prefixed hex public key
+func accountIDFromAddress(address string) ([]byte, error) {
+	if strings.HasPrefix(address, "0x") {
+		accountID, err := hex.DecodeString(address[2:])
+		if err != nil {
+			return nil, &AddressError{address, fmt.Errorf("invalid hex account: %w", err)}
+		}
+		if len(accountID) != 32 {
+			return nil, &AddressError{address, fmt.Errorf("invalid hex account length: expected 32 bytes, got %d", len(accountID))}
+		}
+		return accountID, nil
+	}
+
+	accountID, _, err := DecodeSS58(address)
+	if err != nil {
+		return nil, &AddressError{address, err}
+	}
+	return accountID, nil
+}
+
+// payeeStorageKey returns the Staking.Payee key for a stash account
+func payeeStorageKey(accountID []byte) string {
+	return storageKeyHex(append(storagePrefix("Staking", "Payee"), twox64Concat(accountID)...))
+}
+
+// nominatorsStorageKey returns the Staking.Nominators key for a nominator account
+func nominatorsStorageKey(accountID []byte) string {
+	return storageKeyHex(append(storagePrefix("Staking", "Nominators"), twox64Concat(accountID)...))
+}
+
+// bondedStorageKey returns the Staking.Bonded key mapping a stash to its controller
+func bondedStorageKey(stashID []byte) string {
+	return storageKeyHex(append(storagePrefix("Staking", "Bonded"), twox64Concat(stashID)...))
+}
+
+// ledgerStorageKey returns the Staking.Ledger key mapping a controller to its staking ledger
+func ledgerStorageKey(controllerID []byte) string {
+	return storageKeyHex(append(storagePrefix("Staking", "Ledger"), blake2128Concat(controllerID)...))
+}
+
+// erasStakersStorageKey returns the Staking.ErasStakers key of a validator's exposure in an era
+func erasStakersStorageKey(era uint32, validatorID []byte) string {
+	encodedEra := make([]byte, 4)
+	binary.LittleEndian.PutUint32(encodedEra, era)
+
+	key := append(storagePrefix("Staking", "ErasStakers"), twox64Concat(encodedEra)...)
+	return storageKeyHex(append(key, twox64Concat(validatorID)...))
+}
@@ -0,0 +1,126 @@
+package delegation
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestNominatorsStorageKey(t *testing.T) {
+	log.Printf("🧪 Starting TestNominatorsStorageKey")
+
+	// api.query.staking.nominators.key(ALICE) from polkadot.js
+	expected := "0x5f3e4907f716ac89b6347d15ececedca9c6a637f62ae2af1c7e31eed7e96be04" +
+		"518366b5b1bc7c99" +
+		"d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d"
+
+	accountID, _, err := DecodeSS58("5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+	if err != nil {
+		t.Fatalf("Failed to decode address: %v", err)
+	}
+
+	if key := nominatorsStorageKey(accountID); key != expected {
+		t.Fatalf("Expected key %s, got %s", expected, key)
+	}
+	log.Printf("✅ Nominators key matches polkadot.js: %s", expected)
+}
+
+func TestCheckIfNominated_NoNominations(t *testing.T) {
+	log.Printf("🧪 Starting TestCheckIfNominated_NoNominations")
+
+	bobAccountID, _ := accountIDFromAddress("5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty")
+
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		switch method {
+		case "chain_getFinalizedHead":
+			return testBlockHash, nil
+		case "state_getStorage":
+			// Bob is a bonded stash that has never nominated
+			if params[0] == bondedStorageKey(bobAccountID) {
+				return "0x" + hex.EncodeToString(bobAccountID), nil
+			}
+			return nil, nil
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+	verifier := NewVerifier(server.URL)
+
+	nominated, err := verifier.checkIfNominated(context.Background(), "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty", "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if nominated {
+		t.Fatalf("Expected a null Nominators entry to mean not nominated")
+	}
+	log.Printf("✅ Null Nominators entry reported as not nominated")
+}
+
+func TestVerifyV2_NullNominatorsEntry(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyV2_NullNominatorsEntry")
+
+	bobAccountID, _ := accountIDFromAddress("5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty")
+	nominatorsKey := nominatorsStorageKey(bobAccountID)
+	charlieAccountID := bytes.Repeat([]byte{0x05}, 32)
+
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		switch method {
+		case "chain_getFinalizedHead":
+			return testBlockHash, nil
+		case "state_getStorage":
+			// Bob is bonded but chilled, so his Nominators entry is answered with "result": null.
+			// Charlie has never staked, so none of his entries exist.
+			switch params[0] {
+			case bondedStorageKey(bobAccountID):
+				return "0x" + hex.EncodeToString(bobAccountID), nil
+			case nominatorsKey, bondedStorageKey(charlieAccountID), ledgerStorageKey(charlieAccountID), nominatorsStorageKey(charlieAccountID):
+				return nil, nil
+			}
+			return activeEraHex(7, 0), nil
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+	verifier := NewVerifier(server.URL)
+	verifier.SetNetwork(Substrate)
+
+	result, err := verifier.VerifyV2("0x"+hex.EncodeToString(bobAccountID), "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+	if err != nil {
+		t.Fatalf("Expected a null entry not to be an error, got: %v", err)
+	}
+	if result.IsValid || result.StorageValidation {
+		t.Fatalf("Expected the delegation to be reported missing, got %+v", result)
+	}
+	if !result.AddressValidation || strings.Contains(result.Error, "Storage verification failed") {
+		t.Fatalf("Expected not nominated rather than a storage failure, got %q", result.Error)
+	}
+	log.Printf("✅ Null Nominators entry reported as not nominated: %q", result.Error)
+
+	result, err = verifier.VerifyV2("0x"+hex.EncodeToString(charlieAccountID), "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+	if err != nil {
+		t.Fatalf("Expected an unbonded nominator not to be an error, got: %v", err)
+	}
+	if result.IsValid || !strings.Contains(result.Error, ErrNotBonded.Error()) {
+		t.Fatalf("Expected the nominator to be reported as not bonded, got %+v", result)
+	}
+	log.Printf("✅ Unbonded nominator reported as such: %q", result.Error)
+}
+
+func TestCheckIfNominated_RPCErrorIsNotNull(t *testing.T) {
+	log.Printf("🧪 Starting TestCheckIfNominated_RPCErrorIsNotNull")
+
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		if method == "chain_getFinalizedHead" {
+			return testBlockHash, nil
+		}
+		return nil, &RPCError{Code: -32000, Message: "state already discarded"}
+	})
+	verifier := NewVerifier(server.URL)
+
+	nominated, err := verifier.checkIfNominated(context.Background(), "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty", "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+	if err == nil || nominated {
+		t.Fatalf("Expected the RPC error to be reported, got nominated=%v err=%v", nominated, err)
+	}
+	log.Printf("✅ RPC error reported distinctly: %v", err)
+}
@@ -0,0 +1,162 @@
+package delegation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ProofSource finds the staking extrinsics a nominator submitted without scanning blocks, e.g.
+// from a chain indexer. GetStakingExtrinsics prefers a configured ProofSource over block scanning.
+type ProofSource interface {
+	StakingExtrinsics(ctx context.Context, nominatorAddress, validatorAddress string) ([]StakingExtrinsic, error)
+}
+
+// subscanPageSize is how many extrinsics are requested from Subscan at once, its maximum
+const subscanPageSize = 100
+
+// SubscanProofSource is a ProofSource backed by Subscan's extrinsics API. It returns the
+// nominator's Staking.nominate extrinsics across the chain's whole history.
+type SubscanProofSource struct {
+	baseURL string
+	apiKey  string
+	network Network
+	client  *http.Client
+}
+
+// NewSubscanProofSource creates a Subscan proof source for network, querying baseURL (the
+// network's SubscanURL when empty) with apiKey
+func NewSubscanProofSource(baseURL, apiKey string, network Network) *SubscanProofSource {
+	if baseURL == "" {
+		baseURL = network.SubscanURL
+	}
+	return &SubscanProofSource{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		network: network,
+		client:  &http.Client{Timeout: DefaultRPCTimeout},
+	}
+}
+
+// subscanExtrinsicsRequest is the body of a POST /api/v2/scan/extrinsics
+type subscanExtrinsicsRequest struct {
+	Address   string `json:"address"`
+	Module    string `json:"module"`
+	Call      string `json:"call"`
+	Row       int    `json:"row"`
+	Page      int    `json:"page"`
+	Success   bool   `json:"success"`
+	Finalized bool   `json:"finalized"`
+}
+
+// subscanExtrinsicsResponse is the part of Subscan's extrinsics response the proof source reads
+type subscanExtrinsicsResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		Count      int                `json:"count"`
+		Extrinsics []subscanExtrinsic `json:"extrinsics"`
+	} `json:"data"`
+}
+
+type subscanExtrinsic struct {
+	BlockNum           int64  `json:"block_num"`
+	BlockTimestamp     int64  `json:"block_timestamp"`
+	ExtrinsicIndex     string `json:"extrinsic_index"`
+	ExtrinsicHash      string `json:"extrinsic_hash"`
+	CallModule         string `json:"call_module"`
+	CallModuleFunction string `json:"call_module_function"`
+	Success            bool   `json:"success"`
+}
+
+// StakingExtrinsics returns the successful Staking.nominate extrinsics signed by the nominator,
+// newest first. Subscan doesn't list call arguments, so they aren't filtered by validator.
+func (s *SubscanProofSource) StakingExtrinsics(ctx context.Context, nominatorAddress, validatorAddress string) ([]StakingExtrinsic, error) {
+	if s.baseURL == "" {
+		return nil, fmt.Errorf("no Subscan URL known for network %s", s.network.Name)
+	}
+
+	// Subscan expects the address in the network's own SS58 format
+	accountID, err := accountIDFromAddress(nominatorAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nominator address: %w", err)
+	}
+	nominator := encodeSS58(s.network.SS58Prefix, accountID)
+
+	body, err := json.Marshal(subscanExtrinsicsRequest{
+		Address:   nominator,
+		Module:    "staking",
+		Call:      "nominate",
+		Row:       subscanPageSize,
+		Success:   true,
+		Finalized: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode Subscan request: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/api/v2/scan/extrinsics", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Subscan request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-API-Key", s.apiKey)
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("Subscan request failed: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Subscan request failed: HTTP %d", response.StatusCode)
+	}
+
+	var decoded subscanExtrinsicsResponse
+	if err := json.NewDecoder(response.Body).Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode Subscan response: %w", err)
+	}
+	if decoded.Code != 0 {
+		return nil, fmt.Errorf("Subscan error %d: %s", decoded.Code, decoded.Message)
+	}
+
+	extrinsics := make([]StakingExtrinsic, 0, len(decoded.Data.Extrinsics))
+	for _, item := range decoded.Data.Extrinsics {
+		if !strings.EqualFold(item.CallModule, "staking") || !strings.EqualFold(item.CallModuleFunction, "nominate") {
+			continue
+		}
+		extrinsics = append(extrinsics, item.stakingExtrinsic(nominator))
+	}
+	return extrinsics, nil
+}
+
+// stakingExtrinsic converts a Subscan listing entry; Subscan identifies extrinsics as "block-index"
+func (e subscanExtrinsic) stakingExtrinsic(signer string) StakingExtrinsic {
+	extrinsicIdx := 0
+	if _, index, ok := strings.Cut(e.ExtrinsicIndex, "-"); ok {
+		extrinsicIdx, _ = strconv.Atoi(index)
+	}
+
+	extrinsic := StakingExtrinsic{
+		ExtrinsicHash: e.ExtrinsicHash,
+		BlockNumber:   fmt.Sprintf("%d", e.BlockNum),
+		ExtrinsicIdx:  extrinsicIdx,
+		Method:        "staking.nominate",
+		Params:        map[string]interface{}{"pallet": "staking", "call": "nominate", "signer": signer},
+		Success:       e.Success,
+	}
+	if e.BlockTimestamp > 0 {
+		extrinsic.Timestamp = time.Unix(e.BlockTimestamp, 0).UTC().Format(time.RFC3339)
+	}
+	return extrinsic
+}
+
+// SetProofSource sets the indexer GetStakingExtrinsics prefers over block scanning; nil restores scanning
+func (v *Verifier) SetProofSource(source ProofSource) {
+	v.proofSource = source
+}
@@ -0,0 +1,88 @@
+package delegation
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSubscanProofSource(t *testing.T) {
+	log.Printf("🧪 Starting TestSubscanProofSource")
+
+	bobAccountID, _ := accountIDFromAddress("5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty")
+	bob := encodeSS58(Polkadot.SS58Prefix, bobAccountID)
+	subscan := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request subscanExtrinsicsRequest
+		json.NewDecoder(r.Body).Decode(&request)
+		if r.URL.Path != "/api/v2/scan/extrinsics" || r.Header.Get("X-API-Key") != "test-key" || request.Address != bob {
+			t.Errorf("Unexpected Subscan request to %s for %s with key %q", r.URL.Path, request.Address, r.Header.Get("X-API-Key"))
+		}
+		w.Write([]byte(`{"code":0,"message":"Success","data":{"count":2,"extrinsics":[
+			{"block_num":18000123,"block_timestamp":1700000000,"extrinsic_index":"18000123-2","extrinsic_hash":"0xaa","call_module":"staking","call_module_function":"nominate","success":true},
+			{"block_num":17000000,"block_timestamp":1690000000,"extrinsic_index":"17000000-4","extrinsic_hash":"0xbb","call_module":"staking","call_module_function":"bond","success":true}
+		]}}`))
+	}))
+	defer subscan.Close()
+
+	var rpcCalls atomic.Int32
+	rpc := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		rpcCalls.Add(1)
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+
+	verifier := NewVerifierWithConfig(VerifierConfig{
+		RPCURL:      rpc.URL,
+		MaxRetries:  -1,
+		ProofSource: NewSubscanProofSource(subscan.URL, "test-key", Polkadot),
+	})
+
+	// An address of another network is converted to the network's SS58 form for Subscan
+	extrinsics, err := verifier.GetStakingExtrinsics("5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty", encodeSS58(Polkadot.SS58Prefix, aliceAccountID))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(extrinsics) != 1 {
+		t.Fatalf("Expected only the nominate extrinsic, got %+v", extrinsics)
+	}
+	got := extrinsics[0]
+	if got.ExtrinsicHash != "0xaa" || got.BlockNumber != "18000123" || got.ExtrinsicIdx != 2 || got.Method != "staking.nominate" || got.Params["signer"] != bob || got.Timestamp != "2023-11-14T22:13:20Z" {
+		t.Fatalf("Unexpected extrinsic: %+v", got)
+	}
+	if rpcCalls.Load() != 0 {
+		t.Fatalf("Expected no block scan when the indexer answers, got %d RPC calls", rpcCalls.Load())
+	}
+	log.Printf("✅ Nominate extrinsic found on Subscan without scanning: %+v", got)
+}
+
+func TestGetStakingExtrinsics_FallsBackToBlockScan(t *testing.T) {
+	log.Printf("🧪 Starting TestGetStakingExtrinsics_FallsBackToBlockScan")
+
+	bobAccountID, _ := accountIDFromAddress("5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty")
+	subscan := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"code":10004,"message":"API key invalid"}`))
+	}))
+	defer subscan.Close()
+
+	var rpcCalls atomic.Int32
+	rpc := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		rpcCalls.Add(1)
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+
+	verifier := NewVerifierWithConfig(VerifierConfig{
+		RPCURL:      rpc.URL,
+		MaxRetries:  -1,
+		ProofSource: NewSubscanProofSource(subscan.URL, "bad-key", Polkadot),
+	})
+	if _, err := verifier.GetStakingExtrinsicsCtx(context.Background(), encodeSS58(Polkadot.SS58Prefix, bobAccountID), encodeSS58(Polkadot.SS58Prefix, aliceAccountID)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if rpcCalls.Load() == 0 {
+		t.Fatalf("Expected a block scan after the indexer failed")
+	}
+	log.Printf("✅ Fell back to scanning blocks with %d RPC calls", rpcCalls.Load())
+}
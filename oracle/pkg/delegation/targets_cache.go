@@ -0,0 +1,127 @@
+package delegation
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync"
+)
+
+// defaultTargetsCacheSize bounds the number of nominators cached per finalized block
+const defaultTargetsCacheSize = 1024
+
+// targetsCache holds decoded nominations for the current finalized block.
+// A nominator's nominations can't change within a block, so entries are keyed on
+// (nominator, finalizedBlockHash) and the whole cache is dropped when the finalized head advances.
+type targetsCache struct {
+	mu        sync.Mutex
+	blockHash string
+	entries   map[string]*Nominations
+	order     []string
+	maxSize   int
+}
+
+// newTargetsCache creates a cache holding at most maxSize nominators
+func newTargetsCache(maxSize int) *targetsCache {
+	return &targetsCache{
+		entries: make(map[string]*Nominations),
+		maxSize: maxSize,
+	}
+}
+
+// get returns the cached nominations of a nominator at the given finalized block.
+// A nil value with ok set means the nominator had no nominations.
+func (c *targetsCache) get(nominator, blockHash string) (*Nominations, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if blockHash != c.blockHash {
+		return nil, false
+	}
+	nominations, ok := c.entries[nominator]
+	return nominations, ok
+}
+
+// put stores the nominations of a nominator at the given finalized block, invalidating
+// every entry from an older block and evicting the oldest entry when full
+func (c *targetsCache) put(nominator, blockHash string, nominations *Nominations) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if blockHash != c.blockHash {
+		c.blockHash = blockHash
+		c.entries = make(map[string]*Nominations)
+		c.order = nil
+	}
+
+	if _, exists := c.entries[nominator]; !exists {
+		if len(c.order) >= c.maxSize {
+			oldest := c.order[0]
+			c.order = c.order[1:]
+			delete(c.entries, oldest)
+		}
+		c.order = append(c.order, nominator)
+	}
+	c.entries[nominator] = nominations
+}
+
+// getFinalizedHead returns the hash of the latest finalized block
+func (v *Verifier) getFinalizedHead(ctx context.Context) (string, error) {
+	request := RPCRequest{
+		JSONRPC: "2.0",
+		Method:  "chain_getFinalizedHead",
+		Params:  []interface{}{},
+		ID:      1,
+	}
+
+	result, err := v.makeRPCCallCtx(ctx, request)
+	if err != nil {
+		return "", fmt.Errorf("failed to get finalized head: %w", err)
+	}
+
+	blockHash, ok := result.(string)
+	if !ok {
+		return "", fmt.Errorf("invalid finalized head response")
+	}
+	return blockHash, nil
+}
+
+// getNominations returns the decoded Staking.Nominators entry of a nominator at the finalized head,
+// or at the pinned block, or nil when the account has no nominations. Results are served from
+// the targets cache while the block is unchanged.
+func (v *Verifier) getNominations(ctx context.Context, nominatorAccountID []byte) (*Nominations, error) {
+	nominations, _, err := v.getNominationsWithBlock(ctx, nominatorAccountID)
+	return nominations, err
+}
+
+// getNominationsWithBlock is getNominations also returning the hash of the block the entry was read at
+func (v *Verifier) getNominationsWithBlock(ctx context.Context, nominatorAccountID []byte) (*Nominations, string, error) {
+	blockHash, err := v.storageBlock(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nominator := hex.EncodeToString(nominatorAccountID)
+	if nominations, ok := v.targetsCache.get(nominator, blockHash); ok {
+		v.log().DebugContext(ctx, "using cached nominations", "event", "targets_cache_hit", "block_hash", blockHash)
+		recordDecoded(ctx, "nominations", nominations)
+		return nominations, blockHash, nil
+	}
+
+	raw, err := v.getStorageAt(ctx, nominatorsStorageKey(nominatorAccountID), blockHash)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query nominations: %w", err)
+	}
+
+	var nominations *Nominations
+	if raw != nil {
+		nominations, err = decodeNominations(raw)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	v.targetsCache.put(nominator, blockHash, nominations)
+	recordDecoded(ctx, "nominations", nominations)
+	return nominations, blockHash, nil
+}
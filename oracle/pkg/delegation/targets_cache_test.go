@@ -0,0 +1,91 @@
+package delegation
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"log"
+	"sync"
+	"testing"
+)
+
+func TestGetNominationTargets_CachedWithinBlock(t *testing.T) {
+	log.Printf("🧪 Starting TestGetNominationTargets_CachedWithinBlock")
+
+	nominator := bytes.Repeat([]byte{0x01}, 32)
+	target := bytes.Repeat([]byte{0x02}, 32)
+	nominations := nominationsHex([][]byte{target}, 1000, false)
+
+	var mu sync.Mutex
+	finalizedHead := "0x" + hex.EncodeToString(bytes.Repeat([]byte{0xaa}, 32))
+	storageCalls := 0
+
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch method {
+		case "chain_getFinalizedHead":
+			return finalizedHead, nil
+		case "state_getStorage":
+			storageCalls++
+			if params[0] != nominatorsStorageKey(nominator) || params[1] != finalizedHead {
+				return nil, &RPCError{Code: -32000, Message: "unexpected storage query"}
+			}
+			return nominations, nil
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+	verifier := NewVerifier(server.URL)
+
+	for i := 0; i < 2; i++ {
+		nominations, err := verifier.getNominations(context.Background(), nominator)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if nominations == nil || len(nominations.Targets) != 1 || !bytes.Equal(nominations.Targets[0], target) {
+			t.Fatalf("Unexpected nominations: %+v", nominations)
+		}
+	}
+
+	if storageCalls != 1 {
+		t.Fatalf("Expected 1 storage call within the same block, got %d", storageCalls)
+	}
+	log.Printf("✅ Second call within the block served from cache")
+
+	// Advancing the finalized head invalidates the cache
+	mu.Lock()
+	finalizedHead = "0x" + hex.EncodeToString(bytes.Repeat([]byte{0xbb}, 32))
+	mu.Unlock()
+
+	if _, err := verifier.getNominations(context.Background(), nominator); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if storageCalls != 2 {
+		t.Fatalf("Expected a new storage call after the finalized head advanced, got %d calls", storageCalls)
+	}
+	log.Printf("✅ Cache invalidated when the finalized head advanced")
+}
+
+func TestTargetsCache_Bounded(t *testing.T) {
+	log.Printf("🧪 Starting TestTargetsCache_Bounded")
+
+	cache := newTargetsCache(2)
+	cache.put("a", "0x01", nil)
+	cache.put("b", "0x01", nil)
+	cache.put("c", "0x01", nil)
+
+	if _, ok := cache.get("a", "0x01"); ok {
+		t.Fatalf("Expected oldest entry to be evicted")
+	}
+	for _, nominator := range []string{"b", "c"} {
+		if _, ok := cache.get(nominator, "0x01"); !ok {
+			t.Fatalf("Expected %s to remain cached", nominator)
+		}
+	}
+	if _, ok := cache.get("c", "0x02"); ok {
+		t.Fatalf("Expected entries from another block to miss")
+	}
+
+	log.Printf("✅ Cache stays within its bound")
+}
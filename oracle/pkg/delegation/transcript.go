@@ -0,0 +1,145 @@
+package delegation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// TranscriptRPCCall records one RPC round trip: the request and a hash of its response
+type TranscriptRPCCall struct {
+	Method       string      `json:"method"`
+	Params       interface{} `json:"params"`
+	ResponseHash string      `json:"responseHash,omitempty"`
+	Error        string      `json:"error,omitempty"`
+}
+
+// TranscriptValue records a value decoded from chain state during verification
+type TranscriptValue struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+// Transcript is a canonical record of a verification: its inputs, every RPC call with a hash
+// of the response, the decoded values, the outcome and the final signature. It lets a decision
+// be replayed and audited offline. A Transcript is safe for concurrent use.
+type Transcript struct {
+	mu        sync.Mutex
+	Inputs    map[string]string             `json:"inputs"`
+	RPCCalls  []TranscriptRPCCall           `json:"rpcCalls"`
+	Decoded   []TranscriptValue             `json:"decoded"`
+	Result    *DelegationVerificationResult `json:"result,omitempty"`
+	Signature string                        `json:"signature,omitempty"`
+}
+
+// NewTranscript starts an empty transcript
+func NewTranscript() *Transcript {
+	return &Transcript{Inputs: make(map[string]string)}
+}
+
+type transcriptContextKey struct{}
+
+// WithTranscript returns a context that records every verification step run under it into t
+func WithTranscript(ctx context.Context, t *Transcript) context.Context {
+	return context.WithValue(ctx, transcriptContextKey{}, t)
+}
+
+// transcriptFromContext returns the transcript attached to ctx, or nil
+func transcriptFromContext(ctx context.Context) *Transcript {
+	t, _ := ctx.Value(transcriptContextKey{}).(*Transcript)
+	return t
+}
+
+// hashRPCResult returns the SHA-256 of a result's canonical JSON encoding (object keys sorted)
+func hashRPCResult(result interface{}) string {
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return ""
+	}
+	digest := sha256.Sum256(encoded)
+	return hex.EncodeToString(digest[:])
+}
+
+// recordRPCCall appends an RPC round trip to the transcript in ctx, if any
+func recordRPCCall(ctx context.Context, request RPCRequest, result interface{}, err error) {
+	t := transcriptFromContext(ctx)
+	if t == nil {
+		return
+	}
+
+	call := TranscriptRPCCall{Method: request.Method, Params: request.Params}
+	if err != nil {
+		call.Error = err.Error()
+	} else {
+		call.ResponseHash = hashRPCResult(result)
+	}
+
+	t.mu.Lock()
+	t.RPCCalls = append(t.RPCCalls, call)
+	t.mu.Unlock()
+}
+
+// recordDecoded appends a decoded value to the transcript in ctx, if any
+func recordDecoded(ctx context.Context, name string, value interface{}) {
+	t := transcriptFromContext(ctx)
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	t.Decoded = append(t.Decoded, TranscriptValue{Name: name, Value: value})
+	t.mu.Unlock()
+}
+
+// SetInput records a verification input
+func (t *Transcript) SetInput(name, value string) {
+	t.mu.Lock()
+	t.Inputs[name] = value
+	t.mu.Unlock()
+}
+
+// SetResult records the verification outcome
+func (t *Transcript) SetResult(result *DelegationVerificationResult) {
+	t.mu.Lock()
+	t.Result = result
+	t.mu.Unlock()
+}
+
+// SetSignature records the signature issued for the verified delegation
+func (t *Transcript) SetSignature(signature string) {
+	t.mu.Lock()
+	t.Signature = signature
+	t.mu.Unlock()
+}
+
+// MarshalJSON encodes the transcript under its lock
+func (t *Transcript) MarshalJSON() ([]byte, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	type transcript Transcript
+	return json.Marshal((*transcript)(t))
+}
+
+// Persist writes the transcript as JSON to dir, naming the file after its timestamp and content hash
+func (t *Transcript) Persist(dir string) (string, error) {
+	encoded, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode transcript: %w", err)
+	}
+
+	digest := sha256.Sum256(encoded)
+	name := fmt.Sprintf("transcript-%d-%s.json", time.Now().UTC().Unix(), hex.EncodeToString(digest[:4]))
+	path := filepath.Join(dir, name)
+
+	if err := os.WriteFile(path, encoded, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write transcript: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,127 @@
+package delegation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"os"
+	"testing"
+)
+
+func TestVerifyDelegationCtx_RecordsTranscript(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyDelegationCtx_RecordsTranscript")
+
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		switch method {
+		case "chain_getFinalizedHead":
+			return testBlockHash, nil
+		case "state_getStorage":
+			if len(params) > 1 {
+				// Bob nominates Alice at the finalized head
+				return nominationsHex([][]byte{aliceAccountID}, 1, false), nil
+			}
+			return activeEraHex(1, 0), nil
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+	verifier := NewVerifier(server.URL)
+
+	nominator := "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty"
+	validator := "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+
+	transcript := NewTranscript()
+	ok, err := verifier.VerifyDelegationCtx(WithTranscript(context.Background(), transcript), nominator, validator)
+	if err != nil || !ok {
+		t.Fatalf("Expected delegation to verify, got %v, %v", ok, err)
+	}
+
+	if transcript.Inputs["nominator"] != nominator || transcript.Inputs["validator"] != validator {
+		t.Fatalf("Expected inputs to be recorded, got %v", transcript.Inputs)
+	}
+	log.Printf("✅ Inputs recorded: %v", transcript.Inputs)
+
+	// Nominations are read once at the finalized head and served from cache afterwards
+	expectedMethods := []string{"state_getStorage", "chain_getFinalizedHead", "state_getStorage", "state_getStorage", "chain_getFinalizedHead"}
+	if len(transcript.RPCCalls) != len(expectedMethods) {
+		t.Fatalf("Expected %d RPC calls, got %+v", len(expectedMethods), transcript.RPCCalls)
+	}
+	for i, method := range expectedMethods {
+		if transcript.RPCCalls[i].Method != method {
+			t.Errorf("RPC call %d: expected %s, got %s", i, method, transcript.RPCCalls[i].Method)
+		}
+	}
+
+	digest := sha256.Sum256([]byte(`"` + activeEraHex(1, 0) + `"`))
+	if transcript.RPCCalls[0].ResponseHash != hex.EncodeToString(digest[:]) {
+		t.Errorf("Expected response hash %x, got %s", digest, transcript.RPCCalls[0].ResponseHash)
+	}
+	log.Printf("✅ %d RPC calls recorded with response hashes", len(transcript.RPCCalls))
+
+	var decoded []string
+	for _, value := range transcript.Decoded {
+		decoded = append(decoded, value.Name)
+	}
+	expectedDecoded := []string{"activeEra", "nominations", "isNominated", "activeEra", "nominations", "isActive"}
+	if len(decoded) != len(expectedDecoded) {
+		t.Fatalf("Expected decoded values %v, got %v", expectedDecoded, decoded)
+	}
+	for i, name := range expectedDecoded {
+		if decoded[i] != name {
+			t.Errorf("Decoded value %d: expected %s, got %s", i, name, decoded[i])
+		}
+	}
+	log.Printf("✅ Decoded values recorded: %v", decoded)
+}
+
+func TestVerifyV2_RecordsTranscriptResult(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyV2_RecordsTranscriptResult")
+
+	verifier := NewVerifier("http://127.0.0.1:0")
+
+	// Invalid addresses end verification before any RPC call
+	transcript := NewTranscript()
+	result, err := verifier.VerifyV2WithProgress(WithTranscript(context.Background(), transcript), "bad", "addresses", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if transcript.Result != result {
+		t.Fatalf("Expected transcript to hold the verification result")
+	}
+	if len(transcript.RPCCalls) != 0 {
+		t.Fatalf("Expected no RPC calls, got %+v", transcript.RPCCalls)
+	}
+	log.Printf("✅ Result recorded: %s", transcript.Result.Error)
+}
+
+func TestTranscript_Persist(t *testing.T) {
+	log.Printf("🧪 Starting TestTranscript_Persist")
+
+	transcript := NewTranscript()
+	transcript.SetInput("nominator", "alice")
+	transcript.SetSignature("0xdeadbeef")
+
+	path, err := transcript.Persist(t.TempDir())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read persisted transcript: %v", err)
+	}
+
+	var persisted struct {
+		Inputs    map[string]string `json:"inputs"`
+		Signature string            `json:"signature"`
+	}
+	if err := json.Unmarshal(raw, &persisted); err != nil {
+		t.Fatalf("Failed to decode persisted transcript: %v", err)
+	}
+	if persisted.Inputs["nominator"] != "alice" || persisted.Signature != "0xdeadbeef" {
+		t.Fatalf("Unexpected persisted transcript: %s", raw)
+	}
+	log.Printf("✅ Transcript persisted to %s", path)
+}
@@ -0,0 +1,332 @@
+package delegation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// rpcTransport carries a single JSON-RPC request to the node and returns its response.
+// Failures worth retrying are returned as *transientRPCError.
+type rpcTransport interface {
+	roundTrip(ctx context.Context, request RPCRequest) (*RPCResponse, error)
+	// roundTripBatch sends requests with distinct IDs together; responses may come back in any order
+	roundTripBatch(ctx context.Context, requests []RPCRequest) ([]RPCResponse, error)
+	// endpoint is the URL requests are currently sent to
+	endpoint() string
+	close() error
+}
+
+// HTTP connection pool defaults. Go's default transport keeps only two idle connections per host,
+// so concurrent verifications against one endpoint would keep opening fresh TCP+TLS connections.
+const (
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 32
+	DefaultIdleConnTimeout     = 90 * time.Second
+)
+
+// newRPCTransport picks the transport for cfg's endpoint URL: WebSocket for ws:// and wss://, HTTP
+// otherwise. A comma-separated list of URLs gets one transport per endpoint behind a failover.
+func newRPCTransport(cfg VerifierConfig) rpcTransport {
+	urls := splitRPCURLs(cfg.RPCURL)
+	if len(urls) == 1 {
+		return newEndpointTransport(cfg, urls[0])
+	}
+
+	endpoints := make([]rpcTransport, len(urls))
+	for i, url := range urls {
+		endpoints[i] = newEndpointTransport(cfg, url)
+	}
+	return newFailoverTransport(endpoints)
+}
+
+// newEndpointTransport creates the transport for a single endpoint URL
+func newEndpointTransport(cfg VerifierConfig, url string) rpcTransport {
+	if strings.HasPrefix(url, "ws://") || strings.HasPrefix(url, "wss://") {
+		return newWSTransport(url, cfg.Timeout)
+	}
+	return &httpTransport{url: url, client: newHTTPClient(cfg)}
+}
+
+// splitRPCURLs splits a comma-separated list of endpoint URLs, dropping blanks
+func splitRPCURLs(list string) []string {
+	var urls []string
+	for _, url := range strings.Split(list, ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			urls = append(urls, url)
+		}
+	}
+	if len(urls) == 0 {
+		return []string{list}
+	}
+	return urls
+}
+
+// newHTTPClient creates an HTTP client whose keep-alive connections are pooled per cfg
+func newHTTPClient(cfg VerifierConfig) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DisableKeepAlives = false
+	transport.MaxIdleConns = cfg.MaxIdleConns
+	transport.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	transport.IdleConnTimeout = cfg.IdleConnTimeout
+	return &http.Client{Timeout: cfg.Timeout, Transport: transport}
+}
+
+// httpTransport posts each request to the endpoint over HTTP
+type httpTransport struct {
+	url    string
+	client *http.Client
+}
+
+func (t *httpTransport) roundTrip(ctx context.Context, request RPCRequest) (*RPCResponse, error) {
+	var response RPCResponse
+	if err := t.post(ctx, request, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// roundTripBatch posts all requests as one JSON-RPC 2.0 batch array
+func (t *httpTransport) roundTripBatch(ctx context.Context, requests []RPCRequest) ([]RPCResponse, error) {
+	var responses []RPCResponse
+	if err := t.post(ctx, requests, &responses); err != nil {
+		return nil, err
+	}
+	return responses, nil
+}
+
+// post sends payload as JSON and decodes the JSON response into out
+func (t *httpTransport) post(ctx context.Context, payload interface{}, out interface{}) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(httpReq)
+	if err != nil {
+		return &transientRPCError{fmt.Errorf("failed to make RPC call: %w", err)}
+	}
+	defer resp.Body.Close()
+
+	if isTransientStatus(resp.StatusCode) {
+		// Drain the body so the connection goes back to the pool
+		io.Copy(io.Discard, resp.Body)
+		return &transientRPCError{fmt.Errorf("RPC endpoint returned HTTP %d", resp.StatusCode)}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return nil
+}
+
+func (t *httpTransport) endpoint() string {
+	return t.url
+}
+
+func (t *httpTransport) close() error {
+	t.client.CloseIdleConnections()
+	return nil
+}
+
+// wsTransport multiplexes requests over one persistent WebSocket connection, dialling it on
+// first use and again after it drops. Requests are renumbered so concurrent callers that reuse
+// the same ID still get their own response.
+type wsTransport struct {
+	url     string
+	timeout time.Duration
+	dialer  *websocket.Dialer
+
+	mu      sync.Mutex
+	conn    *websocket.Conn
+	nextID  int
+	pending map[int]chan wsResult
+
+	writeMu sync.Mutex // gorilla/websocket allows one concurrent writer
+}
+
+// wsResult is a response, or the error that ended the connection, delivered to a waiting caller
+type wsResult struct {
+	response *RPCResponse
+	err      error
+}
+
+func newWSTransport(rpcURL string, timeout time.Duration) *wsTransport {
+	return &wsTransport{
+		url:     rpcURL,
+		timeout: timeout,
+		dialer:  &websocket.Dialer{HandshakeTimeout: timeout},
+		pending: make(map[int]chan wsResult),
+	}
+}
+
+// connect returns the open connection, dialling a new one if there is none
+func (t *wsTransport) connect(ctx context.Context) (*websocket.Conn, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.conn != nil {
+		return t.conn, nil
+	}
+
+	conn, _, err := t.dialer.DialContext(ctx, t.url, nil)
+	if err != nil {
+		return nil, &transientRPCError{fmt.Errorf("failed to connect to %s: %w", t.url, err)}
+	}
+	t.conn = conn
+	go t.readLoop(conn)
+
+	return conn, nil
+}
+
+// readLoop delivers responses to their callers until the connection fails, then fails every
+// request still waiting on it so the next call reconnects
+func (t *wsTransport) readLoop(conn *websocket.Conn) {
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			t.drop(conn, &transientRPCError{fmt.Errorf("websocket connection lost: %w", err)})
+			return
+		}
+
+		var response RPCResponse
+		if err := json.Unmarshal(data, &response); err != nil {
+			continue // not a JSON-RPC response, e.g. a subscription notification
+		}
+
+		t.mu.Lock()
+		waiter, ok := t.pending[response.ID]
+		delete(t.pending, response.ID)
+		t.mu.Unlock()
+
+		if ok {
+			waiter <- wsResult{response: &response}
+		}
+	}
+}
+
+// drop discards a broken connection and fails the requests waiting on it
+func (t *wsTransport) drop(conn *websocket.Conn, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.conn != conn {
+		return
+	}
+	conn.Close()
+	t.conn = nil
+
+	for id, waiter := range t.pending {
+		waiter <- wsResult{err: err}
+		delete(t.pending, id)
+	}
+}
+
+func (t *wsTransport) roundTrip(ctx context.Context, request RPCRequest) (*RPCResponse, error) {
+	if t.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+		defer cancel()
+	}
+
+	conn, err := t.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	waiter := make(chan wsResult, 1)
+	t.mu.Lock()
+	t.nextID++
+	id := t.nextID
+	t.pending[id] = waiter
+	t.mu.Unlock()
+
+	callerID := request.ID
+	request.ID = id
+
+	t.writeMu.Lock()
+	err = conn.WriteJSON(request)
+	t.writeMu.Unlock()
+	if err != nil {
+		t.drop(conn, &transientRPCError{fmt.Errorf("websocket write failed: %w", err)})
+		return nil, &transientRPCError{fmt.Errorf("failed to send RPC request: %w", err)}
+	}
+
+	select {
+	case result := <-waiter:
+		if result.err != nil {
+			return nil, result.err
+		}
+		result.response.ID = callerID
+		return result.response, nil
+	case <-ctx.Done():
+		t.mu.Lock()
+		delete(t.pending, id)
+		t.mu.Unlock()
+		return nil, fmt.Errorf("failed to make RPC call: %w", ctx.Err())
+	}
+}
+
+// roundTripBatch sends the requests concurrently over the shared connection, which already
+// multiplexes them, rather than as a batch array
+func (t *wsTransport) roundTripBatch(ctx context.Context, requests []RPCRequest) ([]RPCResponse, error) {
+	responses := make([]RPCResponse, len(requests))
+	errs := make([]error, len(requests))
+
+	var wg sync.WaitGroup
+	for i, request := range requests {
+		wg.Add(1)
+		go func(i int, request RPCRequest) {
+			defer wg.Done()
+			response, err := t.roundTrip(ctx, request)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			responses[i] = *response
+		}(i, request)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return responses, nil
+}
+
+func (t *wsTransport) endpoint() string {
+	return t.url
+}
+
+func (t *wsTransport) close() error {
+	t.mu.Lock()
+	conn := t.conn
+	t.mu.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+	t.drop(conn, fmt.Errorf("transport closed"))
+	return nil
+}
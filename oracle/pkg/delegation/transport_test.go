@@ -0,0 +1,178 @@
+package delegation
+
+import (
+	"context"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// newMockWSServer starts a WebSocket JSON-RPC server that answers with handler.
+// When dropAfter is positive, each connection is closed after that many responses.
+func newMockWSServer(t *testing.T, handler mockRPCHandler, dropAfter int, connections *atomic.Int32) string {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Upgrade failed: %v", err)
+			return
+		}
+		defer conn.Close()
+		connections.Add(1)
+
+		for served := 0; dropAfter <= 0 || served < dropAfter; served++ {
+			var request struct {
+				Method string        `json:"method"`
+				Params []interface{} `json:"params"`
+				ID     int           `json:"id"`
+			}
+			if err := conn.ReadJSON(&request); err != nil {
+				return
+			}
+
+			result, rpcErr := handler(request.Method, request.Params)
+			if err := conn.WriteJSON(RPCResponse{JSONRPC: "2.0", Result: result, Error: rpcErr, ID: request.ID}); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+// wsTestHandler answers the state and chain queries made over WebSocket
+func wsTestHandler(method string, params []interface{}) (interface{}, *RPCError) {
+	switch method {
+	case "state_getStorage":
+		return "0x01", nil
+	case "chain_getBlockHash":
+		return testBlockHash, nil
+	case "chain_getBlock":
+		return mockBlock(), nil
+	}
+	return nil, &RPCError{Code: -32601, Message: "method not found"}
+}
+
+func TestWebSocketTransport_ReusesConnection(t *testing.T) {
+	log.Printf("🧪 Starting TestWebSocketTransport_ReusesConnection")
+
+	var connections atomic.Int32
+	verifier := NewVerifier(newMockWSServer(t, wsTestHandler, 0, &connections), 2*time.Second)
+	defer verifier.Close()
+
+	requests := []RPCRequest{
+		{JSONRPC: "2.0", Method: "state_getStorage", Params: []interface{}{activeEraStorageKey()}, ID: 1},
+		{JSONRPC: "2.0", Method: "chain_getBlockHash", Params: []interface{}{16}, ID: 1},
+		{JSONRPC: "2.0", Method: "chain_getBlock", Params: []interface{}{testBlockHash}, ID: 1},
+	}
+	for _, request := range requests {
+		result, err := verifier.makeRPCCall(request)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got: %v", request.Method, err)
+		}
+		log.Printf("✅ %s over WebSocket: %v", request.Method, result)
+	}
+
+	if _, err := verifier.makeRPCCall(RPCRequest{JSONRPC: "2.0", Method: "unknown_method", ID: 1}); err == nil || !strings.Contains(err.Error(), "method not found") {
+		t.Fatalf("Expected JSON-RPC error over WebSocket, got: %v", err)
+	}
+
+	if connections.Load() != 1 {
+		t.Fatalf("Expected a single reused connection, got %d", connections.Load())
+	}
+	log.Printf("✅ All calls shared one connection")
+}
+
+func TestWebSocketTransport_Reconnects(t *testing.T) {
+	log.Printf("🧪 Starting TestWebSocketTransport_Reconnects")
+
+	var connections atomic.Int32
+	verifier := NewVerifier(newMockWSServer(t, wsTestHandler, 1, &connections), 2*time.Second)
+	verifier.SetRetryBackoff(time.Millisecond)
+	defer verifier.Close()
+
+	for i := 0; i < 3; i++ {
+		if _, err := verifier.makeRPCCall(RPCRequest{JSONRPC: "2.0", Method: "state_getStorage", Params: []interface{}{"0x00"}, ID: 1}); err != nil {
+			t.Fatalf("Call %d: expected no error, got: %v", i, err)
+		}
+	}
+
+	if connections.Load() < 2 {
+		t.Fatalf("Expected the dropped connection to be re-established, got %d connections", connections.Load())
+	}
+	log.Printf("✅ Reconnected after drops: %d connections", connections.Load())
+}
+
+// benchmarkHTTPTransport issues b.N RPC calls through client, concurrently when parallel is set,
+// and reports how many TCP connections the endpoint had to accept
+func benchmarkHTTPTransport(b *testing.B, client *http.Client, parallel bool) {
+	var connections atomic.Int64
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","result":"0x1","id":1}`))
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	transport := &httpTransport{url: server.URL, client: client}
+	request := RPCRequest{JSONRPC: "2.0", Method: "chain_getHeader", Params: []interface{}{}, ID: 1}
+
+	b.ResetTimer()
+	if parallel {
+		b.SetParallelism(8)
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				if _, err := transport.roundTrip(context.Background(), request); err != nil {
+					b.Error(err)
+					return
+				}
+			}
+		})
+	} else {
+		for i := 0; i < b.N; i++ {
+			if _, err := transport.roundTrip(context.Background(), request); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	b.ReportMetric(float64(connections.Load()), "conns")
+}
+
+// BenchmarkHTTPTransport compares the pooled client with the untuned http.Client it replaced.
+// Run with -benchtime=2000x; the conns metric shows how often each had to dial.
+func BenchmarkHTTPTransport(b *testing.B) {
+	tuned := VerifierConfig{
+		Timeout:             DefaultRPCTimeout,
+		MaxIdleConns:        DefaultMaxIdleConns,
+		MaxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
+		IdleConnTimeout:     DefaultIdleConnTimeout,
+	}
+
+	for _, parallel := range []bool{false, true} {
+		mode := "sequential"
+		if parallel {
+			mode = "parallel"
+		}
+		b.Run(mode+"/default", func(b *testing.B) {
+			benchmarkHTTPTransport(b, &http.Client{Timeout: DefaultRPCTimeout}, parallel)
+		})
+		b.Run(mode+"/tuned", func(b *testing.B) {
+			benchmarkHTTPTransport(b, newHTTPClient(tuned), parallel)
+		})
+	}
+}
@@ -2,15 +2,27 @@ package delegation
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
-	"io"
-	"log"
-	"net/http"
+	"log/slog"
+	"math"
+	"math/big"
+	"strconv"
 	"strings"
 	"time"
+
+	"golang.org/x/crypto/blake2b"
 )
 
+// ErrNoNominationExtrinsic is returned when a block contains no nomination extrinsic
+var ErrNoNominationExtrinsic = errors.New("no nomination extrinsic found in block")
+
+// ErrNominationMismatch is returned when a block's nomination extrinsics were all signed by
+// other accounts or target other validators
+var ErrNominationMismatch = errors.New("no nomination extrinsic in block by the nominator for the validator")
+
 // RPCRequest represents a Polkadot RPC request
 type RPCRequest struct {
 	JSONRPC string      `json:"jsonrpc"`
@@ -56,42 +68,103 @@ type StakingExtrinsic struct {
 	Timestamp     string                 `json:"timestamp,omitempty"`
 }
 
-// Verifier handles Polkadot delegation verification via HTTP RPC
+// Verifier handles Polkadot delegation verification via HTTP or WebSocket RPC
 type Verifier struct {
-	rpcURL string
-	client *http.Client
+	rpcURL       string
+	transport    rpcTransport
+	includePayee bool
+	targetsCache *targetsCache
+	stats        *rpcStats
+	metrics      *rpcMetrics
+	// logger receives structured logs; nil means slog.Default()
+	logger *slog.Logger
+	// maxRPCCallsPerVerify caps the RPC calls a block scan may make; zero means unlimited
+	maxRPCCallsPerVerify int
+	// scanRange and scanMaxResults bound how far back and for how many extrinsics a block scan searches
+	scanRange      int64
+	scanMaxResults int
+	// minBonded is the minimum active bond required by VerifyV2; nil disables the check
+	minBonded *big.Int
+	health    rpcHealth
+	// checkOverSubscribed makes VerifyV2 check the nominator's rank in the validator's exposure
+	checkOverSubscribed  bool
+	maxNominatorRewarded int
+	// maxRetries is how many times a transient RPC failure is retried, starting after retryBackoff
+	maxRetries   int
+	retryBackoff time.Duration
+	// resultCache reuses passing results for the same nominator and validator within its TTL
+	resultCache *resultCache
+	// activeEraCache reuses the decoded active era within its TTL
+	activeEraCache *activeEraCache
+	// proofSource is an indexer GetStakingExtrinsics prefers over block scanning; nil scans blocks
+	proofSource ProofSource
+	// network is the chain addresses must belong to
+	network Network
 }
 
-// NewVerifier creates a new delegation verifier
-func NewVerifier(rpcURL string) *Verifier {
-	return &Verifier{
-		rpcURL: rpcURL,
-		client: &http.Client{},
+// DefaultRPCTimeout bounds each RPC call when NewVerifier is given no timeout
+const DefaultRPCTimeout = 10 * time.Second
+
+// NewVerifier creates a new delegation verifier for an http(s):// or ws(s):// endpoint. An optional timeout bounds each RPC call;
+// it defaults to DefaultRPCTimeout so a hung endpoint can't block verification forever.
+// Every other option takes its default; use NewVerifierWithConfig to set them.
+func NewVerifier(rpcURL string, timeout ...time.Duration) *Verifier {
+	cfg := VerifierConfig{RPCURL: rpcURL}
+	if len(timeout) > 0 {
+		cfg.Timeout = timeout[0]
 	}
+	return NewVerifierWithConfig(cfg)
 }
 
-// makeRPCCall makes a call to the Polkadot RPC endpoint
-func (v *Verifier) makeRPCCall(request RPCRequest) (interface{}, error) {
-	jsonData, err := json.Marshal(request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
+// SetLogger sets the structured logger the verifier reports to; nil restores slog.Default()
+func (v *Verifier) SetLogger(logger *slog.Logger) {
+	v.logger = logger
+}
 
-	resp, err := v.client.Post(v.rpcURL, "application/json", bytes.NewReader(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to make RPC call: %w", err)
+// log returns the verifier's logger
+func (v *Verifier) log() *slog.Logger {
+	if v.logger == nil {
+		return slog.Default()
 	}
-	defer resp.Body.Close()
+	return v.logger
+}
 
-	body, err := io.ReadAll(resp.Body)
+// SetIncludePayee controls whether VerifyV2 also reports the nominator's reward destination
+func (v *Verifier) SetIncludePayee(include bool) {
+	v.includePayee = include
+}
+
+// SetMaxRPCCallsPerVerify caps the number of RPC calls a single block scan may issue.
+// Once the budget is spent the scan stops and returns what it found so far. Zero disables the cap.
+func (v *Verifier) SetMaxRPCCallsPerVerify(limit int) {
+	v.maxRPCCallsPerVerify = limit
+}
+
+// makeRPCCall makes a call to the Polkadot RPC endpoint
+func (v *Verifier) makeRPCCall(request RPCRequest) (interface{}, error) {
+	return v.makeRPCCallCtx(context.Background(), request)
+}
+
+// makeRPCCallCtx makes a call to the Polkadot RPC endpoint, aborting when ctx is cancelled
+func (v *Verifier) makeRPCCallCtx(ctx context.Context, request RPCRequest) (interface{}, error) {
+	start := time.Now()
+	result, err := v.doRPCCallWithRetry(ctx, request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
+		err = &UpstreamError{Method: request.Method, Err: err}
+	}
+	elapsed := time.Since(start)
+	v.metrics.observe(request.Method, elapsed, err)
+	v.stats.record(request.Method, err == nil)
+	recordRPCCall(ctx, request, result, err)
+	v.log().DebugContext(ctx, "RPC call completed", "event", "rpc_call", "method", request.Method, "duration_ms", elapsed.Milliseconds(), "success", err == nil)
+	return result, err
+}
 
-	var response RPCResponse
-	err = json.Unmarshal(body, &response)
+// doRPCCall performs a single JSON-RPC round trip over the configured transport
+func (v *Verifier) doRPCCall(ctx context.Context, request RPCRequest) (interface{}, error) {
+	response, err := v.transport.roundTrip(ctx, request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, err
 	}
 
 	if response.Error != nil {
@@ -101,9 +174,21 @@ func (v *Verifier) makeRPCCall(request RPCRequest) (interface{}, error) {
 	return response.Result, nil
 }
 
+// Close releases the verifier's connection to the RPC endpoint
+func (v *Verifier) Close() error {
+	return v.transport.close()
+}
+
 // getExtrinsicInfo retrieves information about a specific extrinsic by its hash
 func (v *Verifier) getExtrinsicInfo(extrinsicHash string) (*ExtrinsicInfo, error) {
-	log.Printf("🔍 Retrieving extrinsic info for hash: %s", extrinsicHash)
+	return v.findNominationExtrinsic(extrinsicHash, nil)
+}
+
+// findNominationExtrinsic retrieves the first Staking.nominate extrinsic in a block that match
+// accepts, or the first of any when match is nil. A block whose nominations match rejects
+// returns ErrNominationMismatch.
+func (v *Verifier) findNominationExtrinsic(extrinsicHash string, match func(extrinsic *DecodedExtrinsic, targets [][]byte) bool) (*ExtrinsicInfo, error) {
+	v.log().Debug("retrieving extrinsic info", "event", "extrinsic_lookup", "block_hash", extrinsicHash)
 
 	request := RPCRequest{
 		JSONRPC: "2.0",
@@ -120,24 +205,32 @@ func (v *Verifier) getExtrinsicInfo(extrinsicHash string) (*ExtrinsicInfo, error
 	}
 
 	// Parse the result to extract extrinsic information
+	mismatched := 0
 	if resultMap, ok := result.(map[string]interface{}); ok {
 		if block, ok := resultMap["block"].(map[string]interface{}); ok {
 			if extrinsics, ok := block["extrinsics"].([]interface{}); ok {
 				// For now, we'll look at the first extrinsic in the block
 				// In a more sophisticated implementation, you'd find the specific extrinsic
 				if len(extrinsics) > 0 {
-					log.Printf("📋 Found %d extrinsics in block", len(extrinsics))
+					v.log().Debug("found extrinsics in block", "event", "extrinsic_lookup", "block_hash", extrinsicHash, "extrinsics", len(extrinsics))
 
 					// Try to decode the extrinsic to check if it's a nomination
 					for i, extrinsic := range extrinsics {
-						log.Printf("🔍 Examining extrinsic %d: %v", i, extrinsic)
-
-						// Check if this extrinsic contains nomination information
-						if v.isNominationExtrinsic(extrinsic) {
-							log.Printf("✅ Found nomination extrinsic at index %d", i)
+						v.log().Debug("examining extrinsic", "event", "extrinsic_lookup", "index", i, "extrinsic", fmt.Sprintf("%v", extrinsic))
+
+						// Check if this extrinsic is a Staking.nominate call
+						decoded, targets, ok := v.decodeNomination(extrinsic)
+						if ok && match != nil && !match(decoded, targets) {
+							v.log().Debug("skipping nomination extrinsic of another nominator or validator", "event", "extrinsic_lookup", "block_hash", extrinsicHash, "index", i)
+							mismatched++
+							continue
+						}
+						if ok {
+							v.log().Debug("found nomination extrinsic", "event", "extrinsic_lookup", "block_hash", extrinsicHash, "index", i, "targets", len(targets))
 							return &ExtrinsicInfo{
 								BlockHash:    extrinsicHash,
 								ExtrinsicIdx: i,
+								Method:       v.nominationMethod(decoded, targets),
 								Success:      true, // Assume success for now
 							}, nil
 						}
@@ -147,269 +240,362 @@ func (v *Verifier) getExtrinsicInfo(extrinsicHash string) (*ExtrinsicInfo, error
 		}
 	}
 
-	log.Printf("⚠️  Could not find nomination extrinsic in block")
-	return nil, fmt.Errorf("no nomination extrinsic found in block")
+	if mismatched > 0 {
+		return nil, ErrNominationMismatch
+	}
+	v.log().Warn("no nomination extrinsic in block", "event", "extrinsic_lookup", "block_hash", extrinsicHash)
+	return nil, ErrNoNominationExtrinsic
 }
 
-// isNominationExtrinsic checks if an extrinsic is related to nomination/delegation
-func (v *Verifier) isNominationExtrinsic(extrinsic interface{}) bool {
-	// This is a simplified check - in a real implementation, you would:
-	// 1. Decode the extrinsic properly
-	// 2. Check if it's a Staking.nominate call
-	// 3. Extract the nominator and validator addresses
-
-	extrinsicStr := fmt.Sprintf("%v", extrinsic)
+// decodeNomination decodes a hex-encoded extrinsic and, when it's a Staking.nominate call,
+// returns it along with its targets. Extrinsics that don't decode are not nominations.
+func (v *Verifier) decodeNomination(extrinsic interface{}) (*DecodedExtrinsic, [][]byte, bool) {
+	decoded, err := decodeExtrinsicHex(extrinsic)
+	if err != nil {
+		v.log().Debug("skipping undecodable extrinsic", "event", "extrinsic_lookup", "error", err)
+		return nil, nil, false
+	}
+	if !v.isNominateCall(decoded) {
+		return nil, nil, false
+	}
 
-	// Look for common patterns in nomination extrinsics
-	nominationPatterns := []string{
-		"nominate",
-		"staking",
-		"delegate",
-		"bond",
+	targets, err := decoded.nominateTargets()
+	if err != nil {
+		v.log().Debug("skipping malformed nominate call", "event", "extrinsic_lookup", "error", err)
+		return nil, nil, false
 	}
+	return decoded, targets, true
+}
 
-	for _, pattern := range nominationPatterns {
-		if strings.Contains(strings.ToLower(extrinsicStr), pattern) {
-			log.Printf("🔍 Found nomination pattern '%s' in extrinsic", pattern)
-			return true
-		}
+// nominationMethod describes a decoded Staking.nominate call, with accounts SS58-encoded for the network
+func (v *Verifier) nominationMethod(extrinsic *DecodedExtrinsic, targets [][]byte) map[string]interface{} {
+	encoded := make([]string, 0, len(targets))
+	for _, target := range targets {
+		encoded = append(encoded, encodeSS58(v.network.SS58Prefix, target))
 	}
 
-	return false
+	method := map[string]interface{}{
+		"pallet":  "staking",
+		"call":    "nominate",
+		"targets": encoded,
+	}
+	if extrinsic.Signer != nil {
+		method["signer"] = encodeSS58(v.network.SS58Prefix, extrinsic.Signer)
+	}
+	return method
 }
 
 // verifyDelegationByExtrinsic verifies delegation using a specific extrinsic hash
 func (v *Verifier) verifyDelegationByExtrinsic(extrinsicHash, nominatorAddress, validatorAddress string) (bool, error) {
-	log.Printf("🔍 Verifying delegation using extrinsic hash: %s", extrinsicHash)
-	log.Printf("   Nominator: %s", nominatorAddress)
-	log.Printf("   Validator: %s", validatorAddress)
+	v.log().Debug("verifying delegation by extrinsic", "event", "extrinsic_verify", "block_hash", extrinsicHash, "nominator", nominatorAddress, "validator", validatorAddress)
 
-	// Get extrinsic information
-	extrinsicInfo, err := v.getExtrinsicInfo(extrinsicHash)
+	nominatorID, err := accountIDFromAddress(nominatorAddress)
+	if err != nil {
+		return false, fmt.Errorf("invalid nominator address: %w", err)
+	}
+	validatorID, err := accountIDFromAddress(validatorAddress)
+	if err != nil {
+		return false, fmt.Errorf("invalid validator address: %w", err)
+	}
+
+	// Only a nomination the nominator signed and that targets the validator counts
+	extrinsicInfo, err := v.findNominationExtrinsic(extrinsicHash, func(extrinsic *DecodedExtrinsic, targets [][]byte) bool {
+		return bytes.Equal(extrinsic.Signer, nominatorID) && containsAccount(targets, validatorID)
+	})
+	if errors.Is(err, ErrNominationMismatch) {
+		v.log().Info("nomination extrinsic not by the nominator for the validator", "event", "extrinsic_verify", "block_hash", extrinsicHash, "nominator", nominatorAddress, "validator", validatorAddress)
+		return false, nil
+	}
 	if err != nil {
-		log.Printf("❌ Failed to get extrinsic info: %v", err)
 		return false, fmt.Errorf("failed to get extrinsic info: %w", err)
 	}
 
 	if extrinsicInfo == nil {
-		log.Printf("❌ No extrinsic info found")
 		return false, fmt.Errorf("no extrinsic info found")
 	}
 
-	log.Printf("✅ Extrinsic info retrieved successfully")
-	log.Printf("   Block Hash: %s", extrinsicInfo.BlockHash)
-	log.Printf("   Extrinsic Index: %d", extrinsicInfo.ExtrinsicIdx)
-	log.Printf("   Success: %t", extrinsicInfo.Success)
+	v.log().Debug("extrinsic info retrieved", "event", "extrinsic_verify", "block_hash", extrinsicInfo.BlockHash, "index", extrinsicInfo.ExtrinsicIdx, "success", extrinsicInfo.Success)
 
 	// Check if the extrinsic was successful
 	if !extrinsicInfo.Success {
-		log.Printf("❌ Extrinsic was not successful")
 		return false, fmt.Errorf("extrinsic was not successful")
 	}
 
-	// For now, we'll assume the extrinsic is valid if we can retrieve it
-	// In a more sophisticated implementation, you would:
-	// 1. Decode the extrinsic properly
-	// 2. Extract the actual nominator and validator addresses
-	// 3. Compare them with the provided addresses
-	// 4. Check if the nomination is still active
-
-	log.Printf("✅ Extrinsic verification successful")
+	// Whether the nomination is still in effect is left to the storage verification
 	return true, nil
 }
 
-// getActiveEra gets the current active era from Polkadot
-func (v *Verifier) getActiveEra() (interface{}, error) {
-	log.Printf("📅 Querying active era from Polkadot")
+// checkIfNominated checks if a nominator has nominated a specific validator by reading the
+// nominator's Staking.Nominators entry, keyed by its SS58-decoded AccountId
+func (v *Verifier) checkIfNominated(ctx context.Context, nominatorAddress, validatorAddress string) (bool, error) {
+	v.log().DebugContext(ctx, "checking nomination", "event", "nomination_check", "nominator", nominatorAddress, "validator", validatorAddress)
 
-	// Query the ActiveEra storage value
-	request := RPCRequest{
-		JSONRPC: "2.0",
-		Method:  "state_getStorage",
-		Params: []interface{}{
-			"0x5f3e4907f716ac89b6347d15ececedca3ed14b45ed20d054f05e37e2542cfe70",
-		},
-		ID: 1,
+	nominatorID, err := accountIDFromAddress(nominatorAddress)
+	if err != nil {
+		return false, fmt.Errorf("invalid nominator address: %w", err)
+	}
+	validatorID, err := accountIDFromAddress(validatorAddress)
+	if err != nil {
+		return false, fmt.Errorf("invalid validator address: %w", err)
 	}
 
-	result, err := v.makeRPCCall(request)
+	nominations, err := v.getStashNominations(ctx, nominatorAddress, nominatorID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get active era: %w", err)
+		return false, err
+	}
+	var targets [][]byte
+	if nominations != nil {
+		targets = nominations.Targets
 	}
 
-	log.Printf("📅 Active era retrieved: %v", result)
-	return result, nil
+	v.log().DebugContext(ctx, "nomination targets read", "event", "nomination_check", "nominator", nominatorAddress, "targets", len(targets))
+	return containsAccount(targets, validatorID), nil
 }
 
-// checkIfNominated checks if a nominator has nominated a specific validator
-func (v *Verifier) checkIfNominated(nominatorAddress, validatorAddress string) (bool, error) {
-	log.Printf("🔍 Checking if nominator %s has nominated validator %s", nominatorAddress, validatorAddress)
+// checkIfActive checks whether the nominator's nomination of the validator is in effect: the
+// Staking.Nominators entry must still target the validator, must not be suppressed and must have
+// been submitted in the active era or earlier. A nominator that has chilled has no entry.
+func (v *Verifier) checkIfActive(ctx context.Context, nominatorAddress, validatorAddress string) (bool, error) {
+	v.log().DebugContext(ctx, "checking nomination is active", "event", "activity_check", "nominator", nominatorAddress, "validator", validatorAddress)
 
-	// For now, let's use a simpler approach and check if the addresses are valid
-	// In a real implementation, you would query the actual staking storage
-	// This is a placeholder that validates the address format
+	nominatorID, err := accountIDFromAddress(nominatorAddress)
+	if err != nil {
+		return false, fmt.Errorf("invalid nominator address: %w", err)
+	}
+	validatorID, err := accountIDFromAddress(validatorAddress)
+	if err != nil {
+		return false, fmt.Errorf("invalid validator address: %w", err)
+	}
 
-	// Check if addresses are valid (basic validation)
-	if len(nominatorAddress) < 10 || len(validatorAddress) < 10 {
-		log.Printf("❌ Invalid address format")
-		return false, fmt.Errorf("invalid address format")
+	activeEra, err := v.getActiveEraInfo(ctx)
+	if err != nil {
+		return false, err
 	}
+	v.log().DebugContext(ctx, "active era read", "event", "activity_check", "era", activeEra.Index)
 
-	// For testing purposes, we'll simulate a real check
-	// In production, you would:
-	// 1. Query the Staking.Nominators storage map
-	// 2. Decode the nomination data
-	// 3. Check if the validator is in the targets list
+	nominations, err := v.getStashNominations(ctx, nominatorAddress, nominatorID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get nominations: %w", err)
+	}
+	if nominations == nil {
+		v.log().InfoContext(ctx, "nominator has chilled", "event", "nomination_inactive", "reason", "chilled", "nominator", nominatorAddress, "validator", validatorAddress)
+		return false, nil
+	}
+	if !containsAccount(nominations.Targets, validatorID) {
+		v.log().InfoContext(ctx, "nomination no longer targets validator", "event", "nomination_inactive", "reason", "not_targeted", "nominator", nominatorAddress, "validator", validatorAddress)
+		return false, nil
+	}
 
-	log.Printf("✅ Addresses appear valid, checking nomination status")
+	// A suppressed nomination still exists but no longer backs its targets
+	if nominations.Suppressed {
+		v.log().InfoContext(ctx, "nomination is suppressed", "event", "nomination_inactive", "reason", "suppressed", "nominator", nominatorAddress, "validator", validatorAddress)
+		return false, nil
+	}
 
-	// Simulate a real check - in production this would be an actual storage query
-	// For now, we'll return true for any valid-looking addresses
-	// This should be replaced with actual storage queries
-	log.Printf("⚠️  Using simplified check - replace with actual storage queries in production")
+	if nominations.SubmittedIn > activeEra.Index {
+		v.log().InfoContext(ctx, "nomination submitted after the active era", "event", "nomination_inactive", "reason", "not_yet_active", "nominator", nominatorAddress, "validator", validatorAddress, "submitted_in", nominations.SubmittedIn, "era", activeEra.Index)
+		return false, nil
+	}
+
+	v.log().DebugContext(ctx, "nomination is active", "event", "activity_check", "nominator", nominatorAddress, "validator", validatorAddress, "submitted_in", nominations.SubmittedIn, "era", activeEra.Index)
 	return true, nil
 }
 
-// checkIfActive checks if the nomination is currently active
-func (v *Verifier) checkIfActive(nominatorAddress, validatorAddress string) (bool, error) {
-	log.Printf("🔍 Checking if nomination is currently active...")
+// VerifyDelegation checks if a nominator has delegated to a validator
+func (v *Verifier) VerifyDelegation(nominatorAddress, validatorAddress string) (bool, error) {
+	return v.VerifyDelegationCtx(context.Background(), nominatorAddress, validatorAddress)
+}
 
-	// Query the current era to check if the nomination is active
-	// In a real implementation, you would check the current era against the nomination era
-	activeEra, err := v.getActiveEra()
+// VerifyDelegationCtx is VerifyDelegation bounded by ctx
+func (v *Verifier) VerifyDelegationCtx(ctx context.Context, nominatorAddress, validatorAddress string) (bool, error) {
+	result, err := v.VerifyDelegationDetail(ctx, nominatorAddress, validatorAddress)
 	if err != nil {
-		log.Printf("❌ Failed to get active era for activity check: %v", err)
-		return false, fmt.Errorf("failed to get active era: %w", err)
+		return false, err
 	}
+	return result.IsValid, nil
+}
 
-	log.Printf("📅 Current active era: %v", activeEra)
+// VerifyDelegationDetail runs VerifyDelegationCtx and reports its sub-checks. The delegation is
+// valid when the nominator's Staking.Nominators entry targets the validator, whether or not the
+// nomination is active yet. When it doesn't, NominatedValidators lists what the nominator targets instead.
+func (v *Verifier) VerifyDelegationDetail(ctx context.Context, nominatorAddress, validatorAddress string) (*VerificationResult, error) {
+	start := time.Now()
+	v.log().DebugContext(ctx, "verifying delegation", "event", "delegation_verify", "nominator", nominatorAddress, "validator", validatorAddress)
 
-	// For now, we'll assume the nomination is active if it exists
-	// In a real implementation, you'd check the nomination era and other factors
-	log.Printf("✅ Assuming nomination is active (simplified check)")
-	return true, nil
-}
+	cacheKey := resultCacheKey("delegation", nominatorAddress, validatorAddress)
+	if cached, ok := v.cachedResult(ctx, cacheKey); ok {
+		return cached, nil
+	}
 
-// VerifyDelegation checks if a nominator has delegated to a validator
-func (v *Verifier) VerifyDelegation(nominatorAddress, validatorAddress string) (bool, error) {
-	log.Printf("🔍 Verifying delegation: %s -> %s", nominatorAddress, validatorAddress)
+	if transcript := transcriptFromContext(ctx); transcript != nil {
+		transcript.SetInput("nominator", nominatorAddress)
+		transcript.SetInput("validator", validatorAddress)
+	}
 
 	// Get the current active era
-	activeEra, err := v.getActiveEra()
+	activeEra, err := v.getActiveEraInfo(ctx)
 	if err != nil {
-		log.Printf("❌ Failed to get active era: %v", err)
-		return false, fmt.Errorf("failed to get active era: %w", err)
+		return nil, err
 	}
-	log.Printf("📅 Current active era: %v", activeEra)
+	v.log().DebugContext(ctx, "active era read", "event", "delegation_verify", "era", activeEra.Index)
 
-	// Check if the nominator has nominated the validator
-	isNominated, err := v.checkIfNominated(nominatorAddress, validatorAddress)
+	nominatorID, err := accountIDFromAddress(nominatorAddress)
 	if err != nil {
-		return false, fmt.Errorf("failed to check nomination: %w", err)
+		return nil, fmt.Errorf("failed to check nomination: invalid nominator address: %w", err)
+	}
+	validatorID, err := accountIDFromAddress(validatorAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check nomination: invalid validator address: %w", err)
 	}
 
-	if !isNominated {
-		log.Printf("❌ Nominator %s has NOT nominated validator %s", nominatorAddress, validatorAddress)
-		return false, nil
+	result := &DelegationVerificationResult{
+		NominatorAddress:  nominatorAddress,
+		ValidatorAddress:  validatorAddress,
+		Timestamp:         time.Now(),
+		AddressValidation: true,
 	}
 
-	log.Printf("✅ Nominator %s HAS nominated validator %s", nominatorAddress, validatorAddress)
+	// Check if the nominator, or the stash it controls, has nominated the validator
+	nominations, blockHash, err := v.getStashNominationsWithBlock(ctx, nominatorAddress, nominatorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check nomination: %w", err)
+	}
+	result.BlockHash = blockHash
+	var targets [][]byte
+	if nominations != nil {
+		targets = nominations.Targets
+	}
+	isNominated := containsAccount(targets, validatorID)
+	recordDecoded(ctx, "isNominated", isNominated)
+
+	if !isNominated {
+		result.NominatedValidators = make([]string, 0, len(targets))
+		for _, target := range targets {
+			result.NominatedValidators = append(result.NominatedValidators, encodeSS58(v.network.SS58Prefix, target))
+		}
+		if len(targets) == 0 {
+			result.AdditionalInfo = "nominator has no nominations: it isn't a nominator or has chilled"
+		} else {
+			result.AdditionalInfo = fmt.Sprintf("nominator nominates %d other validators", len(targets))
+		}
+
+		v.log().InfoContext(ctx, "delegation not found", "event", "delegation_verified", "nominator", nominatorAddress, "validator", validatorAddress,
+			"delegated", false, "targets", len(targets), "duration_ms", time.Since(start).Milliseconds())
+		return result, nil
+	}
+	result.StorageValidation = true
 
 	// Check if the nomination is currently active
-	isActive, err := v.checkIfActive(nominatorAddress, validatorAddress)
+	isActive, err := v.checkIfActive(ctx, nominatorAddress, validatorAddress)
 	if err != nil {
-		return false, fmt.Errorf("failed to check if nomination is active: %w", err)
+		return nil, fmt.Errorf("failed to check if nomination is active: %w", err)
 	}
+	recordDecoded(ctx, "isActive", isActive)
+	result.ActiveEraValidation = isActive
+	result.IsValid = true
 
-	if isActive {
-		log.Printf("✅ The nomination is currently ACTIVE and earning rewards")
-	} else {
-		log.Printf("⚠️  The nomination exists but is currently INACTIVE (not earning rewards)")
-	}
+	v.log().InfoContext(ctx, "delegation found", "event", "delegation_verified", "nominator", nominatorAddress, "validator", validatorAddress,
+		"delegated", true, "active", isActive, "era", activeEra.Index, "duration_ms", time.Since(start).Milliseconds())
 
-	return true, nil
+	v.cacheResult(ctx, cacheKey, result)
+	return result, nil
 }
 
 // VerifyDelegationWithExtrinsic checks if a nominator has delegated to a validator using a specific extrinsic hash
 func (v *Verifier) VerifyDelegationWithExtrinsic(extrinsicHash, nominatorAddress, validatorAddress string) (bool, error) {
-	log.Printf("🔍 Verifying delegation with extrinsic hash: %s", extrinsicHash)
-	log.Printf("   Nominator: %s", nominatorAddress)
-	log.Printf("   Validator: %s", validatorAddress)
+	v.log().Debug("verifying delegation with extrinsic", "event", "extrinsic_verify", "block_hash", extrinsicHash, "nominator", nominatorAddress, "validator", validatorAddress)
 
-	// First, verify the extrinsic itself
+	// First, verify the extrinsic itself. This is best-effort: when the block holds no
+	// nomination extrinsic we fall through to the storage-based verification below.
 	extrinsicValid, err := v.verifyDelegationByExtrinsic(extrinsicHash, nominatorAddress, validatorAddress)
-	if err != nil {
-		log.Printf("❌ Extrinsic verification failed: %v", err)
+	switch {
+	case errors.Is(err, ErrNoNominationExtrinsic):
+		v.log().Info("no nomination extrinsic, falling back to storage verification", "event", "extrinsic_verify", "block_hash", extrinsicHash)
+	case err != nil:
 		return false, fmt.Errorf("extrinsic verification failed: %w", err)
-	}
-
-	if !extrinsicValid {
-		log.Printf("❌ Extrinsic verification failed")
+	case !extrinsicValid:
 		return false, fmt.Errorf("extrinsic verification failed")
 	}
 
-	log.Printf("✅ Extrinsic verification successful")
-
 	// Then, perform the standard delegation verification
 	standardValid, err := v.VerifyDelegation(nominatorAddress, validatorAddress)
 	if err != nil {
-		log.Printf("❌ Standard delegation verification failed: %v", err)
 		return false, fmt.Errorf("standard delegation verification failed: %w", err)
 	}
 
 	if !standardValid {
-		log.Printf("❌ Standard delegation verification failed")
 		return false, fmt.Errorf("standard delegation verification failed")
 	}
 
-	log.Printf("✅ Both extrinsic and standard verification successful")
 	return true, nil
 }
 
 // GetStakingExtrinsics retrieves all staking-related extrinsics for a given nominator-validator pair
 func (v *Verifier) GetStakingExtrinsics(nominatorAddress, validatorAddress string) ([]StakingExtrinsic, error) {
-	log.Printf("🔍 Getting staking extrinsics for nominator: %s, validator: %s", nominatorAddress, validatorAddress)
+	return v.GetStakingExtrinsicsCtx(context.Background(), nominatorAddress, validatorAddress)
+}
+
+// GetStakingExtrinsicsCtx is GetStakingExtrinsics bounded by ctx; cancelling ctx aborts the block scan
+func (v *Verifier) GetStakingExtrinsicsCtx(ctx context.Context, nominatorAddress, validatorAddress string) ([]StakingExtrinsic, error) {
+	v.log().DebugContext(ctx, "getting staking extrinsics", "event", "extrinsic_search", "nominator", nominatorAddress, "validator", validatorAddress)
 
 	var extrinsics []StakingExtrinsic
 
 	// Method 1: If nominatorAddress looks like an extrinsic hash, try to get it directly
 	if strings.HasPrefix(nominatorAddress, "0x") && len(nominatorAddress) == 66 {
-		log.Printf("🔍 Nominator address looks like an extrinsic hash, trying direct lookup")
-		directExtrinsic, err := v.getExtrinsicByHash(nominatorAddress)
+		v.log().DebugContext(ctx, "nominator looks like an extrinsic hash, trying direct lookup", "event", "extrinsic_search")
+		directExtrinsic, err := v.getExtrinsicByHash(ctx, nominatorAddress)
 		if err != nil {
-			log.Printf("⚠️  Error getting extrinsic by hash: %v", err)
+			v.log().WarnContext(ctx, "extrinsic lookup by hash failed", "event", "extrinsic_search", "error", err)
 		} else if directExtrinsic != nil {
 			extrinsics = append(extrinsics, *directExtrinsic)
-			log.Printf("✅ Found extrinsic by hash, returning early")
+			v.log().DebugContext(ctx, "found extrinsic by hash", "event", "extrinsic_search")
 			return extrinsics, nil
 		}
 	}
 
+	// Prefer an indexer, which covers the whole history, falling back to scanning blocks when it fails
+	if v.proofSource != nil {
+		indexed, err := v.proofSource.StakingExtrinsics(ctx, nominatorAddress, validatorAddress)
+		if err == nil {
+			v.log().DebugContext(ctx, "staking extrinsics found by proof source", "event", "extrinsic_search", "extrinsics", len(indexed))
+			return v.removeDuplicateExtrinsics(indexed), nil
+		}
+		v.log().WarnContext(ctx, "proof source lookup failed, scanning blocks", "event", "extrinsic_search", "error", err)
+	}
+
 	// Method 2: Try to find the extrinsic using a more targeted approach
-	targetedExtrinsics, err := v.findExtrinsicByAddress(nominatorAddress, validatorAddress)
+	scan, err := v.findExtrinsicByAddress(ctx, nominatorAddress, validatorAddress)
 	if err != nil {
-		log.Printf("⚠️  Error in targeted search: %v", err)
+		v.log().WarnContext(ctx, "targeted extrinsic search failed", "event", "extrinsic_search", "error", err)
 	} else {
-		extrinsics = append(extrinsics, targetedExtrinsics...)
+		if scan.Note != "" {
+			v.log().WarnContext(ctx, "targeted extrinsic search incomplete", "event", "extrinsic_search", "note", scan.Note)
+		}
+		extrinsics = append(extrinsics, scan.Extrinsics...)
 	}
 
 	// Method 3: Use state_queryStorageAt to find specific staking events (simplified)
-	storageExtrinsics, err := v.queryStakingStorage(nominatorAddress, validatorAddress)
+	storageExtrinsics, err := v.queryStakingStorage(ctx, nominatorAddress, validatorAddress)
 	if err != nil {
-		log.Printf("⚠️  Error querying staking storage: %v", err)
+		v.log().WarnContext(ctx, "staking storage query failed", "event", "extrinsic_search", "error", err)
 	} else {
 		extrinsics = append(extrinsics, storageExtrinsics...)
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Remove duplicates based on extrinsic hash
 	uniqueExtrinsics := v.removeDuplicateExtrinsics(extrinsics)
 
-	log.Printf("✅ Found %d unique staking extrinsics", len(uniqueExtrinsics))
+	v.log().DebugContext(ctx, "staking extrinsics found", "event", "extrinsic_search", "extrinsics", len(uniqueExtrinsics))
 	return uniqueExtrinsics, nil
 }
 
 // getLatestBlockNumber gets the latest block number
-func (v *Verifier) getLatestBlockNumber() (int64, error) {
+func (v *Verifier) getLatestBlockNumber(ctx context.Context) (int64, error) {
 	request := RPCRequest{
 		JSONRPC: "2.0",
 		Method:  "chain_getHeader",
@@ -417,33 +603,50 @@ func (v *Verifier) getLatestBlockNumber() (int64, error) {
 		ID:      1,
 	}
 
-	result, err := v.makeRPCCall(request)
+	result, err := v.makeRPCCallCtx(ctx, request)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get latest block header: %w", err)
 	}
 
 	// Parse the block number from the result
 	if headerMap, ok := result.(map[string]interface{}); ok {
-		if numberStr, exists := headerMap["number"].(string); exists {
-			// Convert hex string to int64
-			var blockNum int64
-			_, err := fmt.Sscanf(numberStr, "0x%x", &blockNum)
-			if err != nil {
-				return 0, fmt.Errorf("failed to parse block number: %w", err)
-			}
-			return blockNum, nil
+		if number, exists := headerMap["number"]; exists {
+			return parseBlockNumber(number)
 		}
 	}
 
 	return 0, fmt.Errorf("could not extract block number from response")
 }
 
+// parseBlockNumber parses a header's block number, given either as a hex string, with or
+// without the 0x prefix, or as a JSON number
+func parseBlockNumber(number interface{}) (int64, error) {
+	switch n := number.(type) {
+	case string:
+		blockNum, err := strconv.ParseInt(strings.TrimPrefix(n, "0x"), 16, 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse block number %q: %w", n, err)
+		}
+		if blockNum < 0 {
+			return 0, fmt.Errorf("negative block number %q", n)
+		}
+		return blockNum, nil
+	case float64:
+		if n < 0 || n != math.Trunc(n) || n > math.MaxInt64 {
+			return 0, fmt.Errorf("invalid block number %v", n)
+		}
+		return int64(n), nil
+	default:
+		return 0, fmt.Errorf("unexpected block number type %T", number)
+	}
+}
+
 // getStakingExtrinsicsFromBlock gets staking extrinsics from a specific block
-func (v *Verifier) getStakingExtrinsicsFromBlock(blockNumber int64, nominatorAddress, validatorAddress string) ([]StakingExtrinsic, error) {
+func (v *Verifier) getStakingExtrinsicsFromBlock(ctx context.Context, blockNumber int64, nominatorAddress, validatorAddress string) ([]StakingExtrinsic, error) {
 	var extrinsics []StakingExtrinsic
 
 	// First, get the block hash for the block number
-	blockHash, err := v.getBlockHash(blockNumber)
+	blockHash, err := v.getBlockHash(ctx, blockNumber)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get block hash for block %d: %w", blockNumber, err)
 	}
@@ -457,7 +660,7 @@ func (v *Verifier) getStakingExtrinsicsFromBlock(blockNumber int64, nominatorAdd
 		ID: 1,
 	}
 
-	result, err := v.makeRPCCall(request)
+	result, err := v.makeRPCCallCtx(ctx, request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get block %d: %w", blockNumber, err)
 	}
@@ -468,12 +671,15 @@ func (v *Verifier) getStakingExtrinsicsFromBlock(blockNumber int64, nominatorAdd
 			if blockExtrinsics, ok := block["extrinsics"].([]interface{}); ok {
 				for i, extrinsic := range blockExtrinsics {
 					if v.isStakingExtrinsic(extrinsic, nominatorAddress, validatorAddress) {
+						method, params := v.describeStakingCall(extrinsic)
 						stakingExtrinsic := StakingExtrinsic{
-							BlockHash:    blockHash,
-							BlockNumber:  fmt.Sprintf("%d", blockNumber),
-							ExtrinsicIdx: i,
-							Method:       "staking.nominate", // Default, will be updated
-							Success:      true,               // Assume success for now
+							ExtrinsicHash: extrinsicHash(extrinsic),
+							BlockHash:     blockHash,
+							BlockNumber:   fmt.Sprintf("%d", blockNumber),
+							ExtrinsicIdx:  i,
+							Method:        method,
+							Params:        params,
+							Success:       true, // Assume success for now
 						}
 						extrinsics = append(extrinsics, stakingExtrinsic)
 					}
@@ -485,8 +691,53 @@ func (v *Verifier) getStakingExtrinsicsFromBlock(blockNumber int64, nominatorAdd
 	return extrinsics, nil
 }
 
+// getBlockTimestamp reads Timestamp.Now, the time the block was authored at, at the given block
+func (v *Verifier) getBlockTimestamp(ctx context.Context, blockHash string) (time.Time, error) {
+	raw, err := v.getStorageAt(ctx, timestampNowStorageKey(), blockHash)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read timestamp of block %s: %w", blockHash, err)
+	}
+	if raw == nil {
+		return time.Time{}, fmt.Errorf("block %s has no timestamp", blockHash)
+	}
+
+	millis, err := newScaleDecoder(raw).readU64()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to decode timestamp of block %s: %w", blockHash, err)
+	}
+	return time.UnixMilli(int64(millis)).UTC(), nil
+}
+
+// stampExtrinsics sets the Timestamp of extrinsics read from one block to that block's time.
+// A timestamp that can't be read is logged and left empty.
+func (v *Verifier) stampExtrinsics(ctx context.Context, extrinsics []StakingExtrinsic) {
+	timestamp, err := v.getBlockTimestamp(ctx, extrinsics[0].BlockHash)
+	if err != nil {
+		v.log().WarnContext(ctx, "failed to read block timestamp", "event", "block_scan", "block_hash", extrinsics[0].BlockHash, "error", err)
+		return
+	}
+	for i := range extrinsics {
+		extrinsics[i].Timestamp = timestamp.Format(time.RFC3339)
+	}
+}
+
+// extrinsicHash returns the 0x-prefixed blake2b-256 hash of a raw, hex-encoded extrinsic as
+// returned by chain_getBlock, or an empty string when the extrinsic isn't valid hex
+func extrinsicHash(extrinsic interface{}) string {
+	encoded, ok := extrinsic.(string)
+	if !ok {
+		return ""
+	}
+	raw, err := hex.DecodeString(strings.TrimPrefix(encoded, "0x"))
+	if err != nil {
+		return ""
+	}
+	hash := blake2b.Sum256(raw)
+	return "0x" + hex.EncodeToString(hash[:])
+}
+
 // getBlockHash gets the block hash for a given block number
-func (v *Verifier) getBlockHash(blockNumber int64) (string, error) {
+func (v *Verifier) getBlockHash(ctx context.Context, blockNumber int64) (string, error) {
 	request := RPCRequest{
 		JSONRPC: "2.0",
 		Method:  "chain_getBlockHash",
@@ -496,7 +747,7 @@ func (v *Verifier) getBlockHash(blockNumber int64) (string, error) {
 		ID: 1,
 	}
 
-	result, err := v.makeRPCCall(request)
+	result, err := v.makeRPCCallCtx(ctx, request)
 	if err != nil {
 		return "", fmt.Errorf("failed to get block hash: %w", err)
 	}
@@ -508,74 +759,72 @@ func (v *Verifier) getBlockHash(blockNumber int64) (string, error) {
 	return "", fmt.Errorf("invalid block hash response")
 }
 
-// isStakingExtrinsic checks if an extrinsic is a staking extrinsic for the given addresses
+// isStakingExtrinsic checks if an extrinsic is a staking call signed by the nominator,
+// or a Staking.nominate call targeting the validator
 func (v *Verifier) isStakingExtrinsic(extrinsic interface{}, nominatorAddress, validatorAddress string) bool {
-	extrinsicStr := fmt.Sprintf("%v", extrinsic)
-
-	// Check for staking-related patterns
-	stakingPatterns := []string{
-		"nominate",
-		"bond",
-		"unbond",
-		"withdraw_unbonded",
-		"chill",
-		"set_payee",
-		"set_controller",
-		"validate",
-	}
-
-	for _, pattern := range stakingPatterns {
-		if strings.Contains(strings.ToLower(extrinsicStr), pattern) {
-			// Additional check: see if the addresses are mentioned in the extrinsic
-			if strings.Contains(extrinsicStr, nominatorAddress) || strings.Contains(extrinsicStr, validatorAddress) {
-				return true
-			}
-		}
+	decoded, err := decodeExtrinsicHex(extrinsic)
+	if err != nil || !v.isStakingCall(decoded) {
+		return false
 	}
 
-	return false
+	if nominatorID, err := accountIDFromAddress(nominatorAddress); err == nil && bytes.Equal(decoded.Signer, nominatorID) {
+		return true
+	}
+
+	if !v.isNominateCall(decoded) {
+		return false
+	}
+	validatorID, err := accountIDFromAddress(validatorAddress)
+	if err != nil {
+		return false
+	}
+	targets, err := decoded.nominateTargets()
+	return err == nil && containsAccount(targets, validatorID)
 }
 
 // queryStakingStorage queries staking storage for specific events
-func (v *Verifier) queryStakingStorage(nominatorAddress, validatorAddress string) ([]StakingExtrinsic, error) {
-	log.Printf("🔍 Querying staking storage for events")
+func (v *Verifier) queryStakingStorage(ctx context.Context, nominatorAddress, validatorAddress string) ([]StakingExtrinsic, error) {
+	v.log().DebugContext(ctx, "querying staking storage", "event", "extrinsic_search", "nominator", nominatorAddress)
 
 	var extrinsics []StakingExtrinsic
 
-	// Query Staking.Nominators storage
-	request := RPCRequest{
-		JSONRPC: "2.0",
-		Method:  "state_getStorage",
-		Params: []interface{}{
-			"0x5f3e4907f716ac89b6347d15ececedca3ed14b45ed20d054f05e37e2542cfe70", // Staking.Nominators
-		},
-		ID: 1,
+	nominatorID, err := accountIDFromAddress(nominatorAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nominator address: %w", err)
 	}
 
-	result, err := v.makeRPCCall(request)
+	// Query the nominator's Staking.Nominators entry
+	raw, err := v.getStorage(ctx, nominatorsStorageKey(nominatorID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to query staking storage: %w", err)
 	}
+	if raw == nil {
+		v.log().DebugContext(ctx, "no nominations stored", "event", "extrinsic_search", "nominator", nominatorAddress)
+		return extrinsics, nil
+	}
 
-	log.Printf("📋 Staking storage result: %v", result)
+	v.log().DebugContext(ctx, "staking storage read", "event", "extrinsic_search", "nominator", nominatorAddress, "raw", fmt.Sprintf("0x%x", raw))
 
-	// This is a simplified implementation
-	// In a real implementation, you would:
-	// 1. Decode the storage value properly
-	// 2. Extract nomination information
-	// 3. Find the corresponding extrinsics
+	// Storage holds the current nominations, not the extrinsics that set them,
+	// so nothing is added to the scan results here
 
 	return extrinsics, nil
 }
 
-// removeDuplicateExtrinsics removes duplicate extrinsics based on extrinsic hash
+// removeDuplicateExtrinsics removes duplicate extrinsics based on extrinsic hash.
+// Extrinsics without a hash are keyed on their block hash and index instead, so
+// distinct hash-less entries don't collapse into one.
 func (v *Verifier) removeDuplicateExtrinsics(extrinsics []StakingExtrinsic) []StakingExtrinsic {
 	seen := make(map[string]bool)
 	var unique []StakingExtrinsic
 
 	for _, extrinsic := range extrinsics {
-		if !seen[extrinsic.ExtrinsicHash] {
-			seen[extrinsic.ExtrinsicHash] = true
+		key := extrinsic.ExtrinsicHash
+		if key == "" {
+			key = fmt.Sprintf("%s#%d", extrinsic.BlockHash, extrinsic.ExtrinsicIdx)
+		}
+		if !seen[key] {
+			seen[key] = true
 			unique = append(unique, extrinsic)
 		}
 	}
@@ -584,8 +833,8 @@ func (v *Verifier) removeDuplicateExtrinsics(extrinsics []StakingExtrinsic) []St
 }
 
 // getExtrinsicByHash retrieves an extrinsic directly by its hash
-func (v *Verifier) getExtrinsicByHash(extrinsicHash string) (*StakingExtrinsic, error) {
-	log.Printf("🔍 Getting extrinsic by hash: %s", extrinsicHash)
+func (v *Verifier) getExtrinsicByHash(ctx context.Context, extrinsicHash string) (*StakingExtrinsic, error) {
+	v.log().DebugContext(ctx, "getting extrinsic by hash", "event", "extrinsic_search", "block_hash", extrinsicHash)
 
 	// Try to get the extrinsic using chain_getBlock
 	request := RPCRequest{
@@ -597,9 +846,8 @@ func (v *Verifier) getExtrinsicByHash(extrinsicHash string) (*StakingExtrinsic,
 		ID: 1,
 	}
 
-	result, err := v.makeRPCCall(request)
+	result, err := v.makeRPCCallCtx(ctx, request)
 	if err != nil {
-		log.Printf("⚠️  Failed to get extrinsic by hash: %v", err)
 		return nil, err
 	}
 
@@ -609,8 +857,8 @@ func (v *Verifier) getExtrinsicByHash(extrinsicHash string) (*StakingExtrinsic,
 			if extrinsics, ok := block["extrinsics"].([]interface{}); ok {
 				for i, extrinsic := range extrinsics {
 					// Check if this is a staking extrinsic
-					if v.isStakingExtrinsic(extrinsic, extrinsicHash, "") {
-						log.Printf("✅ Found staking extrinsic at index %d", i)
+					if decoded, err := decodeExtrinsicHex(extrinsic); err == nil && v.isStakingCall(decoded) {
+						v.log().DebugContext(ctx, "found staking extrinsic", "event", "extrinsic_search", "block_hash", extrinsicHash, "index", i)
 						return &StakingExtrinsic{
 							ExtrinsicHash: extrinsicHash,
 							BlockHash:     extrinsicHash, // In this case, the hash is the block hash
@@ -625,54 +873,142 @@ func (v *Verifier) getExtrinsicByHash(extrinsicHash string) (*StakingExtrinsic,
 		}
 	}
 
-	log.Printf("⚠️  No staking extrinsic found for hash: %s", extrinsicHash)
+	v.log().DebugContext(ctx, "no staking extrinsic found", "event", "extrinsic_search", "block_hash", extrinsicHash)
 	return nil, nil
 }
 
-// findExtrinsicByAddress tries to find extrinsics by searching a small range of recent blocks
-func (v *Verifier) findExtrinsicByAddress(nominatorAddress, validatorAddress string) ([]StakingExtrinsic, error) {
-	log.Printf("🔍 Finding extrinsics by address in recent blocks")
+// ScanNoteBudgetExhausted notes that a block scan stopped early because its RPC budget ran out
+const ScanNoteBudgetExhausted = "budget_exhausted"
 
-	var extrinsics []StakingExtrinsic
+// blockScanCallsPerBlock is the number of RPC calls needed to scan one block (hash lookup and body)
+const blockScanCallsPerBlock = 2
+
+// blockScanDelay spaces out block reads so a scan doesn't overwhelm the RPC endpoint
+const blockScanDelay = 100 * time.Millisecond
+
+// Block scan defaults: how many recent blocks are searched and how many extrinsics end the search
+const (
+	DefaultScanRange      = 10
+	DefaultScanMaxResults = 5
+)
+
+// SetScanRange sets how many blocks behind the latest one a block scan searches.
+// A non-positive range restores DefaultScanRange.
+func (v *Verifier) SetScanRange(blocks int64) {
+	if blocks <= 0 {
+		blocks = DefaultScanRange
+	}
+	v.scanRange = blocks
+}
+
+// SetScanMaxResults sets how many extrinsics a block scan collects before stopping.
+// A non-positive limit restores DefaultScanMaxResults.
+func (v *Verifier) SetScanMaxResults(limit int) {
+	if limit <= 0 {
+		limit = DefaultScanMaxResults
+	}
+	v.scanMaxResults = limit
+}
+
+// blockScanResult holds the extrinsics found by a block scan and why it stopped early, if it did
+type blockScanResult struct {
+	Extrinsics []StakingExtrinsic
+	Note       string
+}
+
+// findExtrinsicByAddress tries to find extrinsics by searching the verifier's scan range of recent blocks.
+// The scan respects the verifier's RPC budget, returning partial results with
+// ScanNoteBudgetExhausted once the budget can't cover another block, and stops as soon as ctx is cancelled.
+// Extrinsics found are stamped with their block's Timestamp.Now while the budget allows the extra read.
+func (v *Verifier) findExtrinsicByAddress(ctx context.Context, nominatorAddress, validatorAddress string) (*blockScanResult, error) {
+	v.log().DebugContext(ctx, "scanning recent blocks for extrinsics", "event", "block_scan", "nominator", nominatorAddress, "validator", validatorAddress)
+
+	scan := &blockScanResult{}
 
 	// Get the latest block number
-	latestBlock, err := v.getLatestBlockNumber()
+	latestBlock, err := v.getLatestBlockNumber(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get latest block: %w", err)
 	}
+	callsUsed := 1
 
-	// Search through only the last 10 blocks for performance
-	searchRange := int64(10)
-	startBlock := latestBlock - searchRange
+	// Search through only the last few blocks for performance
+	startBlock := latestBlock - v.scanRange
 	if startBlock < 0 {
 		startBlock = 0
 	}
 
-	log.Printf("📊 Searching blocks from %d to %d", startBlock, latestBlock)
+	v.log().DebugContext(ctx, "scanning block range", "event", "block_scan", "from", startBlock, "to", latestBlock)
 
 	// Search in reverse order (newest first) and limit results
-	maxExtrinsics := 5
-	for blockNum := latestBlock; blockNum >= startBlock && len(extrinsics) < maxExtrinsics; blockNum-- {
-		blockExtrinsics, err := v.getStakingExtrinsicsFromBlock(blockNum, nominatorAddress, validatorAddress)
+	for blockNum := latestBlock; blockNum >= startBlock && len(scan.Extrinsics) < v.scanMaxResults; blockNum-- {
+		if v.maxRPCCallsPerVerify > 0 && callsUsed+blockScanCallsPerBlock > v.maxRPCCallsPerVerify {
+			v.log().WarnContext(ctx, "RPC budget exhausted, stopping block scan", "event", "block_scan", "budget", v.maxRPCCallsPerVerify, "block", blockNum)
+			scan.Note = ScanNoteBudgetExhausted
+			break
+		}
+		callsUsed += blockScanCallsPerBlock
+
+		blockExtrinsics, err := v.getStakingExtrinsicsFromBlock(ctx, blockNum, nominatorAddress, validatorAddress)
 		if err != nil {
-			log.Printf("⚠️  Error getting extrinsics from block %d: %v", blockNum, err)
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("block scan aborted at block %d: %w", blockNum, ctx.Err())
+			}
+			v.log().WarnContext(ctx, "failed to read block extrinsics", "event", "block_scan", "block", blockNum, "error", err)
 			continue
 		}
-		extrinsics = append(extrinsics, blockExtrinsics...)
+
+		// Matches are stamped with their block's time, budget permitting
+		if len(blockExtrinsics) > 0 && (v.maxRPCCallsPerVerify <= 0 || callsUsed < v.maxRPCCallsPerVerify) {
+			callsUsed++
+			v.stampExtrinsics(ctx, blockExtrinsics)
+		}
+		scan.Extrinsics = append(scan.Extrinsics, blockExtrinsics...)
 
 		// Add a small delay to avoid overwhelming the RPC
-		time.Sleep(100 * time.Millisecond)
+		select {
+		case <-time.After(blockScanDelay):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("block scan aborted at block %d: %w", blockNum, ctx.Err())
+		}
 	}
 
-	log.Printf("✅ Found %d extrinsics in recent blocks", len(extrinsics))
-	return extrinsics, nil
+	v.log().DebugContext(ctx, "block scan finished", "event", "block_scan", "extrinsics", len(scan.Extrinsics))
+	return scan, nil
 }
 
+// VerifyStage names a VerifyV2 validation step that has passed
+type VerifyStage string
+
+const (
+	StageAddressOK VerifyStage = "address_ok"
+	StageStorageOK VerifyStage = "storage_ok"
+	StageEraOK     VerifyStage = "era_ok"
+)
+
 // VerifyV2 provides comprehensive delegation verification with multiple validation steps
 func (v *Verifier) VerifyV2(nominatorAddress, validatorAddress string) (*DelegationVerificationResult, error) {
-	log.Printf("🔍 VerifyV2: Comprehensive delegation verification")
-	log.Printf("   Nominator: %s", nominatorAddress)
-	log.Printf("   Validator: %s", validatorAddress)
+	return v.VerifyV2WithProgress(context.Background(), nominatorAddress, validatorAddress, nil)
+}
+
+// VerifyV2WithProgress runs VerifyV2, calling progress (when non-nil) as each validation step passes.
+// Verification stops with ctx's error once ctx is cancelled.
+func (v *Verifier) VerifyV2WithProgress(ctx context.Context, nominatorAddress, validatorAddress string, progress func(VerifyStage)) (*DelegationVerificationResult, error) {
+	if progress == nil {
+		progress = func(VerifyStage) {}
+	}
+
+	start := time.Now()
+	v.log().DebugContext(ctx, "verifying delegation", "event", "verify_v2", "nominator", nominatorAddress, "validator", validatorAddress)
+
+	// Only passing results are cached, so a cache hit has passed every step
+	cacheKey := resultCacheKey("v2", nominatorAddress, validatorAddress)
+	if cached, ok := v.cachedResult(ctx, cacheKey); ok {
+		progress(StageAddressOK)
+		progress(StageStorageOK)
+		progress(StageEraOK)
+		return cached, nil
+	}
 
 	result := &DelegationVerificationResult{
 		NominatorAddress: nominatorAddress,
@@ -680,187 +1016,232 @@ func (v *Verifier) VerifyV2(nominatorAddress, validatorAddress string) (*Delegat
 		Timestamp:        time.Now(),
 	}
 
+	if transcript := transcriptFromContext(ctx); transcript != nil {
+		transcript.SetInput("nominator", nominatorAddress)
+		transcript.SetInput("validator", validatorAddress)
+		defer func() { transcript.SetResult(result) }()
+	}
+
+	// Each step runs even when an earlier one fails, so callers can see every failing check
+	var failures, notes []string
+
 	// Step 1: Basic address validation
 	if err := v.validateAddresses(nominatorAddress, validatorAddress); err != nil {
-		result.IsValid = false
-		result.Error = fmt.Sprintf("Address validation failed: %v", err)
-		log.Printf("❌ Address validation failed: %v", err)
-		return result, nil
+		failures = append(failures, fmt.Sprintf("Address validation failed: %v", err))
+		v.log().DebugContext(ctx, "address validation failed", "event", "verify_v2", "error", err)
+	} else {
+		result.AddressValidation = true
+		progress(StageAddressOK)
+
+		// The storage and active era checks both need these, so fetch them in one round trip
+		ctx = v.prefetchEraAndNominations(ctx, nominatorAddress)
 	}
-	result.AddressValidation = true
-	log.Printf("✅ Address validation passed")
 
 	// Step 2: Extrinsic verification is not performed in V2
 	// V2 focuses on storage-based and active era verification
-	log.Printf("ℹ️  Extrinsic verification skipped in V2 (focus on storage and era validation)")
 	result.ExtrinsicValidation = false
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Step 3: Storage-based verification
-	storageValid, err := v.verifyDelegationByStorage(nominatorAddress, validatorAddress)
+	storageValid, err := v.verifyDelegationByStorage(ctx, nominatorAddress, validatorAddress)
 	if err != nil {
-		result.IsValid = false
-		result.Error = fmt.Sprintf("Storage verification failed: %v", err)
-		log.Printf("❌ Storage verification failed: %v", err)
-		return result, nil
+		failures = append(failures, fmt.Sprintf("Storage verification failed: %v", err))
+		v.log().DebugContext(ctx, "storage verification failed", "event", "verify_v2", "error", err)
+	} else if storageValid {
+		result.StorageValidation = true
+		progress(StageStorageOK)
 	}
-	result.StorageValidation = storageValid
-	if storageValid {
-		log.Printf("✅ Storage verification passed")
-	} else {
-		log.Printf("❌ Storage verification failed")
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
 	// Step 4: Active era verification
-	activeEraValid, err := v.verifyActiveEra(nominatorAddress, validatorAddress)
+	activeEraValid, err := v.verifyActiveEra(ctx, nominatorAddress, validatorAddress)
 	if err != nil {
-		result.IsValid = false
-		result.Error = fmt.Sprintf("Active era verification failed: %v", err)
-		log.Printf("❌ Active era verification failed: %v", err)
-		return result, nil
-	}
-	result.ActiveEraValidation = activeEraValid
-	if activeEraValid {
-		log.Printf("✅ Active era verification passed")
+		failures = append(failures, fmt.Sprintf("Active era verification failed: %v", err))
+		v.log().DebugContext(ctx, "active era verification failed", "event", "verify_v2", "error", err)
+	} else if activeEraValid {
+		result.ActiveEraValidation = true
+		progress(StageEraOK)
 	} else {
-		log.Printf("❌ Active era verification failed")
+		// Explain an inactive nomination that is suppressed rather than withdrawn
+		suppressed, err := v.nominationSuppressed(ctx, nominatorAddress)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("Nomination suppression check failed: %v", err))
+		} else if suppressed {
+			notes = append(notes, "nomination is suppressed and not backing any validator")
+		}
 	}
 
-	// Step 5: Determine overall validity
-	// For V2, we require both storage validation and active era validation
-	// Extrinsic validation is not required in V2
-	result.IsValid = result.StorageValidation && result.ActiveEraValidation
-
-	if result.IsValid {
-		log.Printf("✅ VerifyV2: Delegation verification SUCCESSFUL")
-	} else {
-		log.Printf("❌ VerifyV2: Delegation verification FAILED")
+	// Optionally report where the nominator's rewards are paid
+	if v.includePayee {
+		payee, err := v.GetPayee(ctx, nominatorAddress)
+		if err != nil {
+			v.log().WarnContext(ctx, "failed to get reward destination", "event", "verify_v2", "nominator", nominatorAddress, "error", err)
+		} else {
+			result.Payee = &payee
+		}
 	}
 
-	return result, nil
-}
-
-// DelegationVerificationResult represents the result of a comprehensive delegation verification
-type DelegationVerificationResult struct {
-	NominatorAddress    string    `json:"nominatorAddress"`
-	ValidatorAddress    string    `json:"validatorAddress"`
-	ExtrinsicHash       string    `json:"extrinsicHash,omitempty"`
-	Timestamp           time.Time `json:"timestamp"`
-	IsValid             bool      `json:"isValid"`
-	AddressValidation   bool      `json:"addressValidation"`
-	ExtrinsicValidation bool      `json:"extrinsicValidation"`
-	StorageValidation   bool      `json:"storageValidation"`
-	ActiveEraValidation bool      `json:"activeEraValidation"`
-	Error               string    `json:"error,omitempty"`
-	AdditionalInfo      string    `json:"additionalInfo,omitempty"`
-}
-
-// validateAddresses performs basic validation on the provided addresses
-func (v *Verifier) validateAddresses(nominatorAddress, validatorAddress string) error {
-	// Check if addresses are not empty
-	if nominatorAddress == "" || validatorAddress == "" {
-		return fmt.Errorf("nominator and validator addresses cannot be empty")
+	// Step 5: Stake verification. A nomination without active stake backs nothing, even while
+	// its targets are still listed, and one whose stake is unlocking is winding down.
+	var activeStake *big.Int
+	ledger, err := v.getNominatorLedger(ctx, nominatorAddress)
+	if err != nil {
+		failures = append(failures, fmt.Sprintf("Stake verification failed: %v", err))
+		v.log().DebugContext(ctx, "stake verification failed", "event", "verify_v2", "error", err)
+	} else {
+		activeStake, result.TotalStake = new(big.Int), new(big.Int)
+		if ledger != nil {
+			activeStake, result.TotalStake = ledger.Active, ledger.Total
+			result.Unbonding = ledger.Unbonding()
+		}
+		result.ActiveStake = activeStake
+		result.TokenDecimals = v.network.TokenDecimals
+		if activeStake.Sign() == 0 {
+			notes = append(notes, "nominator has no active stake")
+		}
+		if result.Unbonding {
+			notes = append(notes, fmt.Sprintf("nominator is unbonding %s planck", new(big.Int).Sub(ledger.Total, ledger.Active)))
+		}
+		v.log().DebugContext(ctx, "stake checked", "event", "verify_v2", "nominator", nominatorAddress,
+			"active_stake", activeStake.String(), "total_stake", result.TotalStake.String(), "unbonding", result.Unbonding)
 	}
 
-	// Check minimum length (basic validation)
-	if len(nominatorAddress) < 10 || len(validatorAddress) < 10 {
-		return fmt.Errorf("addresses appear to be too short")
+	// Optionally require a minimum active bond, reusing the active stake just read
+	if v.minBonded != nil && activeStake != nil {
+		result.BondedThresholdValidation = activeStake.Cmp(v.minBonded) >= 0
+		result.BondedAmount = activeStake.String()
+		v.log().DebugContext(ctx, "bonded threshold checked", "event", "verify_v2", "nominator", nominatorAddress,
+			"bonded", result.BondedAmount, "min_bonded", v.minBonded.String(), "meets_threshold", result.BondedThresholdValidation)
 	}
 
-	// Check if addresses are different
-	if nominatorAddress == validatorAddress {
-		return fmt.Errorf("nominator and validator addresses cannot be the same")
+	// Optionally check the nominator is within the validator's rewarded backers
+	if v.checkOverSubscribed {
+		overSubscribed, position, err := v.CheckOverSubscribed(ctx, nominatorAddress, validatorAddress)
+		if err != nil {
+			v.log().WarnContext(ctx, "failed to check validator exposure", "event", "verify_v2", "validator", validatorAddress, "error", err)
+		} else if overSubscribed {
+			result.OverSubscribed = true
+			notes = append(notes, fmt.Sprintf("validator is over-subscribed: nominator ranks %d, past the %d rewarded backers", position+1, v.maxNominatorRewarded))
+		}
 	}
 
-	// Check for valid format - support both hex (0x) and SS58 (Polkadot) addresses
-	// Nominator address should be hex format
-	if !strings.HasPrefix(nominatorAddress, "0x") {
-		return fmt.Errorf("nominator address must be in hex format starting with 0x")
-	}
+	// Step 6: Determine overall validity
+	// For V2, we require valid addresses, storage validation, active era validation and a
+	// non-zero active stake, plus the bonded threshold when one is configured
+	// Extrinsic validation is not required in V2
+	result.IsValid = result.AddressValidation && result.StorageValidation && result.ActiveEraValidation &&
+		activeStake != nil && activeStake.Sign() > 0 &&
+		(v.minBonded == nil || result.BondedThresholdValidation)
+	result.Error = strings.Join(failures, "; ")
+	result.AdditionalInfo = strings.Join(append([]string{v2Summary(result)}, notes...), "; ")
 
-	// Validator address can be either hex format or SS58 format (Polkadot addresses)
-	// SS58 addresses typically start with numbers and are base58 encoded
-	if !strings.HasPrefix(validatorAddress, "0x") && !v.isValidSS58Address(validatorAddress) {
-		return fmt.Errorf("validator address must be in hex format (0x...) or valid SS58 format")
-	}
+	v.log().InfoContext(ctx, "delegation verified", "event", "delegation_verified", "nominator", nominatorAddress, "validator", validatorAddress,
+		"valid", result.IsValid, "error", result.Error, "duration_ms", time.Since(start).Milliseconds())
 
-	return nil
+	v.cacheResult(ctx, cacheKey, result)
+	return result, nil
 }
 
-// isValidSS58Address checks if an address is in valid SS58 format
-func (v *Verifier) isValidSS58Address(address string) bool {
-	// SS58 addresses are base58 encoded and typically start with numbers
-	// They have a specific length range and character set
-	if len(address) < 32 || len(address) > 48 {
-		return false
-	}
-
-	// Check if it starts with a number (common for Polkadot addresses)
-	if len(address) > 0 && (address[0] >= '0' && address[0] <= '9') {
-		return true
+// v2Summary describes the outcome of each VerifyV2 check in one line
+func v2Summary(result *DelegationVerificationResult) string {
+	outcome := func(passed bool) string {
+		if passed {
+			return "passed"
+		}
+		return "failed"
 	}
 
-	// Check if it starts with letters (some SS58 addresses do)
-	if len(address) > 0 && (address[0] >= 'A' && address[0] <= 'Z') {
-		return true
-	}
+	return fmt.Sprintf("address %s, storage %s, active era %s, extrinsic skipped",
+		outcome(result.AddressValidation), outcome(result.StorageValidation), outcome(result.ActiveEraValidation))
+}
 
-	return false
+// DelegationVerificationResult represents the result of a comprehensive delegation verification
+type DelegationVerificationResult struct {
+	NominatorAddress    string            `json:"nominatorAddress"`
+	ValidatorAddress    string            `json:"validatorAddress"`
+	ExtrinsicHash       string            `json:"extrinsicHash,omitempty"`
+	Timestamp           time.Time         `json:"timestamp"`
+	IsValid             bool              `json:"isValid"`
+	AddressValidation   bool              `json:"addressValidation"`
+	ExtrinsicValidation bool              `json:"extrinsicValidation"`
+	StorageValidation   bool              `json:"storageValidation"`
+	ActiveEraValidation bool              `json:"activeEraValidation"`
+	Error               string            `json:"error,omitempty"`
+	AdditionalInfo      string            `json:"additionalInfo,omitempty"`
+	Payee               *PayeeDestination `json:"payee,omitempty"`
+	// BondedThresholdValidation and BondedAmount are only set when MinBonded is configured
+	BondedThresholdValidation bool   `json:"bondedThresholdValidation"`
+	BondedAmount              string `json:"bondedAmount,omitempty"`
+	// ActiveStake and TotalStake are the nominator's active and total bond in planck, where the total
+	// also counts unlocking funds, and TokenDecimals is the chain's for formatting them. Unbonding is
+	// set while part of the bond is unlocking. VerifyV2 sets all four.
+	ActiveStake   *big.Int `json:"activeStake,omitempty"`
+	TotalStake    *big.Int `json:"totalStake,omitempty"`
+	TokenDecimals uint8    `json:"tokenDecimals,omitempty"`
+	Unbonding     bool     `json:"unbonding"`
+	// OverSubscribed is set when the nominator backs the validator but ranks past the rewarded cap
+	OverSubscribed bool `json:"overSubscribed"`
+	// FromCache is set when the result was served from the result cache without any RPC
+	FromCache bool `json:"fromCache"`
+	// NominatedValidators lists the validators the nominator targets when the requested one isn't among them
+	NominatedValidators []string `json:"nominatedValidators,omitempty"`
+	// BlockHash is the block the nominator's Staking.Nominators entry was read at, when known
+	BlockHash string `json:"blockHash,omitempty"`
 }
 
-// verifyDelegationByStorage performs storage-based verification of delegation
-func (v *Verifier) verifyDelegationByStorage(nominatorAddress, validatorAddress string) (bool, error) {
-	log.Printf("🔍 Verifying delegation through storage queries")
+// VerificationResult is the result VerifyV2 returns; each sub-check is reported independently
+type VerificationResult = DelegationVerificationResult
 
-	// Query the Staking.Nominators storage for the nominator
-	// This is a simplified implementation - in production you'd need proper storage key encoding
-	request := RPCRequest{
-		JSONRPC: "2.0",
-		Method:  "state_getStorage",
-		Params: []interface{}{
-			"0x5f3e4907f716ac89b6347d15ececedca3ed14b45ed20d054f05e37e2542cfe70", // Staking.Nominators
-		},
-		ID: 1,
+// validateAddresses checks that both addresses are 0x-prefixed hex AccountIds or SS58 addresses
+// of the verifier's network, and that they name different accounts
+func (v *Verifier) validateAddresses(nominatorAddress, validatorAddress string) error {
+	if nominatorAddress == "" || validatorAddress == "" {
+		return fmt.Errorf("nominator and validator addresses cannot be empty")
 	}
 
-	result, err := v.makeRPCCall(request)
+	nominatorID, err := v.validateAddress(nominatorAddress)
 	if err != nil {
-		return false, fmt.Errorf("failed to query staking storage: %w", err)
+		return fmt.Errorf("invalid nominator address: %w", err)
+	}
+	validatorID, err := v.validateAddress(validatorAddress)
+	if err != nil {
+		return fmt.Errorf("invalid validator address: %w", err)
 	}
 
-	log.Printf("📋 Storage query result: %v", result)
-
-	// For now, we'll use a simplified check
-	// In a real implementation, you would:
-	// 1. Properly encode the storage key for the specific nominator
-	// 2. Decode the nomination data
-	// 3. Check if the validator is in the targets list
-	// 4. Verify the nomination is still active
-
-	// Simulate a successful storage verification
-	// This should be replaced with actual storage decoding logic
-	log.Printf("⚠️  Using simplified storage verification - replace with actual storage decoding")
-	return true, nil
+	if bytes.Equal(nominatorID, validatorID) {
+		return fmt.Errorf("nominator and validator addresses cannot be the same")
+	}
+	return nil
 }
 
-// verifyActiveEra verifies that the delegation is active in the current era
-func (v *Verifier) verifyActiveEra(nominatorAddress, validatorAddress string) (bool, error) {
-	log.Printf("🔍 Verifying delegation is active in current era")
-
-	// Get the current active era
-	activeEra, err := v.getActiveEra()
+// validateAddress decodes an address to its AccountId, requiring an SS58 address to be encoded
+// for the verifier's network
+func (v *Verifier) validateAddress(address string) ([]byte, error) {
+	accountID, err := accountIDFromAddress(address)
 	if err != nil {
-		return false, fmt.Errorf("failed to get active era: %w", err)
+		return nil, err
 	}
+	if !strings.HasPrefix(address, "0x") {
+		if err := v.network.ValidateAddress(address); err != nil {
+			return nil, &AddressError{address, err}
+		}
+	}
+	return accountID, nil
+}
 
-	log.Printf("📅 Current active era: %v", activeEra)
-
-	// In a real implementation, you would:
-	// 1. Query the nomination era for this specific nominator-validator pair
-	// 2. Compare it with the current active era
-	// 3. Check if the nomination is still within the active period
+// verifyDelegationByStorage performs storage-based verification of delegation: the nominator's
+// Staking.Nominators entry must target the validator
+func (v *Verifier) verifyDelegationByStorage(ctx context.Context, nominatorAddress, validatorAddress string) (bool, error) {
+	return v.checkIfNominated(ctx, nominatorAddress, validatorAddress)
+}
 
-	// For now, we'll assume the nomination is active if we can get the era
-	// This should be replaced with actual era comparison logic
-	log.Printf("⚠️  Using simplified era verification - replace with actual era comparison")
-	return true, nil
+// verifyActiveEra verifies that the delegation is active in the current era
+func (v *Verifier) verifyActiveEra(ctx context.Context, nominatorAddress, validatorAddress string) (bool, error) {
+	return v.checkIfActive(ctx, nominatorAddress, validatorAddress)
 }
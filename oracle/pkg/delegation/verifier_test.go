@@ -1,8 +1,19 @@
 package delegation
 
 import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"log"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestVerifyV2_RealPolkadotAddresses(t *testing.T) {
@@ -91,3 +102,221 @@ func TestVerifyV2_RealPolkadotAddresses(t *testing.T) {
 
 	log.Printf("🎉 TestVerifyV2_RealPolkadotAddresses completed successfully")
 }
+
+func TestVerifyV2_ReportsEachCheckIndependently(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyV2_ReportsEachCheckIndependently")
+
+	nominatorID := bytes.Repeat([]byte{0x01}, 32)
+	validatorID := bytes.Repeat([]byte{0x02}, 32)
+
+	// The nomination targets the validator but only takes effect after the active era
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		switch method {
+		case "chain_getFinalizedHead":
+			return testBlockHash, nil
+		case "state_getStorage":
+			switch params[0] {
+			case nominatorsStorageKey(nominatorID):
+				return nominationsHex([][]byte{validatorID}, 1001, false), nil
+			case activeEraStorageKey():
+				return activeEraHex(1000, 0), nil
+			case bondedStorageKey(nominatorID):
+				return "0x" + hex.EncodeToString(nominatorID), nil
+			case ledgerStorageKey(nominatorID):
+				return ledgerHex(nominatorID, big.NewInt(1_000_000_000_000)), nil
+			}
+			return nil, nil
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+	verifier := NewVerifier(server.URL)
+
+	var result *VerificationResult
+	result, err := verifier.VerifyV2("0x"+hex.EncodeToString(nominatorID), "0x"+hex.EncodeToString(validatorID))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !result.AddressValidation || !result.StorageValidation {
+		t.Errorf("Expected address and storage validation to pass, got %+v", result)
+	}
+	if result.ActiveEraValidation || result.ExtrinsicValidation || result.IsValid {
+		t.Errorf("Expected active era and extrinsic validation and overall validity to fail, got %+v", result)
+	}
+	if result.Error != "" {
+		t.Errorf("Expected no error for a check that ran and failed, got %q", result.Error)
+	}
+	if result.AdditionalInfo != "address passed, storage passed, active era failed, extrinsic skipped" {
+		t.Errorf("Unexpected summary: %q", result.AdditionalInfo)
+	}
+	log.Printf("✅ Partial failure reported: %s", result.AdditionalInfo)
+
+	// Invalid addresses don't stop the remaining checks from reporting
+	result, err = verifier.VerifyV2("bad", "addresses")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	for _, step := range []string{"Address validation failed", "Storage verification failed", "Active era verification failed"} {
+		if !strings.Contains(result.Error, step) {
+			t.Errorf("Expected error to include %q, got %q", step, result.Error)
+		}
+	}
+	log.Printf("✅ Every failing check reported: %s", result.Error)
+}
+
+// newSlowRPCServer starts a server that holds every request until the test ends
+func newSlowRPCServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(func() {
+		close(release)
+		server.Close()
+	})
+	return server
+}
+
+func TestMakeRPCCall_Timeout(t *testing.T) {
+	log.Printf("🧪 Starting TestMakeRPCCall_Timeout")
+
+	server := newSlowRPCServer(t)
+	verifier := NewVerifier(server.URL, 50*time.Millisecond)
+	verifier.SetMaxRetries(0)
+
+	start := time.Now()
+	_, err := verifier.makeRPCCall(RPCRequest{JSONRPC: "2.0", Method: "system_health", Params: []interface{}{}, ID: 1})
+	if err == nil {
+		t.Fatalf("Expected the hung RPC call to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("Expected the timeout to fire promptly, took %s", elapsed)
+	}
+	log.Printf("✅ Hung RPC call timed out: %v", err)
+}
+
+func TestMakeRPCCallCtx_Cancelled(t *testing.T) {
+	log.Printf("🧪 Starting TestMakeRPCCallCtx_Cancelled")
+
+	server := newSlowRPCServer(t)
+	verifier := NewVerifier(server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := verifier.makeRPCCallCtx(ctx, RPCRequest{JSONRPC: "2.0", Method: "system_health", Params: []interface{}{}, ID: 1})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got: %v", err)
+	}
+	log.Printf("✅ Cancelled context aborted the RPC call: %v", err)
+}
+
+func TestSetLogger_EmitsStructuredEvents(t *testing.T) {
+	log.Printf("🧪 Starting TestSetLogger_EmitsStructuredEvents")
+
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		switch method {
+		case "chain_getFinalizedHead":
+			return testBlockHash, nil
+		case "state_getStorage":
+			if len(params) > 1 {
+				return nominationsHex([][]byte{aliceAccountID}, 1, false), nil
+			}
+			return activeEraHex(1, 0), nil
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+	verifier := NewVerifier(server.URL)
+
+	var buf bytes.Buffer
+	verifier.SetLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+
+	nominator := "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty"
+	validator := "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+	if ok, err := verifier.VerifyDelegation(nominator, validator); err != nil || !ok {
+		t.Fatalf("Expected delegation to verify, got %v, %v", ok, err)
+	}
+
+	// Debug lines are filtered out at info level, leaving the single outcome event
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("Expected one info-level log line, got %d:\n%s", len(lines), buf.String())
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("Log line is not JSON: %v", err)
+	}
+	log.Printf("📋 Log entry: %v", entry)
+
+	if entry["event"] != "delegation_verified" || entry["nominator"] != nominator || entry["validator"] != validator || entry["delegated"] != true {
+		t.Errorf("Unexpected log entry: %v", entry)
+	}
+	if _, ok := entry["duration_ms"].(float64); !ok {
+		t.Errorf("Expected a numeric duration_ms, got %v", entry["duration_ms"])
+	}
+	log.Printf("✅ Verification logged as a structured event")
+}
+
+func TestParseBlockNumber(t *testing.T) {
+	log.Printf("🧪 Starting TestParseBlockNumber")
+
+	valid := map[string]struct {
+		number interface{}
+		want   int64
+	}{
+		"prefixed hex":   {"0x1a2b", 0x1a2b},
+		"unprefixed hex": {"1a2b", 0x1a2b},
+		"json number":    {float64(6699), 6699},
+	}
+	for name, tc := range valid {
+		got, err := parseBlockNumber(tc.number)
+		if err != nil {
+			t.Fatalf("%s: expected %v to parse, got: %v", name, tc.number, err)
+		}
+		if got != tc.want {
+			t.Fatalf("%s: expected %d, got %d", name, tc.want, got)
+		}
+		log.Printf("✅ %s parsed as %d", name, got)
+	}
+
+	invalid := map[string]interface{}{
+		"empty":           "",
+		"not hex":         "0xzz",
+		"negative":        "-0x10",
+		"fractional":      1.5,
+		"negative number": float64(-1),
+		"null":            nil,
+	}
+	for name, number := range invalid {
+		if _, err := parseBlockNumber(number); err == nil {
+			t.Fatalf("%s: expected %v to be rejected", name, number)
+		}
+		log.Printf("✅ %s rejected", name)
+	}
+}
+
+func TestGetLatestBlockNumber_NumericHeader(t *testing.T) {
+	log.Printf("🧪 Starting TestGetLatestBlockNumber_NumericHeader")
+
+	server := newMockRPCServer(t, func(method string, params []interface{}) (interface{}, *RPCError) {
+		if method == "chain_getHeader" {
+			return map[string]interface{}{"number": 6699}, nil
+		}
+		return nil, &RPCError{Code: -32601, Message: "method not found"}
+	})
+	verifier := NewVerifier(server.URL)
+
+	number, err := verifier.getLatestBlockNumber(context.Background())
+	if err != nil {
+		t.Fatalf("Expected numeric block number to parse, got: %v", err)
+	}
+	if number != 6699 {
+		t.Fatalf("Expected block 6699, got %d", number)
+	}
+	log.Printf("✅ Numeric header block number parsed as %d", number)
+}
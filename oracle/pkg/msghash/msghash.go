@@ -0,0 +1,68 @@
+// Package msghash holds the encodings the signing oracle and the signature verifier both build
+// signed messages from, so what one signs is always what the other hashes
+package msghash
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// EncodeStrings encodes values as Solidity's abi.encode(string, ...) does: a 32-byte offset per
+// value, followed by each value's 32-byte length and its UTF-8 bytes, zero-padded to a multiple
+// of 32. Every string carries its length, so ("ab", "c") and ("a", "bc") encode differently.
+func EncodeStrings(values ...string) []byte {
+	head := make([]byte, 0, 32*len(values))
+	var tail []byte
+	for _, value := range values {
+		head = append(head, word(uint64(32*len(values)+len(tail)))...)
+		tail = append(tail, word(uint64(len(value)))...)
+		tail = append(tail, value...)
+		if rem := len(value) % 32; rem != 0 {
+			tail = append(tail, make([]byte, 32-rem)...)
+		}
+	}
+	return append(head, tail...)
+}
+
+// PackStrings packs values as Solidity's abi.encodePacked(string, ...) does: their UTF-8 bytes,
+// concatenated without lengths or padding
+func PackStrings(values ...string) []byte {
+	return []byte(strings.Join(values, ""))
+}
+
+// PackUint32 packs a uint32 as abi.encodePacked does: 4 big-endian bytes
+func PackUint32(value uint32) []byte {
+	encoded := make([]byte, 4)
+	binary.BigEndian.PutUint32(encoded, value)
+	return encoded
+}
+
+// PackUint64 packs a uint64 as abi.encodePacked does: 8 big-endian bytes
+func PackUint64(value uint64) []byte {
+	encoded := make([]byte, 8)
+	binary.BigEndian.PutUint64(encoded, value)
+	return encoded
+}
+
+// word encodes a uint64 as a 32-byte big-endian uint256 word
+func word(value uint64) []byte {
+	encoded := make([]byte, 32)
+	binary.BigEndian.PutUint64(encoded[24:], value)
+	return encoded
+}
+
+// ValidateMessagePrefix checks that a personal message prefix follows the EIP-191 layout:
+// the 0x19 byte, a chain-specific name and a trailing ":\n"
+func ValidateMessagePrefix(prefix string) error {
+	if !strings.HasPrefix(prefix, "\x19") {
+		return fmt.Errorf("message prefix must start with the 0x19 byte")
+	}
+	if !strings.HasSuffix(prefix, ":\n") {
+		return fmt.Errorf("message prefix must end with \":\\n\"")
+	}
+	if len(prefix) <= len("\x19:\n") {
+		return fmt.Errorf("message prefix must name the chain")
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package msghash
+
+import (
+	"bytes"
+	"encoding/hex"
+	"log"
+	"math/big"
+	"testing"
+)
+
+func TestEncodeStrings(t *testing.T) {
+	log.Printf("🧪 Starting TestEncodeStrings")
+
+	// abi.encode("ab", "c"): two offsets, then each length and its padded bytes
+	want := "" +
+		"0000000000000000000000000000000000000000000000000000000000000040" +
+		"0000000000000000000000000000000000000000000000000000000000000080" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"6162000000000000000000000000000000000000000000000000000000000000" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"6300000000000000000000000000000000000000000000000000000000000000"
+	if got := hex.EncodeToString(EncodeStrings("ab", "c")); got != want {
+		t.Fatalf("❌ Unexpected encoding:\n%s\n%s", got, want)
+	}
+	if bytes.Equal(EncodeStrings("ab", "c"), EncodeStrings("a", "bc")) {
+		t.Fatalf("❌ Expected (\"ab\", \"c\") and (\"a\", \"bc\") to encode differently")
+	}
+	log.Printf("✅ Strings are offset, length-prefixed and padded")
+
+	// Strings are length-prefixed with their byte length, not their rune count
+	msgText := "délégation ✓"
+	encoded := EncodeStrings(msgText)
+	if length := new(big.Int).SetBytes(encoded[32:64]); length.Int64() != int64(len([]byte(msgText))) {
+		t.Fatalf("❌ Expected a length of %d bytes, got %s", len([]byte(msgText)), length)
+	}
+	if !bytes.Equal(encoded[64:64+len(msgText)], []byte(msgText)) {
+		t.Fatalf("❌ Expected the message's UTF-8 bytes, got %x", encoded[64:])
+	}
+	if len(EncodeStrings("")) != 64 {
+		t.Fatalf("❌ Expected an empty string to encode as its offset and a zero length, got %x", EncodeStrings(""))
+	}
+	log.Printf("✅ Unicode and empty strings are encoded as their UTF-8 bytes")
+}
+
+func TestPack(t *testing.T) {
+	log.Printf("🧪 Starting TestPack")
+
+	if got := string(PackStrings("ab", "c")); got != "abc" {
+		t.Fatalf("❌ Expected the packed strings to concatenate, got %q", got)
+	}
+	if got := hex.EncodeToString(PackUint32(1523)); got != "000005f3" {
+		t.Fatalf("❌ Unexpected packed uint32: %s", got)
+	}
+	if got := hex.EncodeToString(PackUint64(1700000600)); got != "000000006553f358" {
+		t.Fatalf("❌ Unexpected packed uint64: %s", got)
+	}
+	log.Printf("✅ Values are packed at their fixed width")
+}
+
+func TestValidateMessagePrefix(t *testing.T) {
+	log.Printf("🧪 Starting TestValidateMessagePrefix")
+
+	for _, prefix := range []string{"\x19Ethereum Signed Message:\n", "\x19TRON Signed Message:\n"} {
+		if err := ValidateMessagePrefix(prefix); err != nil {
+			t.Fatalf("❌ Expected %q to be accepted: %v", prefix, err)
+		}
+	}
+	for _, prefix := range []string{"", "Ethereum Signed Message:\n", "\x19Ethereum Signed Message", "\x19:\n"} {
+		if err := ValidateMessagePrefix(prefix); err == nil {
+			t.Fatalf("❌ Expected %q to be rejected", prefix)
+		}
+	}
+	log.Printf("✅ Only EIP-191 prefixes are accepted")
+}
@@ -0,0 +1,159 @@
+package signatureverifier
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// SignatureEncoding selects how a signature string is decoded
+type SignatureEncoding int
+
+const (
+	// SignatureEncodingAuto treats 0x-prefixed or 130-character hex strings as hex and anything else as base64
+	SignatureEncodingAuto SignatureEncoding = iota
+	// SignatureEncodingHex decodes hex, with or without a "0x" prefix
+	SignatureEncodingHex
+	// SignatureEncodingBase64 decodes standard or URL-safe base64, padded or not
+	SignatureEncodingBase64
+)
+
+// normalizeV converts a recovery id from the Ethereum {27,28} convention to the
+// {0,1} convention expected by crypto.Ecrecover. Other values are returned unchanged.
+func normalizeV(v byte) byte {
+	if v == 27 || v == 28 {
+		return v - 27
+	}
+	return v
+}
+
+// AssembleSignature builds the 65-byte r||s||v signature from its components.
+// v may be given in either the {0,1} or {27,28} convention; the returned
+// signature always uses {0,1} so it can be passed straight to recoverSigner.
+func AssembleSignature(r, s [32]byte, v byte) []byte {
+	signature := make([]byte, 65)
+	copy(signature[:32], r[:])
+	copy(signature[32:64], s[:])
+	signature[64] = normalizeV(v)
+	return signature
+}
+
+// ParseCompactSignature expands a 64-byte EIP-2098 signature, r || yParityAndS, where the
+// recovery bit is the top bit of s, into the standard 65-byte r || s || v form with v in {0,1}
+func ParseCompactSignature(compact []byte) ([]byte, error) {
+	if len(compact) != 64 {
+		return nil, fmt.Errorf("invalid compact signature length: expected 64, got %d", len(compact))
+	}
+
+	signature := make([]byte, 65)
+	copy(signature, compact)
+	signature[64] = compact[32] >> 7
+	signature[32] &= 0x7f
+	return signature, nil
+}
+
+// CompactSignature folds the recovery id of a 65-byte signature into the top bit of s,
+// producing the 64-byte EIP-2098 form. Signatures with a high s can't be compacted.
+func CompactSignature(signature []byte) ([]byte, error) {
+	if len(signature) != 65 {
+		return nil, fmt.Errorf("invalid signature length: expected 65, got %d", len(signature))
+	}
+	v := normalizeV(signature[64])
+	if v > 1 {
+		return nil, fmt.Errorf("invalid signature recovery id: %d", signature[64])
+	}
+	if signature[32]&0x80 != 0 {
+		return nil, fmt.Errorf("signature s is not in the lower half order and can't be compacted")
+	}
+
+	compact := make([]byte, 64)
+	copy(compact, signature[:64])
+	compact[32] |= v << 7
+	return compact, nil
+}
+
+// expandSignature returns a 65-byte signature as is and expands a 64-byte EIP-2098 one
+func expandSignature(signature []byte) ([]byte, error) {
+	switch len(signature) {
+	case 65:
+		return signature, nil
+	case 64:
+		return ParseCompactSignature(signature)
+	default:
+		return nil, fmt.Errorf("invalid signature length: expected 64 or 65, got %d", len(signature))
+	}
+}
+
+// ParseSignature splits a hex-encoded 65-byte or compact 64-byte (EIP-2098) signature into its
+// r, s and v components. The "0x" prefix is optional and v is normalized to the {0,1} convention.
+func ParseSignature(sigHex string) (r, s [32]byte, v byte, err error) {
+	signature, err := hex.DecodeString(strings.TrimPrefix(sigHex, "0x"))
+	if err != nil {
+		return r, s, 0, fmt.Errorf("invalid signature hex: %w", err)
+	}
+
+	signature, err = expandSignature(signature)
+	if err != nil {
+		return r, s, 0, err
+	}
+
+	v = normalizeV(signature[64])
+	if v > 1 {
+		return r, s, 0, fmt.Errorf("invalid signature recovery id: %d", signature[64])
+	}
+
+	copy(r[:], signature[:32])
+	copy(s[:], signature[32:64])
+	return r, s, v, nil
+}
+
+// isHexSignature reports whether sig looks like a hex-encoded 65-byte or compact 64-byte signature
+func isHexSignature(sig string) bool {
+	if strings.HasPrefix(sig, "0x") {
+		return true
+	}
+	if len(sig) != 130 && len(sig) != 128 {
+		return false
+	}
+	_, err := hex.DecodeString(sig)
+	return err == nil
+}
+
+// decodeBase64Signature accepts the standard and URL-safe alphabets with or without padding
+func decodeBase64Signature(sig string) ([]byte, error) {
+	trimmed := strings.TrimRight(sig, "=")
+	if strings.ContainsAny(trimmed, "-_") {
+		return base64.RawURLEncoding.DecodeString(trimmed)
+	}
+	return base64.RawStdEncoding.DecodeString(trimmed)
+}
+
+// DecodeSignature decodes a signature string in the given encoding.
+// A 65-byte signature is 130 hex characters or 88 base64 characters (87 unpadded); a compact
+// 64-byte one is 128 hex characters.
+func DecodeSignature(sig string, encoding SignatureEncoding) ([]byte, error) {
+	if encoding == SignatureEncodingAuto {
+		encoding = SignatureEncodingBase64
+		if isHexSignature(sig) {
+			encoding = SignatureEncodingHex
+		}
+	}
+
+	switch encoding {
+	case SignatureEncodingHex:
+		signature, err := hex.DecodeString(strings.TrimPrefix(sig, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature hex: %w", err)
+		}
+		return signature, nil
+	case SignatureEncodingBase64:
+		signature, err := decodeBase64Signature(sig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature base64: %w", err)
+		}
+		return signature, nil
+	default:
+		return nil, fmt.Errorf("unsupported signature encoding: %d", encoding)
+	}
+}
@@ -0,0 +1,307 @@
+package signatureverifier
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// TestParseAndAssembleSignatureRoundTrip splits a signature into r, s, v and rebuilds it
+func TestParseAndAssembleSignatureRoundTrip(t *testing.T) {
+	log.Printf("🧪 Starting TestParseAndAssembleSignatureRoundTrip")
+
+	privateKeyHex := "1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+	privateKey, err := crypto.HexToECDSA(privateKeyHex)
+	if err != nil {
+		t.Fatalf("Failed to load private key: %v", err)
+	}
+
+	verifier, err := NewOracleVerifiedDelegation(crypto.PubkeyToAddress(privateKey.PublicKey).Hex())
+	if err != nil {
+		t.Fatalf("Failed to create verifier: %v", err)
+	}
+
+	validatorAddress := "5GNJqTPyNqANBkUVMN1LPPrxXnFouWXoe2wNSmmEoLctxiZY"
+	nominatorAddress := "5DfQJkzFUGDy3JUJW4ZBuERyrN7nVfPbxYtXAkfHQ7KkMtFU"
+	msgText := "msg"
+
+	signatureHex, err := verifier.CreateValidSignature(validatorAddress, nominatorAddress, msgText, privateKeyHex)
+	if err != nil {
+		t.Fatalf("Failed to create signature: %v", err)
+	}
+	log.Printf("📋 Signature: %s", signatureHex)
+
+	r, s, v, err := ParseSignature("0x" + signatureHex)
+	if err != nil {
+		t.Fatalf("Failed to parse signature: %v", err)
+	}
+	log.Printf("📋 r: %x", r)
+	log.Printf("📋 s: %x", s)
+	log.Printf("📋 v: %d", v)
+
+	assembled := AssembleSignature(r, s, v)
+	if hex.EncodeToString(assembled) != signatureHex {
+		t.Fatalf("Round trip mismatch: expected %s, got %x", signatureHex, assembled)
+	}
+
+	// The {27,28} convention must produce the same bytes
+	assembledLegacy := AssembleSignature(r, s, v+27)
+	if !bytes.Equal(assembled, assembledLegacy) {
+		t.Fatalf("Expected v=%d and v=%d to assemble identically", v, v+27)
+	}
+
+	// A signature carrying v in {27,28} must parse back to the same components
+	legacyHex := hex.EncodeToString(append(append(r[:], s[:]...), v+27))
+	r2, s2, v2, err := ParseSignature(legacyHex)
+	if err != nil {
+		t.Fatalf("Failed to parse {27,28} signature: %v", err)
+	}
+	if r2 != r || s2 != s || v2 != v {
+		t.Fatalf("Parsed {27,28} signature does not match original components")
+	}
+
+	if err := verifier.SubmitMessage(validatorAddress, nominatorAddress, msgText, hex.EncodeToString(AssembleSignature(r2, s2, v2))); err != nil {
+		t.Fatalf("Reassembled signature failed verification: %v", err)
+	}
+
+	log.Printf("✅ Signature round-tripped through parse/assemble")
+}
+
+// TestParseSignatureRejectsMalformedInput checks the error paths of ParseSignature
+func TestParseSignatureRejectsMalformedInput(t *testing.T) {
+	log.Printf("🧪 Starting TestParseSignatureRejectsMalformedInput")
+
+	validBody := "95cb703ba12c252f827b6f1f935013bfa7c4671083b67795a4e1b915bc3aaf202430f07045a7df61832a71fbaea93e71b6ad65f15ea3eb0a01fc35dd287a2497"
+
+	cases := map[string]string{
+		"bad hex":        "zz",
+		"short":          validBody[:126],
+		"bad recovery":   validBody + "05",
+		"bad recovery 2": validBody + "1d",
+	}
+
+	for name, sigHex := range cases {
+		if _, _, _, err := ParseSignature(sigHex); err == nil {
+			t.Errorf("%s: expected error, got none", name)
+		} else {
+			log.Printf("✅ %s rejected: %v", name, err)
+		}
+	}
+}
+
+// TestSubmitMessageEncodedHexAndBase64 verifies the same signature in every supported encoding
+func TestSubmitMessageEncodedHexAndBase64(t *testing.T) {
+	log.Printf("🧪 Starting TestSubmitMessageEncodedHexAndBase64")
+
+	privateKeyHex := "1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+	privateKey, err := crypto.HexToECDSA(privateKeyHex)
+	if err != nil {
+		t.Fatalf("Failed to load private key: %v", err)
+	}
+
+	verifier, err := NewOracleVerifiedDelegation(crypto.PubkeyToAddress(privateKey.PublicKey).Hex())
+	if err != nil {
+		t.Fatalf("Failed to create verifier: %v", err)
+	}
+
+	validatorAddress := "5GNJqTPyNqANBkUVMN1LPPrxXnFouWXoe2wNSmmEoLctxiZY"
+	nominatorAddress := "5DfQJkzFUGDy3JUJW4ZBuERyrN7nVfPbxYtXAkfHQ7KkMtFU"
+	msgText := "msg"
+
+	signatureHex, err := verifier.CreateValidSignature(validatorAddress, nominatorAddress, msgText, privateKeyHex)
+	if err != nil {
+		t.Fatalf("Failed to create signature: %v", err)
+	}
+	signature, _ := hex.DecodeString(signatureHex)
+
+	cases := []struct {
+		name     string
+		sig      string
+		encoding SignatureEncoding
+	}{
+		{"hex auto", signatureHex, SignatureEncodingAuto},
+		{"0x hex auto", "0x" + signatureHex, SignatureEncodingAuto},
+		{"base64 auto", base64.StdEncoding.EncodeToString(signature), SignatureEncodingAuto},
+		{"unpadded url base64 auto", base64.RawURLEncoding.EncodeToString(signature), SignatureEncodingAuto},
+		{"explicit hex", signatureHex, SignatureEncodingHex},
+		{"explicit base64", base64.StdEncoding.EncodeToString(signature), SignatureEncodingBase64},
+	}
+
+	for _, tc := range cases {
+		if err := verifier.SubmitMessageEncoded(validatorAddress, nominatorAddress, msgText, tc.sig, tc.encoding); err != nil {
+			t.Errorf("%s: expected signature to verify, got: %v", tc.name, err)
+		} else {
+			log.Printf("✅ %s verified", tc.name)
+		}
+	}
+
+	if err := verifier.SubmitMessageEncoded(validatorAddress, nominatorAddress, msgText, signatureHex, SignatureEncodingBase64); err == nil {
+		t.Errorf("Expected a hex signature decoded as base64 to be rejected")
+	}
+}
+
+// TestCompactSignatureRoundTrip converts signatures to EIP-2098 and back, and verifies both forms
+func TestCompactSignatureRoundTrip(t *testing.T) {
+	log.Printf("🧪 Starting TestCompactSignatureRoundTrip")
+
+	privateKeyHex := "1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+	privateKey, err := crypto.HexToECDSA(privateKeyHex)
+	if err != nil {
+		t.Fatalf("Failed to load private key: %v", err)
+	}
+
+	verifier, err := NewOracleVerifiedDelegation(crypto.PubkeyToAddress(privateKey.PublicKey).Hex())
+	if err != nil {
+		t.Fatalf("Failed to create verifier: %v", err)
+	}
+
+	validatorAddress := "5GNJqTPyNqANBkUVMN1LPPrxXnFouWXoe2wNSmmEoLctxiZY"
+	nominatorAddress := "5DfQJkzFUGDy3JUJW4ZBuERyrN7nVfPbxYtXAkfHQ7KkMtFU"
+
+	// Signing is deterministic, so these messages reliably cover both recovery ids
+	seenParity := map[byte]bool{}
+	for _, msgText := range []string{"msg-0", "msg-1", "msg-2", "msg-3", "msg-4", "msg-5", "msg-6", "msg-7"} {
+		signatureHex, err := verifier.CreateValidSignature(validatorAddress, nominatorAddress, msgText, privateKeyHex)
+		if err != nil {
+			t.Fatalf("Failed to create signature: %v", err)
+		}
+		signature, _ := hex.DecodeString(signatureHex)
+
+		compact, err := CompactSignature(signature)
+		if err != nil {
+			t.Fatalf("%s: failed to compact signature: %v", msgText, err)
+		}
+		if len(compact) != 64 {
+			t.Fatalf("%s: expected a 64-byte compact signature, got %d bytes", msgText, len(compact))
+		}
+
+		expanded, err := ParseCompactSignature(compact)
+		if err != nil {
+			t.Fatalf("%s: failed to expand compact signature: %v", msgText, err)
+		}
+		expected := AssembleSignature([32]byte(signature[:32]), [32]byte(signature[32:64]), signature[64])
+		if !bytes.Equal(expanded, expected) {
+			t.Fatalf("%s: round trip changed the signature:\n%x\n%x", msgText, expanded, expected)
+		}
+
+		if err := verifier.SubmitMessage(validatorAddress, nominatorAddress, msgText, hex.EncodeToString(compact)); err != nil {
+			t.Fatalf("%s: expected compact signature to verify, got: %v", msgText, err)
+		}
+		seenParity[expanded[64]] = true
+	}
+	if !seenParity[0] || !seenParity[1] {
+		t.Fatalf("Expected both recovery ids to be covered, saw %v", seenParity)
+	}
+	log.Printf("✅ Signatures round-trip through the compact form and verify in both")
+
+	if _, err := ParseCompactSignature(make([]byte, 65)); err == nil {
+		t.Errorf("Expected a 65-byte input to be rejected as a compact signature")
+	}
+	highS := make([]byte, 65)
+	highS[32] = 0x80
+	if _, err := CompactSignature(highS); err == nil {
+		t.Errorf("Expected a high-s signature to be rejected")
+	}
+	log.Printf("✅ Malformed compact inputs rejected")
+}
+
+// TestSubmitMessageRejectsHighS ensures the malleable twin of a valid signature, with s flipped to
+// its complement and the recovery id toggled, is rejected even though it recovers the oracle
+func TestSubmitMessageRejectsHighS(t *testing.T) {
+	log.Printf("🧪 Starting TestSubmitMessageRejectsHighS")
+
+	privateKeyHex := "1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+	privateKey, err := crypto.HexToECDSA(privateKeyHex)
+	if err != nil {
+		t.Fatalf("Failed to load private key: %v", err)
+	}
+
+	verifier, err := NewOracleVerifiedDelegation(crypto.PubkeyToAddress(privateKey.PublicKey).Hex())
+	if err != nil {
+		t.Fatalf("Failed to create verifier: %v", err)
+	}
+
+	validatorAddress := "5GNJqTPyNqANBkUVMN1LPPrxXnFouWXoe2wNSmmEoLctxiZY"
+	nominatorAddress := "5DfQJkzFUGDy3JUJW4ZBuERyrN7nVfPbxYtXAkfHQ7KkMtFU"
+	msgText := "msg"
+
+	signatureHex, err := verifier.CreateValidSignature(validatorAddress, nominatorAddress, msgText, privateKeyHex)
+	if err != nil {
+		t.Fatalf("Failed to create signature: %v", err)
+	}
+	if err := verifier.SubmitMessage(validatorAddress, nominatorAddress, msgText, signatureHex); err != nil {
+		t.Fatalf("Expected the low-s signature to verify, got: %v", err)
+	}
+	log.Printf("✅ Low-s signature accepted")
+
+	signature, _ := hex.DecodeString(signatureHex)
+	highS := new(big.Int).Sub(crypto.S256().Params().N, new(big.Int).SetBytes(signature[32:64]))
+	malleated := append([]byte{}, signature...)
+	highS.FillBytes(malleated[32:64])
+	malleated[64] ^= 1
+	log.Printf("📋 High-s variant: %x", malleated)
+
+	// The twin is a valid signature by the oracle, which is what makes it a malleability vector
+	publicKey, err := crypto.SigToPub(verifier.toEthSignedMessageHash(verifier.createMessageHash(validatorAddress, nominatorAddress, msgText)), malleated)
+	if err != nil || crypto.PubkeyToAddress(*publicKey) != crypto.PubkeyToAddress(privateKey.PublicKey) {
+		t.Fatalf("Expected the high-s variant to recover the oracle, got %v", err)
+	}
+
+	err = verifier.SubmitMessage(validatorAddress, nominatorAddress, msgText, hex.EncodeToString(malleated))
+	if !errors.Is(err, ErrHighS) {
+		t.Fatalf("Expected ErrHighS, got: %v", err)
+	}
+	log.Printf("✅ High-s variant rejected: %v", err)
+
+	malleated[64] += 27
+	if _, err := verifier.RecoverSigner(validatorAddress, nominatorAddress, msgText, SignedFields{}, hex.EncodeToString(malleated)); !errors.Is(err, ErrHighS) {
+		t.Fatalf("Expected RecoverSigner to reject the high-s variant, got: %v", err)
+	}
+	log.Printf("✅ High-s variant with v in {27,28} rejected by RecoverSigner")
+}
+
+// personalSignHash is the hash personal_sign signs for raw under the default prefix
+func personalSignHash(raw []byte) []byte {
+	return crypto.Keccak256([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(raw), raw)))
+}
+
+// TestSubmitPersonalMessage verifies personal_sign signatures over payloads whose length isn't 32
+func TestSubmitPersonalMessage(t *testing.T) {
+	log.Printf("🧪 Starting TestSubmitPersonalMessage")
+
+	privateKey, err := crypto.HexToECDSA("1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")
+	if err != nil {
+		t.Fatalf("Failed to load private key: %v", err)
+	}
+
+	verifier, err := NewOracleVerifiedDelegation(crypto.PubkeyToAddress(privateKey.PublicKey).Hex())
+	if err != nil {
+		t.Fatalf("Failed to create verifier: %v", err)
+	}
+
+	for name, raw := range map[string][]byte{
+		"short string":     []byte("hello"),
+		"100-byte payload": bytes.Repeat([]byte{0xa5}, 100),
+	} {
+		signature, err := crypto.Sign(personalSignHash(raw), privateKey)
+		if err != nil {
+			t.Fatalf("%s: failed to sign: %v", name, err)
+		}
+		signature[64] += 27
+
+		if err := verifier.SubmitPersonalMessage(raw, "0x"+hex.EncodeToString(signature)); err != nil {
+			t.Fatalf("%s: expected the signature to verify, got: %v", name, err)
+		}
+		if err := verifier.SubmitPersonalMessage(append(raw, 0), hex.EncodeToString(signature)); err == nil {
+			t.Fatalf("%s: expected a longer payload not to verify", name)
+		}
+		log.Printf("✅ %s verified and bound to its length", name)
+	}
+}
@@ -2,12 +2,75 @@ package signatureverifier
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"oracle/pkg/delegation"
+	"oracle/pkg/msghash"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/text/unicode/norm"
 )
 
+// ErrZeroAddressSigner is returned when a signature recovers to the zero address.
+// This mirrors OpenZeppelin's ECDSA guard against malformed signatures.
+var ErrZeroAddressSigner = errors.New("signature recovers to the zero address")
+
+// ErrHighS is returned when a signature's s is in the upper half of the curve order. Flipping s to
+// its complement yields a second valid signature for the same message, so, like EIP-2 and
+// OpenZeppelin's ECDSA, only the lower-half form is accepted.
+var ErrHighS = errors.New("signature s is not in the lower half of the curve order")
+
+// secp256k1HalfOrder is half the secp256k1 curve order, the largest s a signature may have
+var secp256k1HalfOrder = new(big.Int).Rsh(crypto.S256().Params().N, 1)
+
+// ErrSignatureExpired is returned when a signature is submitted after the deadline it commits to
+var ErrSignatureExpired = errors.New("signature deadline has passed")
+
+// PackMode selects how the (validator, nominator, msg) triplet is packed before hashing
+type PackMode int
+
+const (
+	// PackModeStrings packs all three parameters as strings: abi.encodePacked(string, string, string)
+	PackModeStrings PackMode = iota
+	// PackModeMixed packs the SS58-decoded AccountIds as bytes32 followed by the message string:
+	// abi.encodePacked(bytes32, bytes32, string)
+	PackModeMixed
+	// PackModeEncoded encodes all three parameters as strings: abi.encode(string, string, string).
+	// Each string carries its length, so unlike PackModeStrings ("ab", "c") and ("a", "bc") hash
+	// differently. This is the packing the signing oracle signs.
+	PackModeEncoded
+)
+
+// ErrNoMatchingPackMode is returned by SubmitMessageAnyScheme when the signature verifies under none
+// of the accepted pack modes
+var ErrNoMatchingPackMode = errors.New("signature does not verify under any accepted pack mode")
+
+// String returns a human-readable name for the pack mode
+func (m PackMode) String() string {
+	switch m {
+	case PackModeStrings:
+		return "strings"
+	case PackModeMixed:
+		return "mixed"
+	case PackModeEncoded:
+		return "encoded"
+	default:
+		return fmt.Sprintf("PackMode(%d)", int(m))
+	}
+}
+
+// packsStrings reports whether the mode packs the triplet as strings, the modes era, nonce,
+// deadline and block hash binding are defined for
+func (m PackMode) packsStrings() bool {
+	return m == PackModeStrings || m == PackModeEncoded
+}
+
 // Message represents the delegation message structure
 type Message struct {
 	ValidatorAddress string
@@ -15,84 +78,624 @@ type Message struct {
 	MsgText          string
 }
 
+// DefaultMessagePrefix is the EIP-191 personal message prefix used by Ethereum.
+// The decimal length of the signed payload ("32" for a hash) is appended to it.
+const DefaultMessagePrefix = "\x19Ethereum Signed Message:\n"
+
 // OracleVerifiedDelegation represents the verification logic from the smart contract
 type OracleVerifiedDelegation struct {
 	OracleAddress common.Address
+	// AdditionalOracleAddresses are accepted alongside OracleAddress, e.g. the old and new
+	// addresses while signing keys are rotated
+	AdditionalOracleAddresses []common.Address
+	PackMode                  PackMode
+	// AcceptedPackModes are the pack modes SubmitMessageAnyScheme tries, in order;
+	// empty means every known mode
+	AcceptedPackModes []PackMode
+	// MessagePrefix is the personal message prefix; empty means DefaultMessagePrefix
+	MessagePrefix string
+	// NormalizeNFC applies Unicode NFC normalization to the message text before hashing.
+	// It must match the signer's setting: visually identical strings in different
+	// normal forms hash differently, so a mismatch makes valid signatures fail.
+	NormalizeNFC bool
+	// Domain is packed as a leading string before the triplet so signatures for one use case (e.g. "delegation")
+	// can't be replayed for another (e.g. "withdrawal"); empty means no domain separation
+	Domain string
+	// ChainID is the EVM chain ID the oracle packs as a uint64 after the deadline of a verified
+	// delegation's signature, as the contract packs block.chainid; zero means signatures aren't
+	// bound to a chain
+	ChainID uint64
+	// recoverAddress replaces ecrecover, so tests can simulate a recovery that yields the zero
+	// address; nil means ecrecover
+	recoverAddress func(ethSignedMessageHash []byte, signature []byte) (common.Address, error)
 }
 
 // NewOracleVerifiedDelegation creates a new verifier instance
 func NewOracleVerifiedDelegation(oracleAddressHex string) (*OracleVerifiedDelegation, error) {
-	if !common.IsHexAddress(oracleAddressHex) {
-		return nil, fmt.Errorf("invalid oracle address: %s", oracleAddressHex)
+	return NewOracleVerifiedDelegationWithPrefix(oracleAddressHex, DefaultMessagePrefix)
+}
+
+// NewOracleVerifiedDelegationWithPrefix creates a verifier for chains using a non-standard
+// personal message prefix, e.g. "\x19TRON Signed Message:\n"
+func NewOracleVerifiedDelegationWithPrefix(oracleAddressHex string, messagePrefix string) (*OracleVerifiedDelegation, error) {
+	if err := ValidateMessagePrefix(messagePrefix); err != nil {
+		return nil, fmt.Errorf("invalid message prefix: %w", err)
+	}
+
+	o, err := NewOracleVerifiedDelegationMulti([]string{oracleAddressHex})
+	if err != nil {
+		return nil, err
+	}
+	o.MessagePrefix = messagePrefix
+	return o, nil
+}
+
+// NewOracleVerifiedDelegationMulti creates a verifier accepting signatures from any of the given
+// oracle addresses. The first becomes OracleAddress; duplicates are dropped.
+func NewOracleVerifiedDelegationMulti(oracleAddressesHex []string) (*OracleVerifiedDelegation, error) {
+	if len(oracleAddressesHex) == 0 {
+		return nil, fmt.Errorf("at least one oracle address is required")
+	}
+
+	var addresses []common.Address
+	seen := make(map[common.Address]bool)
+	for _, addressHex := range oracleAddressesHex {
+		if !common.IsHexAddress(addressHex) {
+			return nil, fmt.Errorf("invalid oracle address: %s", addressHex)
+		}
+		address := common.HexToAddress(addressHex)
+		if !seen[address] {
+			seen[address] = true
+			addresses = append(addresses, address)
+		}
 	}
 
 	return &OracleVerifiedDelegation{
-		OracleAddress: common.HexToAddress(oracleAddressHex),
+		OracleAddress:             addresses[0],
+		AdditionalOracleAddresses: addresses[1:],
+		MessagePrefix:             DefaultMessagePrefix,
 	}, nil
 }
 
+// GetOracleAddresses returns every accepted oracle address, OracleAddress first
+func (o *OracleVerifiedDelegation) GetOracleAddresses() []common.Address {
+	return append([]common.Address{o.OracleAddress}, o.AdditionalOracleAddresses...)
+}
+
+// matchOracle returns the accepted oracle address a recovered signer matches, or an error naming
+// the accepted addresses when it matches none
+func (o *OracleVerifiedDelegation) matchOracle(recoveredAddress common.Address) (common.Address, error) {
+	accepted := o.GetOracleAddresses()
+	for _, address := range accepted {
+		if recoveredAddress == address {
+			return address, nil
+		}
+	}
+
+	expected := make([]string, len(accepted))
+	for i, address := range accepted {
+		expected[i] = address.Hex()
+	}
+	return common.Address{}, fmt.Errorf("signature not from oracle: expected %s, got %s",
+		strings.Join(expected, " or "), recoveredAddress.Hex())
+}
+
+// ValidateMessagePrefix checks that a personal message prefix follows the EIP-191 layout:
+// the 0x19 byte, a chain-specific name and a trailing ":\n"
+func ValidateMessagePrefix(prefix string) error {
+	return msghash.ValidateMessagePrefix(prefix)
+}
+
 // SubmitMessage verifies and processes a delegation message
-// This mirrors the smart contract's submitMessage function
+// This mirrors the triplet-only submitMessage of contracts deployed before nonces and deadlines
 func (o *OracleVerifiedDelegation) SubmitMessage(
 	validatorAddress string,
 	nominatorAddress string,
 	msgText string,
 	signatureHex string,
 ) error {
-	// Step 1: Decode the signature
+	_, err := o.SubmitMessageMatch(validatorAddress, nominatorAddress, msgText, signatureHex)
+	return err
+}
+
+// SubmitMessageMatch is SubmitMessage returning which of the accepted oracle addresses signed
+func (o *OracleVerifiedDelegation) SubmitMessageMatch(
+	validatorAddress string,
+	nominatorAddress string,
+	msgText string,
+	signatureHex string,
+) (common.Address, error) {
+	// Rebuild the message hash the way the contract does and recover who signed it
+	recoveredAddress, err := o.RecoverSigner(validatorAddress, nominatorAddress, msgText, SignedFields{}, signatureHex)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return o.matchOracle(recoveredAddress)
+}
+
+// RecoverDelegationSigner returns the address that signed a plain (validator, nominator, msg)
+// triplet, hashed with the default Ethereum prefix, no domain and the all-strings packing.
+// Unlike SubmitMessage it needs no expected oracle address, so callers can compare the signer
+// against several acceptable oracles themselves.
+func RecoverDelegationSigner(validatorAddress, nominatorAddress, msgText, signatureHex string) (common.Address, error) {
+	return (&OracleVerifiedDelegation{}).RecoverSigner(validatorAddress, nominatorAddress, msgText, SignedFields{}, signatureHex)
+}
+
+// SubmitMessageAnyScheme verifies a delegation message signed under any of the accepted pack modes
+// and returns the mode that verified. This lets clients submit signatures without knowing whether
+// they were produced under the packed or encoded all-strings packing or the mixed one.
+func (o *OracleVerifiedDelegation) SubmitMessageAnyScheme(
+	validatorAddress string,
+	nominatorAddress string,
+	msgText string,
+	signatureHex string,
+) (PackMode, error) {
 	signature, err := hex.DecodeString(signatureHex)
 	if err != nil {
-		return fmt.Errorf("invalid signature hex: %w", err)
+		return 0, fmt.Errorf("invalid signature hex: %w", err)
 	}
 
-	if len(signature) != 65 {
-		return fmt.Errorf("invalid signature length: expected 65, got %d", len(signature))
+	modes := o.AcceptedPackModes
+	if len(modes) == 0 {
+		modes = []PackMode{PackModeStrings, PackModeEncoded, PackModeMixed}
 	}
 
-	// Step 2: Rebuild message hash (matches smart contract logic)
-	messageHash := o.createMessageHash(validatorAddress, nominatorAddress, msgText)
+	var failures []string
+	for _, mode := range modes {
+		messageHash, err := o.messageHashWithMode(mode, validatorAddress, nominatorAddress, msgText)
+		if err == nil {
+			err = o.verifyMessageHashSignature(messageHash, signature)
+		}
+		if err == nil {
+			return mode, nil
+		}
+		failures = append(failures, fmt.Sprintf("%s: %v", mode, err))
+	}
 
-	// Step 3: Create Ethereum signed message hash
-	ethSignedMessageHash := o.toEthSignedMessageHash(messageHash)
+	return 0, fmt.Errorf("%w (%s)", ErrNoMatchingPackMode, strings.Join(failures, "; "))
+}
 
-	// Step 4: Recover signer from signature
-	recoveredAddress, err := o.recoverSigner(ethSignedMessageHash, signature)
+// SubmitMessageEncoded verifies a delegation message whose signature is hex or base64 encoded.
+// With SignatureEncodingAuto the encoding is detected from the signature itself.
+func (o *OracleVerifiedDelegation) SubmitMessageEncoded(
+	validatorAddress string,
+	nominatorAddress string,
+	msgText string,
+	signature string,
+	encoding SignatureEncoding,
+) error {
+	signatureBytes, err := DecodeSignature(signature, encoding)
 	if err != nil {
-		return fmt.Errorf("failed to recover signer: %w", err)
+		return err
+	}
+
+	messageHash, err := o.messageHash(validatorAddress, nominatorAddress, msgText)
+	if err != nil {
+		return fmt.Errorf("failed to create message hash: %w", err)
+	}
+
+	return o.verifyMessageHashSignature(messageHash, signatureBytes)
+}
+
+// SubmitMessageForEra verifies a signature produced by SignTripletForEra, which commits to the
+// era the delegation was verified in. A signature for any other era is rejected.
+func (o *OracleVerifiedDelegation) SubmitMessageForEra(
+	validatorAddress string,
+	nominatorAddress string,
+	msgText string,
+	era uint32,
+	signatureHex string,
+) error {
+	messageHash, err := o.messageHashForEra(validatorAddress, nominatorAddress, msgText, era)
+	if err != nil {
+		return fmt.Errorf("failed to create message hash: %w", err)
+	}
+
+	return o.verifyMessageHash(messageHash, signatureHex)
+}
+
+// SubmitMessageWithNonce verifies a signature produced by SignTripletWithNonce, which commits to the
+// per-nominator nonce the oracle issued. A signature for any other nonce is rejected.
+func (o *OracleVerifiedDelegation) SubmitMessageWithNonce(
+	validatorAddress string,
+	nominatorAddress string,
+	msgText string,
+	nonce uint64,
+	signatureHex string,
+) error {
+	if !o.PackMode.packsStrings() {
+		return fmt.Errorf("failed to create message hash: nonces are not supported with pack mode %s", o.PackMode)
+	}
+	if o.NormalizeNFC {
+		msgText = norm.NFC.String(msgText)
+	}
+
+	return o.verifyMessageHash(o.createMessageHashWithSuffix(validatorAddress, nominatorAddress, msgText, msghash.PackUint64(nonce)), signatureHex)
+}
+
+// SubmitMessageAtBlock verifies a signature produced by SignTripletAtBlock, which commits to the
+// 0x-prefixed hash of the block the delegation was confirmed at
+func (o *OracleVerifiedDelegation) SubmitMessageAtBlock(
+	validatorAddress string,
+	nominatorAddress string,
+	msgText string,
+	blockHash string,
+	signatureHex string,
+) error {
+	if !o.PackMode.packsStrings() {
+		return fmt.Errorf("failed to create message hash: block hashes are not supported with pack mode %s", o.PackMode)
+	}
+	encodedBlockHash, err := hex.DecodeString(strings.TrimPrefix(blockHash, "0x"))
+	if err != nil || len(encodedBlockHash) != 32 {
+		return fmt.Errorf("invalid block hash %q: expected 32 hex-encoded bytes", blockHash)
+	}
+	if o.NormalizeNFC {
+		msgText = norm.NFC.String(msgText)
+	}
+
+	return o.verifyMessageHash(o.createMessageHashWithSuffix(validatorAddress, nominatorAddress, msgText, encodedBlockHash), signatureHex)
+}
+
+// SubmitMessageForEraWithNonce verifies a signature produced by SignTripletForEraWithNonce, which
+// commits to both the era and the nonce
+func (o *OracleVerifiedDelegation) SubmitMessageForEraWithNonce(
+	validatorAddress string,
+	nominatorAddress string,
+	msgText string,
+	era uint32,
+	nonce uint64,
+	signatureHex string,
+) error {
+	messageHash, err := o.messageHashForEra(validatorAddress, nominatorAddress, msgText, era, msghash.PackUint64(nonce)...)
+	if err != nil {
+		return fmt.Errorf("failed to create message hash: %w", err)
+	}
+
+	return o.verifyMessageHash(messageHash, signatureHex)
+}
+
+// SubmitMessageWithDeadline verifies a signature produced by SignTripletWithDeadline, which commits to
+// a unix deadline in seconds. Once the deadline has passed the signature is rejected with
+// ErrSignatureExpired, as the contract would, without checking who signed it.
+func (o *OracleVerifiedDelegation) SubmitMessageWithDeadline(
+	validatorAddress string,
+	nominatorAddress string,
+	msgText string,
+	deadline int64,
+	signatureHex string,
+) error {
+	if err := checkDeadline(deadline); err != nil {
+		return err
+	}
+	if !o.PackMode.packsStrings() {
+		return fmt.Errorf("failed to create message hash: deadlines are not supported with pack mode %s", o.PackMode)
+	}
+	if o.NormalizeNFC {
+		msgText = norm.NFC.String(msgText)
+	}
+
+	messageHash := o.createMessageHashWithSuffix(validatorAddress, nominatorAddress, msgText, msghash.PackUint64(uint64(deadline)))
+	return o.verifyMessageHash(messageHash, signatureHex)
+}
+
+// SubmitVerifiedDelegation verifies a signature produced by the oracle's /verify endpoint, which
+// commits to the era when one was bound, the nonce and the deadline. A signature past its deadline
+// is rejected with ErrSignatureExpired. With PackModeEncoded this mirrors the contract's
+// submitMessage and submitMessageForEra, apart from their tracking of consumed nonces.
+func (o *OracleVerifiedDelegation) SubmitVerifiedDelegation(
+	validatorAddress string,
+	nominatorAddress string,
+	msgText string,
+	era *uint32,
+	nonce uint64,
+	deadline int64,
+	signatureHex string,
+) error {
+	if err := checkDeadline(deadline); err != nil {
+		return err
+	}
+
+	suffix := append(msghash.PackUint64(nonce), msghash.PackUint64(uint64(deadline))...)
+	if o.ChainID != 0 {
+		suffix = append(suffix, msghash.PackUint64(o.ChainID)...)
+	}
+	if era != nil {
+		messageHash, err := o.messageHashForEra(validatorAddress, nominatorAddress, msgText, *era, suffix...)
+		if err != nil {
+			return fmt.Errorf("failed to create message hash: %w", err)
+		}
+		return o.verifyMessageHash(messageHash, signatureHex)
+	}
+
+	if !o.PackMode.packsStrings() {
+		return fmt.Errorf("failed to create message hash: nonces are not supported with pack mode %s", o.PackMode)
+	}
+	if o.NormalizeNFC {
+		msgText = norm.NFC.String(msgText)
+	}
+	return o.verifyMessageHash(o.createMessageHashWithSuffix(validatorAddress, nominatorAddress, msgText, suffix), signatureHex)
+}
+
+// SignedFields are the optional values a signature commits to after the triplet, packed in this
+// order: the era as a uint32, then the nonce and the deadline as uint64s. A deadline is followed
+// by the verifier's ChainID when one is set.
+type SignedFields struct {
+	Era      *uint32
+	Nonce    *uint64
+	Deadline *int64
+}
+
+// RecoverSigner returns the address that signed the triplet and fields, hashing them as the
+// oracle does. Unlike the Submit methods it neither compares the signer with the oracle nor
+// checks the deadline, so it suits finding out which key produced a signature.
+func (o *OracleVerifiedDelegation) RecoverSigner(
+	validatorAddress string,
+	nominatorAddress string,
+	msgText string,
+	fields SignedFields,
+	signatureHex string,
+) (common.Address, error) {
+	r, s, v, err := ParseSignature(signatureHex)
+	if err != nil {
+		return common.Address{}, err
 	}
 
-	// Step 5: Verify the recovered address matches the oracle address
-	if recoveredAddress != o.OracleAddress {
-		return fmt.Errorf("signature not from oracle: expected %s, got %s",
-			o.OracleAddress.Hex(), recoveredAddress.Hex())
+	var suffix []byte
+	if fields.Nonce != nil {
+		suffix = append(suffix, msghash.PackUint64(*fields.Nonce)...)
+	}
+	if fields.Deadline != nil {
+		suffix = append(suffix, msghash.PackUint64(uint64(*fields.Deadline))...)
+		if o.ChainID != 0 {
+			suffix = append(suffix, msghash.PackUint64(o.ChainID)...)
+		}
 	}
 
+	var messageHash []byte
+	switch {
+	case fields.Era != nil:
+		messageHash, err = o.messageHashForEra(validatorAddress, nominatorAddress, msgText, *fields.Era, suffix...)
+	case len(suffix) > 0:
+		if !o.PackMode.packsStrings() {
+			return common.Address{}, fmt.Errorf("failed to create message hash: nonces and deadlines are not supported with pack mode %s", o.PackMode)
+		}
+		if o.NormalizeNFC {
+			msgText = norm.NFC.String(msgText)
+		}
+		messageHash = o.createMessageHashWithSuffix(validatorAddress, nominatorAddress, msgText, suffix)
+	default:
+		messageHash, err = o.messageHash(validatorAddress, nominatorAddress, msgText)
+	}
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to create message hash: %w", err)
+	}
+
+	return o.recoverSigner(o.toEthSignedMessageHash(messageHash), AssembleSignature(r, s, v))
+}
+
+// checkDeadline returns ErrSignatureExpired once the unix deadline has passed
+func checkDeadline(deadline int64) error {
+	if time.Now().Unix() > deadline {
+		return fmt.Errorf("%w: deadline was %s", ErrSignatureExpired, time.Unix(deadline, 0).UTC().Format(time.RFC3339))
+	}
 	return nil
 }
 
-// createMessageHash creates the message hash from concatenated parameters
-// This matches the smart contract's keccak256(abi.encodePacked(...)) logic
+// VerifyEthSignedHash recovers the signer of an already EIP-191 prefixed hash and reports whether
+// it is the oracle. No hashing is performed, so this suits integrations that build the hash
+// themselves. The signature may carry a "0x" prefix and v in either {0,1} or {27,28}.
+func (o *OracleVerifiedDelegation) VerifyEthSignedHash(ethSignedHash [32]byte, sigHex string) (common.Address, bool, error) {
+	r, s, v, err := ParseSignature(sigHex)
+	if err != nil {
+		return common.Address{}, false, err
+	}
+
+	recoveredAddress, err := o.recoverSigner(ethSignedHash[:], AssembleSignature(r, s, v))
+	if err != nil {
+		return common.Address{}, false, fmt.Errorf("failed to recover signer: %w", err)
+	}
+
+	_, err = o.matchOracle(recoveredAddress)
+	return recoveredAddress, err == nil, nil
+}
+
+// SubmitPersonalMessage verifies a signature produced by SignPersonalMessage: the oracle's EIP-191
+// personal signature over raw, whose length may be anything. The signature may carry a "0x" prefix
+// and v in either {0,1} or {27,28}.
+func (o *OracleVerifiedDelegation) SubmitPersonalMessage(raw []byte, signatureHex string) error {
+	r, s, v, err := ParseSignature(signatureHex)
+	if err != nil {
+		return err
+	}
+
+	recoveredAddress, err := o.recoverSigner(o.toEthSignedMessageHashLen(raw), AssembleSignature(r, s, v))
+	if err != nil {
+		return fmt.Errorf("failed to recover signer: %w", err)
+	}
+
+	_, err = o.matchOracle(recoveredAddress)
+	return err
+}
+
+// verifyMessageHash checks that signatureHex is the oracle's EIP-191 signature over messageHash
+func (o *OracleVerifiedDelegation) verifyMessageHash(messageHash []byte, signatureHex string) error {
+	// Decode the signature
+	signature, err := hex.DecodeString(signatureHex)
+	if err != nil {
+		return fmt.Errorf("invalid signature hex: %w", err)
+	}
+
+	return o.verifyMessageHashSignature(messageHash, signature)
+}
+
+// verifyMessageHashSignature checks that the raw 65-byte or compact 64-byte signature is the
+// oracle's EIP-191 signature over messageHash
+func (o *OracleVerifiedDelegation) verifyMessageHashSignature(messageHash []byte, signature []byte) error {
+	if len(signature) != 65 && len(signature) != 64 {
+		return fmt.Errorf("invalid signature length: expected 64 or 65, got %d", len(signature))
+	}
+
+	// Create Ethereum signed message hash
+	ethSignedMessageHash := o.toEthSignedMessageHash(messageHash)
+
+	// Recover signer from signature
+	recoveredAddress, err := o.recoverSigner(ethSignedMessageHash, signature)
+	if err != nil {
+		return fmt.Errorf("failed to recover signer: %w", err)
+	}
+
+	// Verify the recovered address matches an accepted oracle address
+	_, err = o.matchOracle(recoveredAddress)
+	return err
+}
+
+// createMessageHash creates the message hash from the triplet packed under the configured
+// PackMode. This matches the smart contract's keccak256(abi.encodePacked(...)) logic, or with
+// PackModeEncoded its keccak256(abi.encode(...)).
 func (o *OracleVerifiedDelegation) createMessageHash(
 	validatorAddress string,
 	nominatorAddress string,
 	msgText string,
 ) []byte {
-	// Concatenate the parameters as they would be in abi.encodePacked
-	message := validatorAddress + nominatorAddress + msgText
+	return o.createMessageHashWithMode(o.PackMode, validatorAddress, nominatorAddress, msgText)
+}
 
+// createMessageHashWithMode is createMessageHash under the given all-strings pack mode
+func (o *OracleVerifiedDelegation) createMessageHashWithMode(
+	mode PackMode,
+	validatorAddress string,
+	nominatorAddress string,
+	msgText string,
+) []byte {
 	// Create Keccak256 hash (Ethereum's standard hash function)
-	hash := crypto.Keccak256([]byte(message))
+	hash := crypto.Keccak256(o.packTriplet(mode, validatorAddress, nominatorAddress, msgText))
 	return hash
 }
 
+// createMessageHashWithSuffix is createMessageHash with already packed values, such as a uint64
+// nonce or deadline, appended: keccak256(abi.encodePacked(domain, validator, nominator, msg, ...)),
+// or keccak256(abi.encodePacked(abi.encode(domain, validator, nominator, msg), ...)) with PackModeEncoded
+func (o *OracleVerifiedDelegation) createMessageHashWithSuffix(
+	validatorAddress string,
+	nominatorAddress string,
+	msgText string,
+	suffix []byte,
+) []byte {
+	return crypto.Keccak256(append(o.packTriplet(o.PackMode, validatorAddress, nominatorAddress, msgText), suffix...))
+}
+
+// packTriplet packs the domain and triplet as strings: abi.encode(domain, validator, nominator, msg)
+// with PackModeEncoded, leaving the domain out when it is empty, and otherwise
+// abi.encodePacked(domain, validator, nominator, msg)
+func (o *OracleVerifiedDelegation) packTriplet(mode PackMode, validatorAddress, nominatorAddress, msgText string) []byte {
+	if mode != PackModeEncoded {
+		return msghash.PackStrings(o.Domain, validatorAddress, nominatorAddress, msgText)
+	}
+	if o.Domain == "" {
+		return msghash.EncodeStrings(validatorAddress, nominatorAddress, msgText)
+	}
+	return msghash.EncodeStrings(o.Domain, validatorAddress, nominatorAddress, msgText)
+}
+
+// createMessageHashMixed creates the message hash for contracts that pack the addresses as bytes32.
+// This matches keccak256(abi.encodePacked(string domain, bytes32 validator, bytes32 nominator, string msg)),
+// where each address is the 32-byte AccountId decoded from its SS58 form.
+func (o *OracleVerifiedDelegation) createMessageHashMixed(
+	validatorAddress string,
+	nominatorAddress string,
+	msgText string,
+) ([]byte, error) {
+	validatorID, _, err := delegation.DecodeSS58(validatorAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid validator address: %w", err)
+	}
+
+	nominatorID, _, err := delegation.DecodeSS58(nominatorAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nominator address: %w", err)
+	}
+
+	// bytes32 values are packed at their fixed width, the string without padding
+	packed := make([]byte, 0, len(o.Domain)+64+len(msgText))
+	packed = append(packed, []byte(o.Domain)...)
+	packed = append(packed, validatorID...)
+	packed = append(packed, nominatorID...)
+	packed = append(packed, []byte(msgText)...)
+
+	return crypto.Keccak256(packed), nil
+}
+
+// messageHash creates the message hash according to the configured PackMode
+func (o *OracleVerifiedDelegation) messageHash(
+	validatorAddress string,
+	nominatorAddress string,
+	msgText string,
+) ([]byte, error) {
+	return o.messageHashWithMode(o.PackMode, validatorAddress, nominatorAddress, msgText)
+}
+
+// messageHashWithMode creates the message hash under the given pack mode
+func (o *OracleVerifiedDelegation) messageHashWithMode(
+	mode PackMode,
+	validatorAddress string,
+	nominatorAddress string,
+	msgText string,
+) ([]byte, error) {
+	if o.NormalizeNFC {
+		msgText = norm.NFC.String(msgText)
+	}
+
+	switch mode {
+	case PackModeStrings, PackModeEncoded:
+		return o.createMessageHashWithMode(mode, validatorAddress, nominatorAddress, msgText), nil
+	case PackModeMixed:
+		return o.createMessageHashMixed(validatorAddress, nominatorAddress, msgText)
+	default:
+		return nil, fmt.Errorf("unsupported pack mode: %s", mode)
+	}
+}
+
+// messageHashForEra hashes the triplet with the era appended as a packed uint32, followed by any
+// further packed fields: keccak256(abi.encodePacked(domain, validator, nominator, msg, uint32 era, suffix)),
+// with the triplet abi.encoded under PackModeEncoded. Era binding is only defined for the
+// all-strings pack modes, matching SignTripletForEra.
+func (o *OracleVerifiedDelegation) messageHashForEra(
+	validatorAddress string,
+	nominatorAddress string,
+	msgText string,
+	era uint32,
+	suffix ...byte,
+) ([]byte, error) {
+	if !o.PackMode.packsStrings() {
+		return nil, fmt.Errorf("era binding is not supported with pack mode %s", o.PackMode)
+	}
+	if o.NormalizeNFC {
+		msgText = norm.NFC.String(msgText)
+	}
+
+	packed := append(o.packTriplet(o.PackMode, validatorAddress, nominatorAddress, msgText), msghash.PackUint32(era)...)
+	return crypto.Keccak256(append(packed, suffix...)), nil
+}
+
 // toEthSignedMessageHash creates the Ethereum signed message hash
 // This matches the smart contract's toEthSignedMessageHash function
 func (o *OracleVerifiedDelegation) toEthSignedMessageHash(messageHash []byte) []byte {
 	// Ethereum signed message prefix: "\x19Ethereum Signed Message:\n32"
-	prefix := []byte("\x19Ethereum Signed Message:\n32")
+	return o.toEthSignedMessageHashLen(messageHash)
+}
+
+// toEthSignedMessageHashLen hashes a personal message of any length behind the message prefix
+// and the decimal length of msg, as eth_sign and personal_sign do
+func (o *OracleVerifiedDelegation) toEthSignedMessageHashLen(msg []byte) []byte {
+	messagePrefix := o.MessagePrefix
+	if messagePrefix == "" {
+		messagePrefix = DefaultMessagePrefix
+	}
+	prefix := []byte(messagePrefix + strconv.Itoa(len(msg)))
 
-	// Concatenate prefix with the message hash
-	data := append(prefix, messageHash...)
+	// Concatenate prefix with the message
+	data := append(prefix, msg...)
 
 	// Create hash of the concatenated data
 	hash := crypto.Keccak256(data)
@@ -100,12 +703,42 @@ func (o *OracleVerifiedDelegation) toEthSignedMessageHash(messageHash []byte) []
 }
 
 // recoverSigner recovers the signer address from the signature
-// This matches the smart contract's recoverSigner function
+// This matches the smart contract's recoverSigner function; v may be in {0,1} or {27,28}.
+// A 64-byte signature is taken to be in the compact EIP-2098 form and expanded first, and a
+// signature with a high s is rejected with ErrHighS.
 func (o *OracleVerifiedDelegation) recoverSigner(ethSignedMessageHash []byte, signature []byte) (common.Address, error) {
-	if len(signature) != 65 {
-		return common.Address{}, fmt.Errorf("invalid signature length: expected 65, got %d", len(signature))
+	signature, err := expandSignature(signature)
+	if err != nil {
+		return common.Address{}, err
 	}
 
+	if new(big.Int).SetBytes(signature[32:64]).Cmp(secp256k1HalfOrder) > 0 {
+		return common.Address{}, ErrHighS
+	}
+
+	// Normalize a copy so the caller's signature is left untouched
+	normalized := append([]byte{}, signature...)
+	normalized[64] = normalizeV(normalized[64])
+
+	recoverAddress := ecrecover
+	if o.recoverAddress != nil {
+		recoverAddress = o.recoverAddress
+	}
+	address, err := recoverAddress(ethSignedMessageHash, normalized)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	// Never accept the zero address, even if the oracle is (mis)configured to it
+	if address == (common.Address{}) {
+		return common.Address{}, ErrZeroAddressSigner
+	}
+
+	return address, nil
+}
+
+// ecrecover recovers the signing address, like Solidity's ecrecover precompile
+func ecrecover(ethSignedMessageHash []byte, signature []byte) (common.Address, error) {
 	// Use the signature directly with crypto.Ecrecover
 	pubKey, err := crypto.Ecrecover(ethSignedMessageHash, signature)
 	if err != nil {
@@ -124,7 +757,39 @@ func (o *OracleVerifiedDelegation) VerifyMessage(msg Message, signatureHex strin
 	return o.SubmitMessage(msg.ValidatorAddress, msg.NominatorAddress, msg.MsgText, signatureHex)
 }
 
-// GetOracleAddress returns the oracle address
+// CandidateSigners returns every address the signature could be attributed to for the given triplet,
+// recovering with both possible recovery ids. The address for the signature's own v comes first.
+// This helps integrators pick the oracle address to configure when the recovery id is in doubt.
+func (o *OracleVerifiedDelegation) CandidateSigners(
+	validatorAddress string,
+	nominatorAddress string,
+	msgText string,
+	signatureHex string,
+) []common.Address {
+	r, s, v, err := ParseSignature(signatureHex)
+	if err != nil {
+		return nil
+	}
+
+	messageHash, err := o.messageHash(validatorAddress, nominatorAddress, msgText)
+	if err != nil {
+		return nil
+	}
+	ethSignedMessageHash := o.toEthSignedMessageHash(messageHash)
+
+	var candidates []common.Address
+	for _, recoveryID := range []byte{v, 1 - v} {
+		address, err := o.recoverSigner(ethSignedMessageHash, AssembleSignature(r, s, recoveryID))
+		if err != nil {
+			continue
+		}
+		candidates = append(candidates, address)
+	}
+
+	return candidates
+}
+
+// GetOracleAddress returns the primary oracle address
 func (o *OracleVerifiedDelegation) GetOracleAddress() common.Address {
 	return o.OracleAddress
 }
@@ -149,15 +814,18 @@ func (o *OracleVerifiedDelegation) CreateValidSignature(
 		return "", fmt.Errorf("failed to create private key: %w", err)
 	}
 
-	// Verify the private key corresponds to the oracle address
+	// Verify the private key corresponds to an accepted oracle address
 	derivedAddress := crypto.PubkeyToAddress(privateKey.PublicKey)
-	if derivedAddress != o.OracleAddress {
+	if _, err := o.matchOracle(derivedAddress); err != nil {
 		return "", fmt.Errorf("private key does not correspond to oracle address: expected %s, got %s",
 			o.OracleAddress.Hex(), derivedAddress.Hex())
 	}
 
 	// Create message hash
-	messageHash := o.createMessageHash(validatorAddress, nominatorAddress, msgText)
+	messageHash, err := o.messageHash(validatorAddress, nominatorAddress, msgText)
+	if err != nil {
+		return "", fmt.Errorf("failed to create message hash: %w", err)
+	}
 
 	// Create Ethereum signed message hash
 	ethSignedMessageHash := o.toEthSignedMessageHash(messageHash)
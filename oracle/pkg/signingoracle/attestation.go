@@ -0,0 +1,127 @@
+package signingoracle
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"oracle/pkg/delegation"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DefaultAttestationTTL is how long an attestation stays valid when ATTESTATION_TTL is unset
+const DefaultAttestationTTL = 5 * time.Minute
+
+// ErrAttestationExpired is returned by VerifyAttestation for a token past its expiry
+var ErrAttestationExpired = errors.New("attestation has expired")
+
+// attestationHeader is the fixed JOSE header of every attestation: ES256K over secp256k1
+var attestationHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"ES256K","typ":"JWT"}`))
+
+// AttestationClaims are the JWT claims of a delegation attestation
+type AttestationClaims struct {
+	Issuer    string `json:"iss"`
+	Nominator string `json:"nominator"`
+	Validator string `json:"validator"`
+	Verified  bool   `json:"verified"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// IssueAttestation mints a short-lived ES256K JWT, signed with the oracle key, stating that
+// the delegation in result was verified with the given outcome at the current time
+func (so *SigningOracle) IssueAttestation(result delegation.DelegationVerificationResult) (string, error) {
+	now := so.now()
+	claims := AttestationClaims{
+		Issuer:    so.GetAddress(),
+		Nominator: result.NominatorAddress,
+		Validator: result.ValidatorAddress,
+		Verified:  result.IsValid,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(so.attestationTTL).Unix(),
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal attestation claims: %v", err)
+	}
+
+	signingInput := attestationHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	signature, err := so.signHash(digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign attestation: %v", err)
+	}
+
+	// JOSE ES256K signatures are r||s without the recovery id
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature[:64]), nil
+}
+
+// VerifyAttestation checks an attestation's signature against the oracle key and its expiry,
+// returning the claims when the token is valid
+func (so *SigningOracle) VerifyAttestation(token string) (*AttestationClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed attestation: expected 3 parts, got %d", len(parts))
+	}
+
+	if parts[0] != attestationHeader {
+		return nil, fmt.Errorf("unsupported attestation header")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid attestation signature encoding: %v", err)
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if !so.signedByOracle(digest[:], signature) {
+		return nil, fmt.Errorf("invalid attestation signature")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid attestation payload encoding: %v", err)
+	}
+
+	var claims AttestationClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("invalid attestation payload: %v", err)
+	}
+
+	if so.now().Unix() >= claims.ExpiresAt {
+		return nil, ErrAttestationExpired
+	}
+
+	return &claims, nil
+}
+
+// signedByOracle reports whether the 64-byte r||s signature over digest recovers to the oracle's
+// address under either recovery id, which works whether or not the signer exposes its public key
+func (so *SigningOracle) signedByOracle(digest, signature []byte) bool {
+	if len(signature) != 64 {
+		return false
+	}
+	// Reject malleable high-s signatures, as crypto.VerifySignature does
+	if !crypto.ValidateSignatureValues(0, new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:]), true) {
+		return false
+	}
+
+	oracleAddress := so.currentSigner().Address()
+	withRecoveryID := append(append([]byte{}, signature...), 0)
+	for v := byte(0); v <= 1; v++ {
+		withRecoveryID[64] = v
+		publicKey, err := crypto.SigToPub(digest, withRecoveryID)
+		if err == nil && crypto.PubkeyToAddress(*publicKey) == oracleAddress {
+			return true
+		}
+	}
+	return false
+}
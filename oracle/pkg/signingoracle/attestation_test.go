@@ -0,0 +1,85 @@
+package signingoracle
+
+import (
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"oracle/pkg/delegation"
+)
+
+func newTestAttestationOracle(t *testing.T) *SigningOracle {
+	t.Helper()
+
+	os.Setenv("PRIVATE_KEY", "1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")
+	os.Setenv("ATTESTATION_TTL", "2m")
+	defer os.Unsetenv("PRIVATE_KEY")
+	defer os.Unsetenv("ATTESTATION_TTL")
+
+	oracle, err := NewSigningOracle()
+	if err != nil {
+		t.Fatalf("Failed to create signing oracle: %v", err)
+	}
+	return oracle
+}
+
+func TestIssueAndVerifyAttestation(t *testing.T) {
+	log.Printf("🧪 Starting TestIssueAndVerifyAttestation")
+
+	oracle := newTestAttestationOracle(t)
+	issuedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	oracle.now = func() time.Time { return issuedAt }
+
+	token, err := oracle.IssueAttestation(delegation.DelegationVerificationResult{
+		NominatorAddress: "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty",
+		ValidatorAddress: "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+		IsValid:          true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to issue attestation: %v", err)
+	}
+	log.Printf("📋 Attestation: %s", token)
+
+	claims, err := oracle.VerifyAttestation(token)
+	if err != nil {
+		t.Fatalf("Failed to verify attestation: %v", err)
+	}
+	if !claims.Verified || claims.Issuer != oracle.GetAddress() {
+		t.Fatalf("Unexpected claims: %+v", claims)
+	}
+	if claims.ExpiresAt-claims.IssuedAt != int64((2 * time.Minute).Seconds()) {
+		t.Fatalf("Expected a 2 minute lifetime, got %ds", claims.ExpiresAt-claims.IssuedAt)
+	}
+	log.Printf("✅ Attestation verified: %+v", claims)
+
+	// Tampering with the payload must break the signature
+	parts := strings.Split(token, ".")
+	tampered := parts[0] + "." + parts[1] + "x." + parts[2]
+	if _, err := oracle.VerifyAttestation(tampered); err == nil {
+		t.Fatalf("Expected tampered attestation to be rejected")
+	}
+	log.Printf("✅ Tampered attestation rejected")
+}
+
+func TestVerifyAttestation_Expired(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyAttestation_Expired")
+
+	oracle := newTestAttestationOracle(t)
+	issuedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	oracle.now = func() time.Time { return issuedAt }
+
+	token, err := oracle.IssueAttestation(delegation.DelegationVerificationResult{IsValid: true})
+	if err != nil {
+		t.Fatalf("Failed to issue attestation: %v", err)
+	}
+
+	oracle.now = func() time.Time { return issuedAt.Add(2*time.Minute + time.Second) }
+	if _, err := oracle.VerifyAttestation(token); !errors.Is(err, ErrAttestationExpired) {
+		t.Fatalf("Expected ErrAttestationExpired, got: %v", err)
+	}
+
+	log.Printf("✅ Expired attestation rejected")
+}
@@ -0,0 +1,22 @@
+package signingoracle
+
+import (
+	"time"
+
+	"oracle/pkg/msghash"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DefaultSignatureTTL is how long a signed delegation stays valid when SIGNATURE_TTL is unset
+const DefaultSignatureTTL = 10 * time.Minute
+
+// SignTripletWithDeadline signs keccak256(abi.encodePacked(abi.encode(domain, validator, nominator, msgText), uint64 deadline))
+// with the configured EIP-191 prefix. The deadline is a unix timestamp in seconds after which
+// the contract must reject the signature, so a captured signature can't be used indefinitely.
+func (so *SigningOracle) SignTripletWithDeadline(validator, nominator, msgText string, deadline int64) (sig []byte, err error) {
+	packed := append(so.packTriplet(validator, nominator, msgText), msghash.PackUint64(uint64(deadline))...)
+
+	so.signingRate.record(so.now(), so.GetAddress())
+	return so.signHash(so.toEthSignedMessageHash(crypto.Keccak256(packed)))
+}
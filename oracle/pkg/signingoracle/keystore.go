@@ -0,0 +1,204 @@
+package signingoracle
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+var (
+	// ErrKeystorePassword is returned when a keystore's MAC doesn't match, i.e. the password is wrong
+	ErrKeystorePassword = errors.New("could not decrypt keystore: wrong password")
+	// ErrKeystoreMalformed is returned when a keystore file isn't a valid V3 JSON keystore
+	ErrKeystoreMalformed = errors.New("malformed keystore")
+)
+
+// keystoreV3 is the go-ethereum Web3 Secret Storage (version 3) JSON layout
+type keystoreV3 struct {
+	Address string         `json:"address"`
+	Crypto  keystoreCrypto `json:"crypto"`
+	// CryptoLegacy is the capitalized "Crypto" key written by some older tools
+	CryptoLegacy *keystoreCrypto `json:"Crypto"`
+	Version      int             `json:"version"`
+}
+
+type keystoreCrypto struct {
+	Cipher       string                 `json:"cipher"`
+	CipherText   string                 `json:"ciphertext"`
+	CipherParams keystoreCipherParams   `json:"cipherparams"`
+	KDF          string                 `json:"kdf"`
+	KDFParams    map[string]interface{} `json:"kdfparams"`
+	MAC          string                 `json:"mac"`
+}
+
+type keystoreCipherParams struct {
+	IV string `json:"iv"`
+}
+
+// malformedKeystore wraps a keystore parsing failure in ErrKeystoreMalformed
+func malformedKeystore(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrKeystoreMalformed, fmt.Sprintf(format, args...))
+}
+
+// kdfInt reads an integer KDF parameter, which JSON decodes as a float64
+func kdfInt(params map[string]interface{}, name string) (int, error) {
+	value, ok := params[name].(float64)
+	if !ok || value <= 0 || value != float64(int(value)) {
+		return 0, malformedKeystore("invalid kdfparams.%s", name)
+	}
+	return int(value), nil
+}
+
+// deriveKeystoreKey runs the keystore's scrypt or pbkdf2 KDF over the password
+func deriveKeystoreKey(c keystoreCrypto, password string) ([]byte, error) {
+	saltHex, _ := c.KDFParams["salt"].(string)
+	salt, err := hex.DecodeString(saltHex)
+	if err != nil || len(salt) == 0 {
+		return nil, malformedKeystore("invalid kdfparams.salt")
+	}
+	dkLen, err := kdfInt(c.KDFParams, "dklen")
+	if err != nil {
+		return nil, err
+	}
+	if dkLen < 32 {
+		return nil, malformedKeystore("kdfparams.dklen must be at least 32, got %d", dkLen)
+	}
+
+	switch c.KDF {
+	case "scrypt":
+		n, err := kdfInt(c.KDFParams, "n")
+		if err != nil {
+			return nil, err
+		}
+		r, err := kdfInt(c.KDFParams, "r")
+		if err != nil {
+			return nil, err
+		}
+		p, err := kdfInt(c.KDFParams, "p")
+		if err != nil {
+			return nil, err
+		}
+		key, err := scrypt.Key([]byte(password), salt, n, r, p, dkLen)
+		if err != nil {
+			return nil, malformedKeystore("scrypt: %v", err)
+		}
+		return key, nil
+	case "pbkdf2":
+		if prf, _ := c.KDFParams["prf"].(string); prf != "hmac-sha256" {
+			return nil, malformedKeystore("unsupported pbkdf2 prf %q", prf)
+		}
+		iterations, err := kdfInt(c.KDFParams, "c")
+		if err != nil {
+			return nil, err
+		}
+		return pbkdf2.Key([]byte(password), salt, iterations, dkLen, sha256.New), nil
+	default:
+		return nil, malformedKeystore("unsupported kdf %q", c.KDF)
+	}
+}
+
+// decryptKeystore decrypts a V3 JSON keystore with password, returning its private key
+func decryptKeystore(keyJSON []byte, password string) (*ecdsa.PrivateKey, error) {
+	var ks keystoreV3
+	if err := json.Unmarshal(keyJSON, &ks); err != nil {
+		return nil, malformedKeystore("%v", err)
+	}
+	if ks.Version != 3 {
+		return nil, malformedKeystore("unsupported version %d", ks.Version)
+	}
+	c := ks.Crypto
+	if ks.CryptoLegacy != nil {
+		c = *ks.CryptoLegacy
+	}
+	if c.Cipher != "aes-128-ctr" {
+		return nil, malformedKeystore("unsupported cipher %q", c.Cipher)
+	}
+
+	cipherText, err := hex.DecodeString(c.CipherText)
+	if err != nil || len(cipherText) == 0 {
+		return nil, malformedKeystore("invalid ciphertext")
+	}
+	iv, err := hex.DecodeString(c.CipherParams.IV)
+	if err != nil || len(iv) != aes.BlockSize {
+		return nil, malformedKeystore("invalid cipherparams.iv")
+	}
+	mac, err := hex.DecodeString(c.MAC)
+	if err != nil || len(mac) != 32 {
+		return nil, malformedKeystore("invalid mac")
+	}
+
+	derivedKey, err := deriveKeystoreKey(c, password)
+	if err != nil {
+		return nil, err
+	}
+
+	// The MAC commits to the second half of the derived key, so a mismatch means a wrong password
+	calculatedMAC := crypto.Keccak256(derivedKey[16:32], cipherText)
+	if subtle.ConstantTimeCompare(calculatedMAC, mac) != 1 {
+		return nil, ErrKeystorePassword
+	}
+
+	block, err := aes.NewCipher(derivedKey[:16])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %v", err)
+	}
+	plainText := make([]byte, len(cipherText))
+	cipher.NewCTR(block, iv).XORKeyStream(plainText, cipherText)
+
+	privateKey, err := crypto.ToECDSA(plainText)
+	if err != nil {
+		return nil, malformedKeystore("invalid private key: %v", err)
+	}
+
+	// The address field is optional, but when present it must match the decrypted key
+	if ks.Address != "" {
+		address := crypto.PubkeyToAddress(privateKey.PublicKey)
+		expected := common.HexToAddress(strings.TrimPrefix(ks.Address, "0x"))
+		if !bytes.Equal(address.Bytes(), expected.Bytes()) {
+			return nil, malformedKeystore("key belongs to %s, not %s", address.Hex(), expected.Hex())
+		}
+	}
+
+	return privateKey, nil
+}
+
+// NewSigningOracleFromKeystore creates a signing oracle whose key is loaded from a go-ethereum V3
+// JSON keystore decrypted with password, so the raw key never has to sit in the environment.
+// Everything else is configured from the environment as in NewSigningOracle. A wrong password
+// returns ErrKeystorePassword and an unreadable keystore ErrKeystoreMalformed.
+func NewSigningOracleFromKeystore(path, password string) (*SigningOracle, error) {
+	signer, err := NewKeystoreSigner(path, password)
+	if err != nil {
+		return nil, err
+	}
+	return newSigningOracle(signer)
+}
+
+// NewKeystoreSigner creates a signer for the key in a go-ethereum V3 JSON keystore decrypted
+// with password. Errors are as for NewSigningOracleFromKeystore.
+func NewKeystoreSigner(path, password string) (*LocalSigner, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore: %w", err)
+	}
+
+	privateKey, err := decryptKeystore(keyJSON, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load keystore %s: %w", path, err)
+	}
+	return NewLocalSigner(privateKey), nil
+}
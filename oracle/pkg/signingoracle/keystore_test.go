@@ -0,0 +1,131 @@
+package signingoracle
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/scrypt"
+)
+
+// writeTestKeystore encrypts privateKey into a V3 JSON keystore in dir, using light scrypt
+// parameters so the test stays fast
+func writeTestKeystore(t *testing.T, dir string, privateKey *ecdsa.PrivateKey, password string) string {
+	t.Helper()
+
+	salt := make([]byte, 32)
+	iv := make([]byte, aes.BlockSize)
+	rand.Read(salt)
+	rand.Read(iv)
+
+	const n, r, p, dkLen = 4096, 8, 6, 32
+	derivedKey, err := scrypt.Key([]byte(password), salt, n, r, p, dkLen)
+	if err != nil {
+		t.Fatalf("scrypt failed: %v", err)
+	}
+
+	block, err := aes.NewCipher(derivedKey[:16])
+	if err != nil {
+		t.Fatalf("Failed to create cipher: %v", err)
+	}
+	cipherText := make([]byte, 32)
+	cipher.NewCTR(block, iv).XORKeyStream(cipherText, crypto.FromECDSA(privateKey))
+
+	keyJSON, err := json.Marshal(map[string]interface{}{
+		"version": 3,
+		"address": strings.ToLower(strings.TrimPrefix(crypto.PubkeyToAddress(privateKey.PublicKey).Hex(), "0x")),
+		"crypto": map[string]interface{}{
+			"cipher":       "aes-128-ctr",
+			"ciphertext":   hex.EncodeToString(cipherText),
+			"cipherparams": map[string]string{"iv": hex.EncodeToString(iv)},
+			"kdf":          "scrypt",
+			"kdfparams":    map[string]interface{}{"n": n, "r": r, "p": p, "dklen": dkLen, "salt": hex.EncodeToString(salt)},
+			"mac":          hex.EncodeToString(crypto.Keccak256(derivedKey[16:32], cipherText)),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal keystore: %v", err)
+	}
+
+	path := filepath.Join(dir, "keystore.json")
+	if err := os.WriteFile(path, keyJSON, 0600); err != nil {
+		t.Fatalf("Failed to write keystore: %v", err)
+	}
+	return path
+}
+
+func TestNewSigningOracleFromKeystore(t *testing.T) {
+	log.Printf("🧪 Starting TestNewSigningOracleFromKeystore")
+
+	os.Setenv("POLKADOT_RPC_URL", "https://rpc.polkadot.io")
+	defer os.Unsetenv("POLKADOT_RPC_URL")
+
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	dir := t.TempDir()
+	path := writeTestKeystore(t, dir, privateKey, "correct horse")
+
+	oracle, err := NewSigningOracleFromKeystore(path, "correct horse")
+	if err != nil {
+		t.Fatalf("Expected keystore to load, got: %v", err)
+	}
+	if oracle.GetAddress() != crypto.PubkeyToAddress(privateKey.PublicKey).Hex() {
+		t.Fatalf("Expected address %s, got %s", crypto.PubkeyToAddress(privateKey.PublicKey).Hex(), oracle.GetAddress())
+	}
+	if oracle.GetPrivateKeyHex() != hex.EncodeToString(crypto.FromECDSA(privateKey)) {
+		t.Fatalf("Decrypted key does not match the original")
+	}
+	log.Printf("✅ Keystore round-tripped to %s", oracle.GetAddress())
+
+	if _, err := NewSigningOracleFromKeystore(path, "wrong horse"); !errors.Is(err, ErrKeystorePassword) {
+		t.Fatalf("Expected ErrKeystorePassword, got: %v", err)
+	}
+	log.Printf("✅ Wrong password reported as ErrKeystorePassword")
+
+	malformed := filepath.Join(dir, "malformed.json")
+	os.WriteFile(malformed, []byte(`{"version": 3, "crypto": {"cipher": "aes-128-ctr"`), 0600)
+	_, err = NewSigningOracleFromKeystore(malformed, "correct horse")
+	if !errors.Is(err, ErrKeystoreMalformed) || errors.Is(err, ErrKeystorePassword) {
+		t.Fatalf("Expected ErrKeystoreMalformed, got: %v", err)
+	}
+	log.Printf("✅ Malformed keystore reported as ErrKeystoreMalformed")
+}
+
+// TestDecryptKeystore_PBKDF2Vector decrypts the PBKDF2 test vector from the Web3 Secret Storage spec
+func TestDecryptKeystore_PBKDF2Vector(t *testing.T) {
+	log.Printf("🧪 Starting TestDecryptKeystore_PBKDF2Vector")
+
+	keyJSON := `{
+		"crypto": {
+			"cipher": "aes-128-ctr",
+			"cipherparams": {"iv": "6087dab2f9fdbbfaddc31a909735c1e6"},
+			"ciphertext": "5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46",
+			"kdf": "pbkdf2",
+			"kdfparams": {"c": 262144, "dklen": 32, "prf": "hmac-sha256", "salt": "ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"},
+			"mac": "517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"
+		},
+		"id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+		"version": 3
+	}`
+
+	privateKey, err := decryptKeystore([]byte(keyJSON), "testpassword")
+	if err != nil {
+		t.Fatalf("Failed to decrypt test vector: %v", err)
+	}
+	if got := hex.EncodeToString(crypto.FromECDSA(privateKey)); got != "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d" {
+		t.Fatalf("Unexpected private key: %s", got)
+	}
+	log.Printf("✅ PBKDF2 test vector decrypted")
+}
@@ -0,0 +1,150 @@
+package signingoracle
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DefaultKMSTimeout bounds each call to AWS KMS
+const DefaultKMSTimeout = 10 * time.Second
+
+// KMSClient is the subset of the AWS KMS API the KMS signer uses
+type KMSClient interface {
+	Sign(ctx context.Context, params *kms.SignInput, optFns ...func(*kms.Options)) (*kms.SignOutput, error)
+	GetPublicKey(ctx context.Context, params *kms.GetPublicKeyInput, optFns ...func(*kms.Options)) (*kms.GetPublicKeyOutput, error)
+}
+
+// KMSSigner signs with an ECC_SECG_P256K1 key held in AWS KMS, so the private key never enters
+// process memory
+type KMSSigner struct {
+	client    KMSClient
+	keyID     string
+	publicKey *ecdsa.PublicKey
+	address   common.Address
+	timeout   time.Duration
+}
+
+// subjectPublicKeyInfo is the DER structure KMS returns from GetPublicKey
+type subjectPublicKeyInfo struct {
+	Algorithm struct {
+		Algorithm  asn1.ObjectIdentifier
+		Parameters asn1.ObjectIdentifier
+	}
+	PublicKey asn1.BitString
+}
+
+// ecdsaSignature is the DER structure KMS returns from Sign
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// NewKMSSigner creates a signer for the KMS key keyID, fetching its public key to derive the address
+func NewKMSSigner(ctx context.Context, client KMSClient, keyID string) (*KMSSigner, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultKMSTimeout)
+	defer cancel()
+
+	output, err := client.GetPublicKey(ctx, &kms.GetPublicKeyInput{KeyId: aws.String(keyID)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get KMS public key: %w", err)
+	}
+	if output.KeySpec != types.KeySpecEccSecgP256k1 {
+		return nil, fmt.Errorf("KMS key %s has spec %s, expected %s", keyID, output.KeySpec, types.KeySpecEccSecgP256k1)
+	}
+
+	var info subjectPublicKeyInfo
+	if _, err := asn1.Unmarshal(output.PublicKey, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse KMS public key: %w", err)
+	}
+	publicKey, err := crypto.UnmarshalPubkey(info.PublicKey.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid KMS public key: %w", err)
+	}
+
+	return &KMSSigner{
+		client:    client,
+		keyID:     keyID,
+		publicKey: publicKey,
+		address:   crypto.PubkeyToAddress(*publicKey),
+		timeout:   DefaultKMSTimeout,
+	}, nil
+}
+
+// newKMSSignerFromEnv creates a KMS signer for KMS_KEY_ID using the default AWS credential chain
+func newKMSSignerFromEnv(keyID string) (*KMSSigner, error) {
+	if keyID == "" {
+		return nil, fmt.Errorf("KMS_KEY_ID environment variable is required when SIGNER=kms")
+	}
+
+	ctx := context.Background()
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	return NewKMSSigner(ctx, kms.NewFromConfig(cfg), keyID)
+}
+
+// SignHash asks KMS to sign the digest and rebuilds the 65-byte Ethereum signature: s is folded
+// into the lower half of the curve order as Ethereum requires, and the recovery id is found by
+// trying both values against the key's address.
+func (s *KMSSigner) SignHash(hash []byte) ([]byte, error) {
+	if len(hash) != 32 {
+		return nil, fmt.Errorf("hash is required to be exactly 32 bytes (%d)", len(hash))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	output, err := s.client.Sign(ctx, &kms.SignInput{
+		KeyId:            aws.String(s.keyID),
+		Message:          hash,
+		MessageType:      types.MessageTypeDigest,
+		SigningAlgorithm: types.SigningAlgorithmSpecEcdsaSha256,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("KMS sign failed: %w", err)
+	}
+
+	var der ecdsaSignature
+	if _, err := asn1.Unmarshal(output.Signature, &der); err != nil {
+		return nil, fmt.Errorf("failed to parse KMS signature: %w", err)
+	}
+
+	curveOrder := crypto.S256().Params().N
+	if der.S.Cmp(new(big.Int).Rsh(curveOrder, 1)) > 0 {
+		der.S = new(big.Int).Sub(curveOrder, der.S)
+	}
+
+	signature := make([]byte, 65)
+	der.R.FillBytes(signature[:32])
+	der.S.FillBytes(signature[32:64])
+
+	for v := byte(0); v <= 1; v++ {
+		signature[64] = v
+		publicKey, err := crypto.SigToPub(hash, signature)
+		if err == nil && crypto.PubkeyToAddress(*publicKey) == s.address {
+			return signature, nil
+		}
+	}
+	return nil, fmt.Errorf("KMS signature does not recover to %s", s.address.Hex())
+}
+
+// Address returns the Ethereum address of the KMS key
+func (s *KMSSigner) Address() common.Address {
+	return s.address
+}
+
+// PublicKey returns the public key of the KMS key
+func (s *KMSSigner) PublicKey() *ecdsa.PublicKey {
+	return s.publicKey
+}
@@ -0,0 +1,150 @@
+package signingoracle
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"encoding/hex"
+	"log"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// fakeKMS answers like AWS KMS for an in-memory secp256k1 key, returning DER signatures.
+// With highS set it returns the equally valid s' = N - s, as KMS may.
+type fakeKMS struct {
+	privateKey *ecdsa.PrivateKey
+	highS      bool
+}
+
+func (f *fakeKMS) GetPublicKey(ctx context.Context, params *kms.GetPublicKeyInput, optFns ...func(*kms.Options)) (*kms.GetPublicKeyOutput, error) {
+	var info subjectPublicKeyInfo
+	info.Algorithm.Algorithm = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	info.Algorithm.Parameters = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+	info.PublicKey = asn1.BitString{Bytes: crypto.FromECDSAPub(&f.privateKey.PublicKey), BitLength: 65 * 8}
+
+	der, err := asn1.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+	return &kms.GetPublicKeyOutput{KeyId: params.KeyId, KeySpec: types.KeySpecEccSecgP256k1, PublicKey: der}, nil
+}
+
+func (f *fakeKMS) Sign(ctx context.Context, params *kms.SignInput, optFns ...func(*kms.Options)) (*kms.SignOutput, error) {
+	signature, err := crypto.Sign(params.Message, f.privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:64])
+	if f.highS {
+		s.Sub(crypto.S256().Params().N, s)
+	}
+
+	der, err := asn1.Marshal(ecdsaSignature{R: r, S: s})
+	if err != nil {
+		return nil, err
+	}
+	return &kms.SignOutput{KeyId: params.KeyId, Signature: der}, nil
+}
+
+func TestKMSSigner_SignHash(t *testing.T) {
+	log.Printf("🧪 Starting TestKMSSigner_SignHash")
+
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	expected := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	for _, highS := range []bool{false, true} {
+		signer, err := NewKMSSigner(context.Background(), &fakeKMS{privateKey: privateKey, highS: highS}, "alias/oracle")
+		if err != nil {
+			t.Fatalf("Failed to create KMS signer: %v", err)
+		}
+		if signer.Address() != expected {
+			t.Fatalf("Expected address %s, got %s", expected.Hex(), signer.Address().Hex())
+		}
+
+		// Sign several hashes so both recovery ids are exercised
+		for i := 0; i < 8; i++ {
+			hash := crypto.Keccak256([]byte{byte(i)})
+			signature, err := signer.SignHash(hash)
+			if err != nil {
+				t.Fatalf("highS=%v: SignHash failed: %v", highS, err)
+			}
+			if signature[64] > 1 {
+				t.Fatalf("Expected v in {0,1}, got %d", signature[64])
+			}
+			publicKey, err := crypto.SigToPub(hash, signature)
+			if err != nil || crypto.PubkeyToAddress(*publicKey) != expected {
+				t.Fatalf("highS=%v: signature does not recover to the KMS key", highS)
+			}
+			if !crypto.VerifySignature(crypto.FromECDSAPub(publicKey), hash, signature[:64]) {
+				t.Fatalf("highS=%v: signature is not in canonical low-s form", highS)
+			}
+		}
+		log.Printf("✅ highS=%v: KMS signatures recover to %s", highS, expected.Hex())
+	}
+}
+
+func TestSigningOracle_WithKMSSigner(t *testing.T) {
+	log.Printf("🧪 Starting TestSigningOracle_WithKMSSigner")
+
+	os.Setenv("POLKADOT_RPC_URL", "https://rpc.polkadot.io")
+	defer os.Unsetenv("POLKADOT_RPC_URL")
+
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	signer, err := NewKMSSigner(context.Background(), &fakeKMS{privateKey: privateKey}, "alias/oracle")
+	if err != nil {
+		t.Fatalf("Failed to create KMS signer: %v", err)
+	}
+	oracle, err := NewSigningOracleWithSigner(signer)
+	if err != nil {
+		t.Fatalf("Failed to create signing oracle: %v", err)
+	}
+
+	if oracle.GetPrivateKeyHex() != "" {
+		t.Fatalf("Expected no private key to be exposed for a KMS signer")
+	}
+	if oracle.GetAddress() != crypto.PubkeyToAddress(privateKey.PublicKey).Hex() {
+		t.Fatalf("Unexpected oracle address %s", oracle.GetAddress())
+	}
+
+	signatureHex, err := oracle.SignEthereumMessage("hello")
+	if err != nil {
+		t.Fatalf("SignEthereumMessage failed: %v", err)
+	}
+	signature, _ := hex.DecodeString(signatureHex)
+	publicKey, err := crypto.SigToPub(oracle.toEthSignedMessageHash(crypto.Keccak256([]byte("hello"))), signature)
+	if err != nil || crypto.PubkeyToAddress(*publicKey).Hex() != oracle.GetAddress() {
+		t.Fatalf("Message signature does not recover to the oracle address")
+	}
+	log.Printf("✅ Oracle signs through KMS as %s", oracle.GetAddress())
+}
+
+func TestNewSigningOracle_InvalidSigner(t *testing.T) {
+	log.Printf("🧪 Starting TestNewSigningOracle_InvalidSigner")
+
+	os.Setenv("SIGNER", "hsm")
+	defer os.Unsetenv("SIGNER")
+
+	if _, err := NewSigningOracle(); err == nil {
+		t.Fatalf("Expected an unknown SIGNER to be rejected")
+	}
+
+	os.Setenv("SIGNER", "kms")
+	if _, err := NewSigningOracle(); err == nil {
+		t.Fatalf("Expected SIGNER=kms without KMS_KEY_ID to be rejected")
+	}
+	log.Printf("✅ Invalid signer configuration rejected")
+}
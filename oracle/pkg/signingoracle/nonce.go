@@ -0,0 +1,187 @@
+package signingoracle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// NonceStore holds the last nonce issued to each nominator. Implementations must be safe for
+// concurrent use.
+type NonceStore interface {
+	// Get returns the last nonce issued to the nominator, or zero when none has been
+	Get(nominator string) uint64
+	// Set records n as the last nonce issued to the nominator
+	Set(nominator string, n uint64) error
+}
+
+// MemoryNonceStore keeps nonces in memory only, so they start again from 1 when the oracle restarts
+type MemoryNonceStore struct {
+	mu   sync.Mutex
+	last map[string]uint64
+}
+
+// NewMemoryNonceStore creates an empty in-memory nonce store
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{last: make(map[string]uint64)}
+}
+
+// Get returns the last nonce issued to the nominator
+func (s *MemoryNonceStore) Get(nominator string) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.last[nominator]
+}
+
+// Set records n as the last nonce issued to the nominator
+func (s *MemoryNonceStore) Set(nominator string, n uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.last[nominator] = n
+	return nil
+}
+
+// FileNonceStore keeps nonces in memory and persists them as a JSON object of nominator to last
+// nonce, so a restarted oracle never reissues a nonce a contract may already have consumed.
+// Every Set rewrites the file through a temporary file and a rename, so it is never left half written.
+type FileNonceStore struct {
+	mu   sync.Mutex
+	path string
+	last map[string]uint64
+}
+
+// NewFileNonceStore creates a nonce store persisted at path, loading the nonces already there.
+// A missing file starts an empty store.
+func NewFileNonceStore(path string) (*FileNonceStore, error) {
+	store := &FileNonceStore{path: path, last: make(map[string]uint64)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read nonce store: %w", err)
+	}
+	if err := json.Unmarshal(data, &store.last); err != nil {
+		return nil, fmt.Errorf("failed to parse nonce store %s: %w", path, err)
+	}
+	if store.last == nil {
+		store.last = make(map[string]uint64)
+	}
+	return store, nil
+}
+
+// Get returns the last nonce issued to the nominator
+func (s *FileNonceStore) Get(nominator string) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.last[nominator]
+}
+
+// Set records n as the last nonce issued to the nominator and persists every nonce to disk.
+// The nonce is only kept when the write succeeds.
+func (s *FileNonceStore) Set(nominator string, n uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, existed := s.last[nominator]
+	s.last[nominator] = n
+	if err := s.persist(); err != nil {
+		if existed {
+			s.last[nominator] = previous
+		} else {
+			delete(s.last, nominator)
+		}
+		return err
+	}
+	return nil
+}
+
+// persist writes the nonces to a temporary file next to the store and renames it over the store
+func (s *FileNonceStore) persist() error {
+	data, err := json.Marshal(s.last)
+	if err != nil {
+		return fmt.Errorf("failed to encode nonce store: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create nonce store temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write nonce store: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync nonce store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close nonce store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to replace nonce store: %w", err)
+	}
+	return nil
+}
+
+// nonceTracker issues a monotonically increasing nonce per nominator, so no two signatures over
+// the same triplet are identical and a contract can reject a replayed one
+type nonceTracker struct {
+	mu    sync.Mutex
+	store NonceStore
+}
+
+func newNonceTracker(store NonceStore) *nonceTracker {
+	return &nonceTracker{store: store}
+}
+
+// next issues the nominator's next nonce, failing when it can't be recorded
+func (t *nonceTracker) next(nominator string) (uint64, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	nonce := t.store.Get(nominator) + 1
+	if err := t.store.Set(nominator, nonce); err != nil {
+		return 0, fmt.Errorf("failed to record nonce: %w", err)
+	}
+	return nonce, nil
+}
+
+// peek returns the last nonce issued to the nominator, or zero when none has been
+func (t *nonceTracker) peek(nominator string) uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.store.Get(nominator)
+}
+
+// setStore replaces the store nonces are issued from
+func (t *nonceTracker) setStore(store NonceStore) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.store = store
+}
+
+// LastNonce returns the last nonce issued to the nominator, or zero when none has been
+func (so *SigningOracle) LastNonce(nominator string) uint64 {
+	return so.nonces.peek(nominator)
+}
+
+// SetNonceStore replaces where issued nonces are kept; nil restores a fresh in-memory store.
+// Set it before signing, as nonces already issued from the previous store aren't carried over.
+func (so *SigningOracle) SetNonceStore(store NonceStore) {
+	if store == nil {
+		store = NewMemoryNonceStore()
+	}
+	so.nonces.setStore(store)
+}
@@ -0,0 +1,139 @@
+package signingoracle
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestFileNonceStore_PersistsAcrossRestarts(t *testing.T) {
+	log.Printf("🧪 Starting TestFileNonceStore_PersistsAcrossRestarts")
+
+	path := filepath.Join(t.TempDir(), "nonces.json")
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	store, err := NewFileNonceStore(path)
+	if err != nil {
+		t.Fatalf("Failed to create nonce store: %v", err)
+	}
+	oracle, err := NewSigningOracleWithSigner(NewLocalSigner(privateKey))
+	if err != nil {
+		t.Fatalf("Failed to create signing oracle: %v", err)
+	}
+	oracle.SetNonceStore(store)
+
+	for i := 0; i < 3; i++ {
+		if _, err := oracle.SignVerifiedDelegation(goldenValidator, goldenNominator, goldenMsg, nil); err != nil {
+			t.Fatalf("SignVerifiedDelegation failed: %v", err)
+		}
+	}
+	if err := store.Set("other", 41); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	log.Printf("📋 Issued nonce %d before the restart", oracle.LastNonce(goldenNominator))
+
+	// A new oracle loading the same file continues where the first left off
+	reloaded, err := NewFileNonceStore(path)
+	if err != nil {
+		t.Fatalf("Failed to reload nonce store: %v", err)
+	}
+	if reloaded.Get(goldenNominator) != 3 || reloaded.Get("other") != 41 {
+		t.Fatalf("Expected nonces 3 and 41 from disk, got %d and %d", reloaded.Get(goldenNominator), reloaded.Get("other"))
+	}
+	restarted, err := NewSigningOracleWithSigner(NewLocalSigner(privateKey))
+	if err != nil {
+		t.Fatalf("Failed to create signing oracle: %v", err)
+	}
+	restarted.SetNonceStore(reloaded)
+
+	signed, err := restarted.SignVerifiedDelegation(goldenValidator, goldenNominator, goldenMsg, nil)
+	if err != nil {
+		t.Fatalf("SignVerifiedDelegation failed: %v", err)
+	}
+	if signed.Nonce != 4 {
+		t.Fatalf("Expected the restarted oracle to issue nonce 4, got %d", signed.Nonce)
+	}
+	log.Printf("✅ Restarted oracle continued with nonce %d", signed.Nonce)
+
+	os.Setenv("NONCE_STORE_FILE", path)
+	defer os.Unsetenv("NONCE_STORE_FILE")
+	fromEnv, err := NewSigningOracleWithSigner(NewLocalSigner(privateKey))
+	if err != nil {
+		t.Fatalf("Failed to create signing oracle: %v", err)
+	}
+	if fromEnv.LastNonce(goldenNominator) != 4 {
+		t.Fatalf("Expected NONCE_STORE_FILE to load nonce 4, got %d", fromEnv.LastNonce(goldenNominator))
+	}
+	log.Printf("✅ NONCE_STORE_FILE loaded at startup")
+}
+
+func TestFileNonceStore_ConcurrentIssue(t *testing.T) {
+	log.Printf("🧪 Starting TestFileNonceStore_ConcurrentIssue")
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nonces.json")
+	store, err := NewFileNonceStore(path)
+	if err != nil {
+		t.Fatalf("Failed to create nonce store: %v", err)
+	}
+	tracker := newNonceTracker(store)
+
+	const issues = 50
+	issued := make(chan uint64, issues)
+	var wg sync.WaitGroup
+	for i := 0; i < issues; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nonce, err := tracker.next("nominator")
+			if err != nil {
+				t.Errorf("next failed: %v", err)
+			}
+			issued <- nonce
+		}()
+	}
+	wg.Wait()
+	close(issued)
+
+	seen := make(map[uint64]bool)
+	for nonce := range issued {
+		if seen[nonce] {
+			t.Fatalf("Nonce %d issued twice", nonce)
+		}
+		seen[nonce] = true
+	}
+
+	reloaded, err := NewFileNonceStore(path)
+	if err != nil {
+		t.Fatalf("Failed to reload nonce store: %v", err)
+	}
+	if reloaded.Get("nominator") != issues {
+		t.Fatalf("Expected %d on disk, got %d", issues, reloaded.Get("nominator"))
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Fatalf("Expected only the store file to remain, got %d entries", len(entries))
+	}
+	log.Printf("✅ %d concurrent nonces issued once each and persisted", issues)
+}
+
+func TestNewFileNonceStore_RejectsCorruptFile(t *testing.T) {
+	log.Printf("🧪 Starting TestNewFileNonceStore_RejectsCorruptFile")
+
+	path := filepath.Join(t.TempDir(), "nonces.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if _, err := NewFileNonceStore(path); err == nil {
+		t.Fatalf("Expected a corrupt nonce store to be refused rather than restarting from 1")
+	}
+	log.Printf("✅ Corrupt nonce store refused")
+}
@@ -0,0 +1,47 @@
+package signingoracle
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// rotationProbe is the hash a replacement signer must sign correctly before it is swapped in
+var rotationProbe = crypto.Keccak256([]byte("signing oracle key rotation"))
+
+// currentSigner returns the signer in use. Each signature is made with a single snapshot, so a
+// request that began before a rotation completes with the key it started with.
+func (so *SigningOracle) currentSigner() Signer {
+	so.signerMu.RLock()
+	defer so.signerMu.RUnlock()
+
+	return so.signer
+}
+
+// RotateSigner swaps the oracle's signer for signer without a restart and returns the address
+// of the key it replaced. The new signer must produce a low-s signature that recovers to its
+// own address, so a broken key or KMS binding is refused before any request is signed with it.
+// Signatures already being made complete with the previous signer.
+func (so *SigningOracle) RotateSigner(signer Signer) (previousAddress string, err error) {
+	if signer == nil {
+		return "", fmt.Errorf("signer is required")
+	}
+
+	signature, err := signer.SignHash(rotationProbe)
+	if err != nil {
+		return "", fmt.Errorf("new signer failed to sign: %w", err)
+	}
+	if err := checkLowS(signature); err != nil {
+		return "", err
+	}
+	if err := checkRecoversTo(rotationProbe, signature, signer.Address()); err != nil {
+		return "", fmt.Errorf("new signer refused: %w", err)
+	}
+
+	so.signerMu.Lock()
+	defer so.signerMu.Unlock()
+
+	previousAddress = so.signer.Address().Hex()
+	so.signer = signer
+	return previousAddress, nil
+}
@@ -0,0 +1,48 @@
+package signingoracle
+
+import (
+	"log"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestRotateSigner_RefusesBrokenSigner(t *testing.T) {
+	log.Printf("🧪 Starting TestRotateSigner_RefusesBrokenSigner")
+
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	oracle, err := NewSigningOracleWithSigner(NewLocalSigner(privateKey))
+	if err != nil {
+		t.Fatalf("Failed to create signing oracle: %v", err)
+	}
+	address := oracle.GetAddress()
+
+	replacement, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	for name, signer := range map[string]Signer{
+		"nil signer":    nil,
+		"high-s signer": highSSigner{NewLocalSigner(replacement)},
+	} {
+		if _, err := oracle.RotateSigner(signer); err == nil {
+			t.Fatalf("%s: expected the rotation to be refused", name)
+		}
+		if oracle.GetAddress() != address {
+			t.Fatalf("%s: expected the key to be unchanged, got %s", name, oracle.GetAddress())
+		}
+		log.Printf("✅ %s refused", name)
+	}
+
+	previous, err := oracle.RotateSigner(NewLocalSigner(replacement))
+	if err != nil || previous != address {
+		t.Fatalf("Expected a rotation away from %s, got %s: %v", address, previous, err)
+	}
+	if oracle.GetAddress() != crypto.PubkeyToAddress(replacement.PublicKey).Hex() {
+		t.Fatalf("Expected the replacement key, got %s", oracle.GetAddress())
+	}
+	log.Printf("✅ Rotated from %s to %s", previous, oracle.GetAddress())
+}
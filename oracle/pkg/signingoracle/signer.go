@@ -0,0 +1,98 @@
+package signingoracle
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Signer produces the oracle's secp256k1 signatures. Implementations may keep the key in process
+// (LocalSigner) or in a remote key store (KMSSigner), and must be safe for concurrent use.
+type Signer interface {
+	// SignHash signs a 32-byte hash, returning the 65-byte r||s||v signature with v in {0,1}
+	SignHash(hash []byte) ([]byte, error)
+	// Address returns the Ethereum address of the signing key
+	Address() common.Address
+}
+
+// errHighS is returned when a signer produces a signature whose s is in the upper half of the
+// curve order, which verifiers and contracts enforcing EIP-2 reject
+var errHighS = errors.New("signer produced a signature with s in the upper half of the curve order")
+
+// secp256k1HalfOrder is half the secp256k1 curve order, the largest s a signature may have
+var secp256k1HalfOrder = new(big.Int).Rsh(crypto.S256().Params().N, 1)
+
+// errSignatureMismatch is returned when a signature doesn't recover to the address of the signer
+// that produced it
+var errSignatureMismatch = errors.New("signature does not recover to the signer's address")
+
+// checkRecoversTo returns errSignatureMismatch unless the 65-byte r||s||v signature over hash
+// recovers to address
+func checkRecoversTo(hash, signature []byte, address common.Address) error {
+	publicKey, err := crypto.SigToPub(hash, signature)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errSignatureMismatch, err)
+	}
+	if recovered := crypto.PubkeyToAddress(*publicKey); recovered != address {
+		return fmt.Errorf("%w: recovered %s, expected %s", errSignatureMismatch, recovered.Hex(), address.Hex())
+	}
+	return nil
+}
+
+// checkLowS returns errHighS unless the s of the 65-byte r||s||v signature is in the lower half of the order
+func checkLowS(signature []byte) error {
+	if len(signature) < 64 || new(big.Int).SetBytes(signature[32:64]).Cmp(secp256k1HalfOrder) > 0 {
+		return errHighS
+	}
+	return nil
+}
+
+// publicKeySigner is implemented by signers that can expose their public key
+type publicKeySigner interface {
+	PublicKey() *ecdsa.PublicKey
+}
+
+// LocalSigner signs with a private key held in process memory
+type LocalSigner struct {
+	privateKey *ecdsa.PrivateKey
+}
+
+// NewLocalSigner creates a signer for an in-process private key
+func NewLocalSigner(privateKey *ecdsa.PrivateKey) *LocalSigner {
+	return &LocalSigner{privateKey: privateKey}
+}
+
+// NewLocalSignerFromHex creates a signer for a hex-encoded private key, with or without a 0x prefix
+func NewLocalSignerFromHex(privateKeyHex string) (*LocalSigner, error) {
+	privateKeyBytes, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(privateKeyHex), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode private key: %v", err)
+	}
+
+	privateKey, err := crypto.ToECDSA(privateKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create private key: %v", err)
+	}
+	return NewLocalSigner(privateKey), nil
+}
+
+// SignHash signs hash with the private key
+func (s *LocalSigner) SignHash(hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, s.privateKey)
+}
+
+// Address returns the Ethereum address of the private key
+func (s *LocalSigner) Address() common.Address {
+	return crypto.PubkeyToAddress(s.privateKey.PublicKey)
+}
+
+// PublicKey returns the public half of the private key
+func (s *LocalSigner) PublicKey() *ecdsa.PublicKey {
+	return &s.privateKey.PublicKey
+}
@@ -0,0 +1,155 @@
+package signingoracle
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultSigningRateWindow is the sliding window signatures are counted over when SIGNING_RATE_WINDOW is unset
+const DefaultSigningRateWindow = time.Minute
+
+// SigningRateStatus reports recent signing volume and whether it is above the alert threshold
+type SigningRateStatus struct {
+	Count     int    `json:"count"`
+	Threshold int    `json:"threshold"`
+	Window    string `json:"window"`
+	Alerting  bool   `json:"alerting"`
+}
+
+// signingRateAlert is the JSON body posted to the alert webhook
+type signingRateAlert struct {
+	Event     string `json:"event"`
+	Address   string `json:"address"`
+	Count     int    `json:"count"`
+	Threshold int    `json:"threshold"`
+	Window    string `json:"window"`
+	Time      int64  `json:"time"`
+}
+
+// signingRateMonitor counts signatures in a sliding window and raises an alert when more than
+// threshold are issued within it, as an early signal of key abuse. A zero threshold only counts.
+type signingRateMonitor struct {
+	mu         sync.Mutex
+	window     time.Duration
+	threshold  int
+	webhookURL string
+	client     *http.Client
+	signedAt   []time.Time
+	alerting   bool
+	// logger receives structured logs; nil means slog.Default(). Guarded by mu.
+	logger *slog.Logger
+}
+
+func newSigningRateMonitor(window time.Duration, threshold int, webhookURL string) *signingRateMonitor {
+	return &signingRateMonitor{
+		window:     window,
+		threshold:  threshold,
+		webhookURL: webhookURL,
+		client:     &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// setLogger replaces the monitor's logger; nil restores slog.Default()
+func (m *signingRateMonitor) setLogger(logger *slog.Logger) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.logger = logger
+}
+
+// log returns the monitor's logger. The caller must hold mu.
+func (m *signingRateMonitor) log() *slog.Logger {
+	if m.logger == nil {
+		return slog.Default()
+	}
+	return m.logger
+}
+
+// prune drops signatures that have left the window. The caller must hold mu.
+func (m *signingRateMonitor) prune(now time.Time) {
+	cutoff := now.Add(-m.window)
+	kept := 0
+	for kept < len(m.signedAt) && !m.signedAt[kept].After(cutoff) {
+		kept++
+	}
+	m.signedAt = m.signedAt[kept:]
+
+	if m.alerting && len(m.signedAt) <= m.threshold {
+		m.alerting = false
+		m.log().Info("signing rate back under threshold", "event", "signing_rate_recovered", "count", len(m.signedAt), "threshold", m.threshold, "window", m.window.String())
+	}
+}
+
+// record counts a signature issued at now and reports whether it raised a new alert
+func (m *signingRateMonitor) record(now time.Time, address string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.prune(now)
+	m.signedAt = append(m.signedAt, now)
+
+	count := len(m.signedAt)
+	if m.threshold <= 0 || count <= m.threshold || m.alerting {
+		return false
+	}
+
+	m.alerting = true
+	m.log().Warn("signing rate exceeded threshold", "event", "signing_rate_exceeded", "address", address, "count", count, "threshold", m.threshold, "window", m.window.String())
+
+	if m.webhookURL != "" {
+		go m.notify(m.log(), signingRateAlert{
+			Event:     "signing_rate_exceeded",
+			Address:   address,
+			Count:     count,
+			Threshold: m.threshold,
+			Window:    m.window.String(),
+			Time:      now.Unix(),
+		})
+	}
+	return true
+}
+
+// notify posts an alert to the webhook; failures are logged to logger, never returned to the signer.
+// It runs without mu, so record hands it the logger to use.
+func (m *signingRateMonitor) notify(logger *slog.Logger, alert signingRateAlert) {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		logger.Error("signing rate webhook failed", "event", "signing_rate_webhook_failed", "error", err)
+		return
+	}
+
+	resp, err := m.client.Post(m.webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		logger.Error("signing rate webhook failed", "event", "signing_rate_webhook_failed", "error", err)
+		return
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		logger.Error("signing rate webhook failed", "event", "signing_rate_webhook_failed", "status", resp.StatusCode)
+	}
+}
+
+// status reports the signing volume within the window ending at now
+func (m *signingRateMonitor) status(now time.Time) SigningRateStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.prune(now)
+	return SigningRateStatus{
+		Count:     len(m.signedAt),
+		Threshold: m.threshold,
+		Window:    m.window.String(),
+		Alerting:  m.alerting,
+	}
+}
+
+// SigningRate reports how many triplets were signed within the configured window and whether
+// that exceeds SIGNING_RATE_THRESHOLD
+func (so *SigningOracle) SigningRate() SigningRateStatus {
+	return so.signingRate.status(so.now())
+}
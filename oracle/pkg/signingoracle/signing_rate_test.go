@@ -0,0 +1,81 @@
+package signingoracle
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSigningRateAlert(t *testing.T) {
+	log.Printf("🧪 Starting TestSigningRateAlert")
+
+	alerts := make(chan signingRateAlert, 1)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert signingRateAlert
+		if err := json.NewDecoder(r.Body).Decode(&alert); err != nil {
+			t.Errorf("Failed to decode webhook body: %v", err)
+		}
+		alerts <- alert
+	}))
+	defer webhook.Close()
+
+	os.Setenv("PRIVATE_KEY", "1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")
+	os.Setenv("SIGNING_RATE_THRESHOLD", "3")
+	os.Setenv("SIGNING_RATE_WINDOW", "1m")
+	os.Setenv("SIGNING_RATE_WEBHOOK", webhook.URL)
+	defer os.Unsetenv("PRIVATE_KEY")
+	defer os.Unsetenv("SIGNING_RATE_THRESHOLD")
+	defer os.Unsetenv("SIGNING_RATE_WINDOW")
+	defer os.Unsetenv("SIGNING_RATE_WEBHOOK")
+
+	oracle, err := NewSigningOracle()
+	if err != nil {
+		t.Fatalf("Failed to create signing oracle: %v", err)
+	}
+
+	now := time.Unix(1_700_000_000, 0)
+	oracle.now = func() time.Time { return now }
+
+	// Signatures up to the threshold don't alert
+	for i := 0; i < 3; i++ {
+		if _, err := oracle.SignTriplet("validator", "nominator", "msg"); err != nil {
+			t.Fatalf("Failed to sign: %v", err)
+		}
+		now = now.Add(time.Second)
+	}
+	if status := oracle.SigningRate(); status.Alerting || status.Count != 3 {
+		t.Fatalf("Expected 3 signatures without alert, got %+v", status)
+	}
+	log.Printf("✅ No alert at the threshold")
+
+	// One more within the window trips the alert
+	if _, err := oracle.SignTriplet("validator", "nominator", "msg"); err != nil {
+		t.Fatalf("Failed to sign: %v", err)
+	}
+	status := oracle.SigningRate()
+	if !status.Alerting || status.Count != 4 {
+		t.Fatalf("Expected alert after 4 signatures, got %+v", status)
+	}
+	log.Printf("✅ Alert raised: %+v", status)
+
+	select {
+	case alert := <-alerts:
+		if alert.Event != "signing_rate_exceeded" || alert.Count != 4 || alert.Address != oracle.GetAddress() {
+			t.Fatalf("Unexpected webhook alert: %+v", alert)
+		}
+		log.Printf("✅ Webhook notified: %+v", alert)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected webhook to be notified")
+	}
+
+	// Once the signatures leave the window the alert clears
+	now = now.Add(2 * time.Minute)
+	if status := oracle.SigningRate(); status.Alerting || status.Count != 0 {
+		t.Fatalf("Expected alert to clear after the window, got %+v", status)
+	}
+	log.Printf("✅ Alert cleared once the window passed")
+}
@@ -1,79 +1,393 @@
 package signingoracle
 
 import (
-	"crypto/ecdsa"
 	"encoding/hex"
 	"fmt"
+	"log/slog"
+	"math/big"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"oracle/pkg/delegation"
+	"oracle/pkg/msghash"
 
 	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/text/unicode/norm"
 )
 
-// SigningOracle holds the private key for signing
+// DefaultMessagePrefix is the EIP-191 personal message prefix used by Ethereum.
+// The decimal length of the signed payload ("32" for a hash) is appended to it.
+const DefaultMessagePrefix = "\x19Ethereum Signed Message:\n"
+
+// SigningOracle signs verified delegations with its Signer.
+//
+// A SigningOracle is safe for concurrent use by multiple goroutines, as the HTTP server shares one
+// across its handlers. Its configuration is fixed when it is created and only read afterwards;
+// the state that changes while signing, the signing-rate window and the issued nonces, is guarded
+// by the mutex of the component that owns it, and the signer, which RotateSigner replaces, by
+// signerMu. SetLogger, SetNonceStore and SetDelegationChecker don't race with signing, but are
+// meant for setup, before the oracle is shared.
 type SigningOracle struct {
-	privateKey *ecdsa.PrivateKey
-	publicKey  *ecdsa.PublicKey
-	verifier   *delegation.Verifier
+	signerMu sync.RWMutex
+	signer   Signer
+
+	// Set when the oracle is created and read-only afterwards
+	verifier       *delegation.Verifier
+	checker        DelegationChecker
+	messagePrefix  string
+	attestationTTL time.Duration
+	signatureTTL   time.Duration
+	now            func() time.Time
+	normalizeMsg   bool
+	verifyOwnSigs  bool
+	domain         string
+	chainID        uint64
+
+	// Mutable state, each guarded by its own mutex
+	signingRate *signingRateMonitor
+	nonces      *nonceTracker
 }
 
-// NewSigningOracle creates a new signing oracle with a private key from environment
-func NewSigningOracle() (*SigningOracle, error) {
-	// Get private key from environment variable
-	privateKeyHex := os.Getenv("PRIVATE_KEY")
-	if privateKeyHex == "" {
-		return nil, fmt.Errorf("PRIVATE_KEY environment variable is required")
+// privateKeyHexFromEnv returns the hex private key, without a 0x prefix, from PRIVATE_KEY_FILE
+// or PRIVATE_KEY. The file is the preferred way to provide the key, as mounted secrets don't leak
+// through the process environment. Setting both is allowed only when they hold the same key, so
+// a stale inline key can't silently shadow a rotated file or vice versa.
+func privateKeyHexFromEnv() (string, error) {
+	inline := strings.TrimPrefix(strings.TrimSpace(os.Getenv("PRIVATE_KEY")), "0x")
+
+	path := os.Getenv("PRIVATE_KEY_FILE")
+	if path == "" {
+		if inline == "" {
+			return "", fmt.Errorf("PRIVATE_KEY or PRIVATE_KEY_FILE environment variable is required")
+		}
+		return inline, nil
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read PRIVATE_KEY_FILE: %w", err)
 	}
+	fromFile := strings.TrimPrefix(strings.TrimSpace(string(contents)), "0x")
+	if fromFile == "" {
+		return "", fmt.Errorf("PRIVATE_KEY_FILE %s is empty", path)
+	}
+	if inline != "" && !strings.EqualFold(inline, fromFile) {
+		return "", fmt.Errorf("PRIVATE_KEY and PRIVATE_KEY_FILE are both set but hold different keys")
+	}
+	return fromFile, nil
+}
 
-	// Remove "0x" prefix if present
-	privateKeyHex = strings.TrimPrefix(privateKeyHex, "0x")
+// NewSigningOracle creates a new signing oracle configured from the environment. SIGNER selects
+// the key backend: "local" (the default) signs with the key in PRIVATE_KEY_FILE or PRIVATE_KEY in
+// process, "kms" with the AWS KMS key KMS_KEY_ID.
+func NewSigningOracle() (*SigningOracle, error) {
+	switch backend := os.Getenv("SIGNER"); backend {
+	case "", "local":
+	case "kms":
+		signer, err := newKMSSignerFromEnv(os.Getenv("KMS_KEY_ID"))
+		if err != nil {
+			return nil, err
+		}
+		return newSigningOracle(signer)
+	default:
+		return nil, fmt.Errorf("invalid SIGNER: %s", backend)
+	}
 
-	// Decode the private key
-	privateKeyBytes, err := hex.DecodeString(privateKeyHex)
+	// Get private key from the environment
+	privateKeyHex, err := privateKeyHexFromEnv()
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode private key: %v", err)
+		return nil, err
 	}
 
-	// Create private key
-	privateKey, err := crypto.ToECDSA(privateKeyBytes)
+	signer, err := NewLocalSignerFromHex(privateKeyHex)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create private key: %v", err)
+		return nil, err
 	}
+	return newSigningOracle(signer)
+}
+
+// NewSigningOracleWithSigner creates a signing oracle that signs with signer, reading the rest of
+// its configuration from the environment
+func NewSigningOracleWithSigner(signer Signer) (*SigningOracle, error) {
+	return newSigningOracle(signer)
+}
 
-	// Derive public key from private key
-	publicKey := privateKey.Public().(*ecdsa.PublicKey)
+// newSigningOracle creates a signing oracle for signer, reading the rest of its configuration
+// from the environment
+func newSigningOracle(signer Signer) (*SigningOracle, error) {
+	var err error
 
-	// Get Polkadot RPC URL from environment
+	// Get the chain from environment, defaulting to Polkadot. SS58_PREFIX overrides the
+	// network's address prefix so custom chains can reuse the closest known network.
+	network := delegation.Polkadot
+	if value := os.Getenv("CHAIN"); value != "" {
+		network, err = delegation.NetworkByName(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CHAIN: %v", err)
+		}
+	}
+	if value := os.Getenv("SS58_PREFIX"); value != "" {
+		prefix, err := strconv.ParseUint(value, 10, 8)
+		if err != nil || prefix > 63 {
+			return nil, fmt.Errorf("invalid SS58_PREFIX: %s", value)
+		}
+		network.SS58Prefix = byte(prefix)
+	}
+
+	// Get the RPC URL from environment, defaulting to the network's public endpoint
 	rpcURL := os.Getenv("POLKADOT_RPC_URL")
 	if rpcURL == "" {
-		rpcURL = "https://rpc.polkadot.io" // Default to official Polkadot RPC
+		rpcURL = network.DefaultRPCURL
+	}
+
+	// Get the personal message prefix from environment, written with Go escapes
+	// (e.g. "\x19TRON Signed Message:\n"); defaults to the Ethereum prefix
+	messagePrefix := DefaultMessagePrefix
+	if rawPrefix := os.Getenv("MESSAGE_PREFIX"); rawPrefix != "" {
+		messagePrefix, err = strconv.Unquote(`"` + rawPrefix + `"`)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse MESSAGE_PREFIX: %v", err)
+		}
+	}
+	if err := msghash.ValidateMessagePrefix(messagePrefix); err != nil {
+		return nil, fmt.Errorf("invalid MESSAGE_PREFIX: %v", err)
+	}
+
+	// Get attestation lifetime from environment (Go duration, e.g. "5m")
+	attestationTTL := DefaultAttestationTTL
+	if value := os.Getenv("ATTESTATION_TTL"); value != "" {
+		attestationTTL, err = time.ParseDuration(value)
+		if err != nil || attestationTTL <= 0 {
+			return nil, fmt.Errorf("invalid ATTESTATION_TTL: %s", value)
+		}
+	}
+
+	// Get how long signed delegations stay valid from environment (Go duration, e.g. "10m")
+	signatureTTL := DefaultSignatureTTL
+	if value := os.Getenv("SIGNATURE_TTL"); value != "" {
+		signatureTTL, err = time.ParseDuration(value)
+		if err != nil || signatureTTL <= 0 {
+			return nil, fmt.Errorf("invalid SIGNATURE_TTL: %s", value)
+		}
+	}
+
+	verifierConfig := delegation.VerifierConfig{RPCURL: rpcURL, Network: network}
+
+	// Get the per-call RPC timeout from environment (Go duration, e.g. "10s")
+	if value := os.Getenv("RPC_TIMEOUT"); value != "" {
+		verifierConfig.Timeout, err = time.ParseDuration(value)
+		if err != nil || verifierConfig.Timeout <= 0 {
+			return nil, fmt.Errorf("invalid RPC_TIMEOUT: %s", value)
+		}
+	}
+
+	// Optionally change how often transient RPC failures are retried ("0" disables)
+	if value := os.Getenv("RPC_MAX_RETRIES"); value != "" {
+		maxRetries, err := strconv.Atoi(value)
+		if err != nil || maxRetries < 0 {
+			return nil, fmt.Errorf("invalid RPC_MAX_RETRIES: %s", value)
+		}
+		verifierConfig.MaxRetries = maxRetries
+		if maxRetries == 0 {
+			verifierConfig.MaxRetries = -1
+		}
+	}
+
+	// Optionally change how long passing verification results are reused (Go duration, "0" disables)
+	if value := os.Getenv("RESULT_CACHE_TTL"); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl < 0 {
+			return nil, fmt.Errorf("invalid RESULT_CACHE_TTL: %s", value)
+		}
+		verifierConfig.ResultCacheTTL = ttl
+		if ttl == 0 {
+			verifierConfig.ResultCacheTTL = -1
+		}
+	}
+
+	// Optionally change how long the active era is reused (Go duration, "0" disables)
+	if value := os.Getenv("ACTIVE_ERA_CACHE_TTL"); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl < 0 {
+			return nil, fmt.Errorf("invalid ACTIVE_ERA_CACHE_TTL: %s", value)
+		}
+		verifierConfig.ActiveEraCacheTTL = ttl
+		if ttl == 0 {
+			verifierConfig.ActiveEraCacheTTL = -1
+		}
+	}
+
+	// Optionally cap the RPC calls a single block scan may issue
+	if value := os.Getenv("MAX_RPC_CALLS_PER_VERIFY"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf("invalid MAX_RPC_CALLS_PER_VERIFY: %s", value)
+		}
+		verifierConfig.MaxRPCCallsPerVerify = limit
+	}
+
+	// Optionally find historical nominations through Subscan instead of scanning blocks
+	if apiKey := os.Getenv("SUBSCAN_API_KEY"); apiKey != "" {
+		verifierConfig.ProofSource = delegation.NewSubscanProofSource(os.Getenv("SUBSCAN_URL"), apiKey, network)
 	}
 
 	// Create delegation verifier
-	verifier := delegation.NewVerifier(rpcURL)
+	verifier := delegation.NewVerifierWithConfig(verifierConfig)
+
+	// Optionally require a minimum active bond (in planck) before signing
+	if value := os.Getenv("MIN_BONDED"); value != "" {
+		minBonded, ok := new(big.Int).SetString(value, 10)
+		if !ok || minBonded.Sign() < 0 {
+			return nil, fmt.Errorf("invalid MIN_BONDED: %s", value)
+		}
+		verifier.SetMinBonded(minBonded)
+	}
+
+	// Alert when more than SIGNING_RATE_THRESHOLD triplets are signed within SIGNING_RATE_WINDOW
+	signingRateWindow := DefaultSigningRateWindow
+	if value := os.Getenv("SIGNING_RATE_WINDOW"); value != "" {
+		signingRateWindow, err = time.ParseDuration(value)
+		if err != nil || signingRateWindow <= 0 {
+			return nil, fmt.Errorf("invalid SIGNING_RATE_WINDOW: %s", value)
+		}
+	}
+	signingRateThreshold := 0
+	if value := os.Getenv("SIGNING_RATE_THRESHOLD"); value != "" {
+		signingRateThreshold, err = strconv.Atoi(value)
+		if err != nil || signingRateThreshold < 0 {
+			return nil, fmt.Errorf("invalid SIGNING_RATE_THRESHOLD: %s", value)
+		}
+	}
+
+	// Optionally persist issued nonces, so a restart never reissues one a contract has consumed
+	var nonceStore NonceStore = NewMemoryNonceStore()
+	if path := os.Getenv("NONCE_STORE_FILE"); path != "" {
+		nonceStore, err = NewFileNonceStore(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid NONCE_STORE_FILE: %v", err)
+		}
+	}
+
+	// Optionally name the EVM chain signatures are meant for; zero leaves it unset
+	var chainID uint64
+	if value := os.Getenv("CHAIN_ID"); value != "" {
+		chainID, err = strconv.ParseUint(value, 10, 64)
+		if err != nil || chainID == 0 {
+			return nil, fmt.Errorf("invalid CHAIN_ID: %s", value)
+		}
+	}
 
 	return &SigningOracle{
-		privateKey: privateKey,
-		publicKey:  publicKey,
-		verifier:   verifier,
+		signer:         signer,
+		verifier:       verifier,
+		checker:        verifier,
+		messagePrefix:  messagePrefix,
+		attestationTTL: attestationTTL,
+		signatureTTL:   signatureTTL,
+		now:            time.Now,
+		normalizeMsg:   os.Getenv("NORMALIZE_MSG") == "true",
+		verifyOwnSigs:  os.Getenv("VERIFY_OWN_SIGNATURES") == "true",
+		domain:         os.Getenv("SIGNING_DOMAIN"),
+		chainID:        chainID,
+		signingRate:    newSigningRateMonitor(signingRateWindow, signingRateThreshold, os.Getenv("SIGNING_RATE_WEBHOOK")),
+		nonces:         newNonceTracker(nonceStore),
 	}, nil
 }
 
-// GetPrivateKeyHex returns the private key as a hex string
+// GetMessagePrefix returns the personal message prefix used when signing
+func (so *SigningOracle) GetMessagePrefix() string {
+	return so.messagePrefix
+}
+
+// GetDomain returns the domain mixed into every triplet hash; empty means no domain separation
+func (so *SigningOracle) GetDomain() string {
+	return so.domain
+}
+
+// GetChainID returns the EVM chain ID signatures are meant for, or zero when none is configured.
+// SignVerifiedDelegation binds it into every signature it produces.
+func (so *SigningOracle) GetChainID() uint64 {
+	return so.chainID
+}
+
+// GetNormalizeMsg reports whether message text is NFC-normalized before hashing
+func (so *SigningOracle) GetNormalizeMsg() bool {
+	return so.normalizeMsg
+}
+
+// packTriplet encodes the domain and triplet as Solidity's abi.encode(domain, validator, nominator,
+// msgText), each string as its UTF-8 bytes. The domain is left out, giving
+// abi.encode(validator, nominator, msgText), when it is empty. Unlike abi.encodePacked, every
+// string carries its length, so ("ab", "c") and ("a", "bc") no longer pack to the same bytes.
+func (so *SigningOracle) packTriplet(validator, nominator, msgText string) []byte {
+	if so.domain == "" {
+		return msghash.EncodeStrings(validator, nominator, so.normalizeMessage(msgText))
+	}
+	return msghash.EncodeStrings(so.domain, validator, nominator, so.normalizeMessage(msgText))
+}
+
+// toEthSignedMessageHash prefixes a 32-byte hash with the configured personal message prefix and hashes it
+func (so *SigningOracle) toEthSignedMessageHash(hash []byte) []byte {
+	return so.toEthSignedMessageHashLen(hash)
+}
+
+// toEthSignedMessageHashLen hashes msg behind the configured personal message prefix and the
+// decimal length of msg, e.g. "\x19Ethereum Signed Message:\n5hello"
+func (so *SigningOracle) toEthSignedMessageHashLen(msg []byte) []byte {
+	prefix := []byte(so.messagePrefix + strconv.Itoa(len(msg)))
+	return crypto.Keccak256(append(prefix, msg...))
+}
+
+// signHash signs hash with the oracle's Signer, asserting that the signature has a low s
+// so contracts enforcing EIP-2 accept it. With VERIFY_OWN_SIGNATURES it also asserts that the
+// signature recovers to the signer's address, so a signer bug surfaces as an error here rather
+// than as a signature a contract rejects on-chain.
+func (so *SigningOracle) signHash(hash []byte) ([]byte, error) {
+	signer := so.currentSigner()
+	signature, err := signer.SignHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLowS(signature); err != nil {
+		return nil, err
+	}
+	if so.verifyOwnSigs {
+		if err := checkRecoversTo(hash, signature, signer.Address()); err != nil {
+			return nil, err
+		}
+	}
+	return signature, nil
+}
+
+// GetPrivateKeyHex returns the private key as a hex string, or "" when the key isn't held in process
 func (so *SigningOracle) GetPrivateKeyHex() string {
-	return hex.EncodeToString(crypto.FromECDSA(so.privateKey))
+	local, ok := so.currentSigner().(*LocalSigner)
+	if !ok {
+		return ""
+	}
+	return hex.EncodeToString(crypto.FromECDSA(local.privateKey))
 }
 
-// GetPublicKeyHex returns the public key as a hex string
+// GetPublicKeyHex returns the public key as a hex string, or "" when the signer doesn't expose it
 func (so *SigningOracle) GetPublicKeyHex() string {
-	return hex.EncodeToString(crypto.FromECDSAPub(so.publicKey))
+	withPublicKey, ok := so.currentSigner().(publicKeySigner)
+	if !ok {
+		return ""
+	}
+	return hex.EncodeToString(crypto.FromECDSAPub(withPublicKey.PublicKey()))
 }
 
-// GetAddress returns the Ethereum address derived from the public key
+// GetAddress returns the Ethereum address of the signing key
 func (so *SigningOracle) GetAddress() string {
-	return crypto.PubkeyToAddress(*so.publicKey).Hex()
+	return so.currentSigner().Address().Hex()
+}
+
+// Address returns the Ethereum address of the signing key
+func (so *SigningOracle) Address() string {
+	return so.GetAddress()
 }
 
 // SignMessage signs the given message
@@ -82,7 +396,7 @@ func (so *SigningOracle) SignMessage(msg string) (string, error) {
 	msgHash := crypto.Keccak256Hash([]byte(msg))
 
 	// Sign the hash
-	signature, err := crypto.Sign(msgHash.Bytes(), so.privateKey)
+	signature, err := so.signHash(msgHash.Bytes())
 	if err != nil {
 		return "", fmt.Errorf("failed to sign message: %v", err)
 	}
@@ -91,38 +405,172 @@ func (so *SigningOracle) SignMessage(msg string) (string, error) {
 	return hex.EncodeToString(signature), nil
 }
 
-// SignEthereumMessage signs the given message with Ethereum signed message format
+// SignEthereumMessage signs the given message with Ethereum signed message format.
+// The recovery id v is go-ethereum's {0,1}; use SignEthereumMessageCanonical for on-chain use.
 func (so *SigningOracle) SignEthereumMessage(msg string) (string, error) {
+	signature, err := so.signEthereumMessage(msg)
+	if err != nil {
+		return "", err
+	}
+
+	// Return the signature as a hex string
+	return hex.EncodeToString(signature), nil
+}
+
+// SignEthereumMessageCanonical is SignEthereumMessage with v in {27,28}, as Solidity's ecrecover
+// and OpenZeppelin's ECDSA expect
+func (so *SigningOracle) SignEthereumMessageCanonical(msg string) (string, error) {
+	signature, err := so.signEthereumMessage(msg)
+	if err != nil {
+		return "", err
+	}
+
+	signature[64] += 27
+	return hex.EncodeToString(signature), nil
+}
+
+// signEthereumMessage returns the raw 65-byte r||s||v signature of msg, with v in {0,1}
+func (so *SigningOracle) signEthereumMessage(msg string) ([]byte, error) {
 	// Create the message hash
 	msgHash := crypto.Keccak256Hash([]byte(msg))
 
 	// Create Ethereum signed message hash
-	// Ethereum signed message prefix: "\x19Ethereum Signed Message:\n32"
-	prefix := []byte("\x19Ethereum Signed Message:\n32")
-	data := append(prefix, msgHash.Bytes()...)
-	ethSignedMessageHash := crypto.Keccak256(data)
+	// Default prefix: "\x19Ethereum Signed Message:\n32"
+	ethSignedMessageHash := so.toEthSignedMessageHash(msgHash.Bytes())
 
 	// Sign the Ethereum signed message hash
-	signature, err := crypto.Sign(ethSignedMessageHash, so.privateKey)
+	signature, err := so.signHash(ethSignedMessageHash)
 	if err != nil {
-		return "", fmt.Errorf("failed to sign Ethereum message: %v", err)
+		return nil, fmt.Errorf("failed to sign Ethereum message: %v", err)
 	}
+	return signature, nil
+}
 
-	// Return the signature as a hex string
-	return hex.EncodeToString(signature), nil
+// SignPersonalMessage signs raw as an EIP-191 personal message of any length, as eth_sign and
+// personal_sign do, returning the 65-byte r||s||v signature with v in {0,1}.
+// A 32-byte raw is signed exactly like a hash passed to the triplet methods, so raw must never
+// come from an untrusted caller.
+func (so *SigningOracle) SignPersonalMessage(raw []byte) ([]byte, error) {
+	return so.signHash(so.toEthSignedMessageHashLen(raw))
+}
+
+// normalizeMessage applies Unicode NFC normalization to msgText when NORMALIZE_MSG is enabled.
+// Normalization is off by default: a client that hashes the raw bytes of a non-NFC string
+// (e.g. "e\u0301" instead of "\u00e9") will not match a signature over the normalized text.
+func (so *SigningOracle) normalizeMessage(msgText string) string {
+	if so.normalizeMsg {
+		return norm.NFC.String(msgText)
+	}
+	return msgText
 }
 
-// SignTriplet signs keccak256(abi.encodePacked(validator, nominator, msgText))
-// with the EIP-191 "\x19Ethereum Signed Message:\n32" prefix.
+// SignTriplet signs keccak256(abi.encode(domain, validator, nominator, msgText)), see packTriplet,
+// with the configured EIP-191 prefix ("\x19Ethereum Signed Message:\n32" by default).
+// The domain (SIGNING_DOMAIN) keeps a signature for one use case from being replayed in another.
 func (so *SigningOracle) SignTriplet(validator, nominator, msgText string) (sig []byte, err error) {
-	packed := append(append([]byte(validator), []byte(nominator)...), []byte(msgText)...)
-	h := crypto.Keccak256(packed)
+	h := crypto.Keccak256(so.packTriplet(validator, nominator, msgText))
 
 	// EIP-191 for bytes32
-	prefix := []byte("\x19Ethereum Signed Message:\n32")
-	ethSigned := crypto.Keccak256(append(prefix, h...))
+	ethSigned := so.toEthSignedMessageHash(h)
+
+	so.signingRate.record(so.now(), so.GetAddress())
+
+	return so.signHash(ethSigned) // returns 65 bytes: r||s||v (v in {0,1})
+}
+
+// SignTripletForEra signs keccak256(abi.encodePacked(abi.encode(domain, validator, nominator, msgText), uint32 era))
+// with the configured EIP-191 prefix. Committing to the era lets a contract reject approvals
+// that were verified against an older validator-set snapshot.
+func (so *SigningOracle) SignTripletForEra(validator, nominator, msgText string, era uint32) (sig []byte, err error) {
+	packed := so.packTriplet(validator, nominator, msgText)
+
+	// uint32 is packed as 4 big-endian bytes
+	h := crypto.Keccak256(append(packed, msghash.PackUint32(era)...))
+
+	so.signingRate.record(so.now(), so.GetAddress())
+	return so.signHash(so.toEthSignedMessageHash(h))
+}
+
+// SignTripletWithNonce signs keccak256(abi.encodePacked(abi.encode(domain, validator, nominator, msgText), uint64 nonce))
+// with the configured EIP-191 prefix, so each nonce yields a distinct signature
+func (so *SigningOracle) SignTripletWithNonce(validator, nominator, msgText string, nonce uint64) (sig []byte, err error) {
+	packed := append(so.packTriplet(validator, nominator, msgText), msghash.PackUint64(nonce)...)
+
+	so.signingRate.record(so.now(), so.GetAddress())
+	return so.signHash(so.toEthSignedMessageHash(crypto.Keccak256(packed)))
+}
+
+// SignTripletForEraWithNonce signs
+// keccak256(abi.encodePacked(abi.encode(domain, validator, nominator, msgText), uint32 era, uint64 nonce))
+// with the configured EIP-191 prefix
+func (so *SigningOracle) SignTripletForEraWithNonce(validator, nominator, msgText string, era uint32, nonce uint64) (sig []byte, err error) {
+	packed := append(append(so.packTriplet(validator, nominator, msgText), msghash.PackUint32(era)...), msghash.PackUint64(nonce)...)
+
+	so.signingRate.record(so.now(), so.GetAddress())
+	return so.signHash(so.toEthSignedMessageHash(crypto.Keccak256(packed)))
+}
+
+// SignTripletAtBlock signs keccak256(abi.encodePacked(abi.encode(domain, validator, nominator, msgText), bytes32 blockHash))
+// with the configured EIP-191 prefix, binding the signature to the chain state the delegation
+// was confirmed at. blockHash is the 0x-prefixed hex of a 32-byte block hash.
+func (so *SigningOracle) SignTripletAtBlock(validator, nominator, msgText, blockHash string) (sig []byte, err error) {
+	encodedBlockHash, err := hex.DecodeString(strings.TrimPrefix(blockHash, "0x"))
+	if err != nil || len(encodedBlockHash) != 32 {
+		return nil, fmt.Errorf("invalid block hash %q: expected 32 hex-encoded bytes", blockHash)
+	}
+	packed := append(so.packTriplet(validator, nominator, msgText), encodedBlockHash...)
+
+	so.signingRate.record(so.now(), so.GetAddress())
+	return so.signHash(so.toEthSignedMessageHash(crypto.Keccak256(packed)))
+}
+
+// SignedDelegation is a verified triplet's signature together with the nonce and deadline it
+// commits to, all of which the caller must pass on to the contract
+type SignedDelegation struct {
+	Signature []byte
+	Nonce     uint64
+	// Deadline is the unix time, in seconds, after which the contract must reject the signature
+	Deadline int64
+}
+
+// SignVerifiedDelegation signs a triplet whose delegation has already been verified under the
+// nominator's next nonce and a deadline SIGNATURE_TTL from now:
+// keccak256(abi.encodePacked(abi.encode(domain, validator, nominator, msgText), [uint32 era], uint64 nonce, uint64 deadline)),
+// where the era is only packed when one is given
+func (so *SigningOracle) SignVerifiedDelegation(validator, nominator, msgText string, era *uint32) (*SignedDelegation, error) {
+	nonce, err := so.nonces.next(nominator)
+	if err != nil {
+		return nil, err
+	}
+	signed := &SignedDelegation{
+		Nonce:    nonce,
+		Deadline: so.now().Add(so.signatureTTL).Unix(),
+	}
+
+	packed := so.packTriplet(validator, nominator, msgText)
+	if era != nil {
+		packed = append(packed, msghash.PackUint32(*era)...)
+	}
+	packed = append(packed, msghash.PackUint64(signed.Nonce)...)
+	packed = append(packed, msghash.PackUint64(uint64(signed.Deadline))...)
+	if so.chainID != 0 {
+		packed = append(packed, msghash.PackUint64(so.chainID)...)
+	}
+
+	so.signingRate.record(so.now(), so.GetAddress())
+	signature, err := so.signHash(so.toEthSignedMessageHash(crypto.Keccak256(packed)))
+	if err != nil {
+		return nil, err
+	}
+	signed.Signature = signature
+	return signed, nil
+}
 
-	return crypto.Sign(ethSigned, so.privateKey) // returns 65 bytes: r||s||v (v in {0,1})
+// SetLogger sets the structured logger the oracle, its signing-rate monitor and its delegation
+// verifier report to; nil restores slog.Default()
+func (so *SigningOracle) SetLogger(logger *slog.Logger) {
+	so.signingRate.setLogger(logger)
+	so.verifier.SetLogger(logger)
 }
 
 // GetVerifier returns the delegation verifier
@@ -1,12 +1,25 @@
 package signingoracle
 
 import (
+	"bytes"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"log"
+	"log/slog"
+	"math/big"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+
+	"oracle/pkg/delegation"
+	"oracle/pkg/msghash"
 )
 
 func TestNewSigningOracle(t *testing.T) {
@@ -277,3 +290,407 @@ func TestSigningOracleImplementationCorrectness(t *testing.T) {
 
 	log.Printf("🎉 SigningOracle implementation is correct!")
 }
+
+func TestNewSigningOracle_Chain(t *testing.T) {
+	log.Printf("🧪 Starting TestNewSigningOracle_Chain")
+
+	os.Setenv("PRIVATE_KEY", "1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")
+	os.Setenv("CHAIN", "kusama")
+	defer os.Unsetenv("PRIVATE_KEY")
+	defer os.Unsetenv("CHAIN")
+
+	oracle, err := NewSigningOracle()
+	if err != nil {
+		t.Fatalf("Failed to create signing oracle: %v", err)
+	}
+	if network := oracle.GetVerifier().Network(); network != delegation.Kusama {
+		t.Fatalf("Expected Kusama, got %+v", network)
+	}
+	log.Printf("✅ CHAIN=kusama selects the Kusama network")
+
+	os.Setenv("SS58_PREFIX", "5")
+	defer os.Unsetenv("SS58_PREFIX")
+	oracle, err = NewSigningOracle()
+	if err != nil {
+		t.Fatalf("Failed to create signing oracle: %v", err)
+	}
+	if prefix := oracle.GetVerifier().Network().SS58Prefix; prefix != 5 {
+		t.Fatalf("Expected SS58_PREFIX to override the prefix, got %d", prefix)
+	}
+	log.Printf("✅ SS58_PREFIX overrides the network prefix")
+
+	os.Setenv("CHAIN", "westend")
+	if _, err := NewSigningOracle(); err == nil {
+		t.Fatalf("Expected an unknown CHAIN to be rejected")
+	}
+	log.Printf("✅ Unknown CHAIN rejected")
+}
+
+func TestNewSigningOracle_ChainID(t *testing.T) {
+	log.Printf("🧪 Starting TestNewSigningOracle_ChainID")
+
+	os.Setenv("PRIVATE_KEY", "1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")
+	defer os.Unsetenv("PRIVATE_KEY")
+	defer os.Unsetenv("CHAIN_ID")
+
+	os.Setenv("CHAIN_ID", "137")
+	oracle, err := NewSigningOracle()
+	if err != nil {
+		t.Fatalf("Failed to create signing oracle: %v", err)
+	}
+	if oracle.GetChainID() != 137 {
+		t.Fatalf("Expected chain ID 137, got %d", oracle.GetChainID())
+	}
+	log.Printf("✅ CHAIN_ID=137 parsed")
+
+	for _, value := range []string{"0", "-1", "mainnet"} {
+		os.Setenv("CHAIN_ID", value)
+		if _, err := NewSigningOracle(); err == nil {
+			t.Fatalf("Expected CHAIN_ID=%s to be rejected", value)
+		}
+		log.Printf("✅ CHAIN_ID=%s rejected", value)
+	}
+}
+
+// Golden signatures over fixed inputs with the test key. Signing is deterministic (RFC 6979), so
+// any change to these means the hashing changed and contracts would stop accepting signatures.
+const (
+	goldenValidator = "5GNJqTPyNqANBkUVMN1LPPrxXnFouWXoe2wNSmmEoLctxiZY"
+	goldenNominator = "5DfQJkzFUGDy3JUJW4ZBuERyrN7nVfPbxYtXAkfHQ7KkMtFU"
+	goldenMsg       = "msg"
+
+	goldenTripletSignature            = "5073ade01b62df21479bb3fdaca796eb37d00dec035758927ad998e7f9d7cf85274677bf68be3e9a0bc0551b42e04dd263865f1bcc70a98c9ba78d9bd8e84ed501"
+	goldenVerifiedDelegationSignature = "b70bb657f2ac4afdb37aa0789d4be81e1067420c3294c5e27e0131acf44c46d06c106ee0dd99fb08ae381ef080f43b2456ebf3b70e0a21aaad8d0c9c778fe4f900"
+)
+
+func TestSignTriplet_GoldenSignature(t *testing.T) {
+	log.Printf("🧪 Starting TestSignTriplet_GoldenSignature")
+
+	os.Setenv("PRIVATE_KEY", "1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")
+	defer os.Unsetenv("PRIVATE_KEY")
+
+	oracle, err := NewSigningOracle()
+	if err != nil {
+		t.Fatalf("Failed to create signing oracle: %v", err)
+	}
+
+	signature, err := oracle.SignTriplet(goldenValidator, goldenNominator, goldenMsg)
+	if err != nil {
+		t.Fatalf("Failed to sign triplet: %v", err)
+	}
+	if got := hex.EncodeToString(signature); got != goldenTripletSignature {
+		t.Fatalf("Triplet signature changed:\n got: %s\nwant: %s", got, goldenTripletSignature)
+	}
+	log.Printf("✅ Triplet signature matches golden value")
+
+	// The triplet is packed as abi.encode(string, string, string), so signing that as one message is identical
+	encoded, err := oracle.SignEthereumMessage(string(msghash.EncodeStrings(goldenValidator, goldenNominator, goldenMsg)))
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	if encoded != hex.EncodeToString(signature) {
+		t.Fatalf("SignTriplet and SignEthereumMessage disagree on the encoded input:\n%s\n%x", encoded, signature)
+	}
+	log.Printf("✅ SignTriplet matches SignEthereumMessage over the abi.encode packing")
+
+	// Era, nonce and deadline are packed after the triplet in that order
+	oracle.now = func() time.Time { return time.Unix(1700000000, 0) }
+	era := uint32(1523)
+	signed, err := oracle.SignVerifiedDelegation(goldenValidator, goldenNominator, goldenMsg, &era)
+	if err != nil {
+		t.Fatalf("Failed to sign verified delegation: %v", err)
+	}
+	if signed.Nonce != 1 || signed.Deadline != 1700000000+int64(DefaultSignatureTTL.Seconds()) {
+		t.Fatalf("Unexpected nonce %d or deadline %d", signed.Nonce, signed.Deadline)
+	}
+	if got := hex.EncodeToString(signed.Signature); got != goldenVerifiedDelegationSignature {
+		t.Fatalf("Verified delegation signature changed:\n got: %s\nwant: %s", got, goldenVerifiedDelegationSignature)
+	}
+	log.Printf("✅ Verified delegation signature matches golden value")
+}
+
+// highSSigner flips the s of every signature from an inner signer to its upper-half complement
+type highSSigner struct {
+	*LocalSigner
+}
+
+func (s highSSigner) SignHash(hash []byte) ([]byte, error) {
+	signature, err := s.LocalSigner.SignHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	new(big.Int).Sub(crypto.S256().Params().N, new(big.Int).SetBytes(signature[32:64])).FillBytes(signature[32:64])
+	signature[64] ^= 1
+	return signature, nil
+}
+
+func TestSignTriplet_AssertsLowS(t *testing.T) {
+	log.Printf("🧪 Starting TestSignTriplet_AssertsLowS")
+
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	oracle, err := NewSigningOracleWithSigner(NewLocalSigner(privateKey))
+	if err != nil {
+		t.Fatalf("Failed to create signing oracle: %v", err)
+	}
+	signature, err := oracle.SignTriplet("validator", "nominator", "msg")
+	if err != nil {
+		t.Fatalf("SignTriplet failed: %v", err)
+	}
+	if checkLowS(signature) != nil {
+		t.Fatalf("Expected a low-s signature, got %x", signature)
+	}
+	log.Printf("✅ Oracle signature has a low s")
+
+	oracle, err = NewSigningOracleWithSigner(highSSigner{NewLocalSigner(privateKey)})
+	if err != nil {
+		t.Fatalf("Failed to create signing oracle: %v", err)
+	}
+	if _, err := oracle.SignTriplet("validator", "nominator", "msg"); !errors.Is(err, errHighS) {
+		t.Fatalf("Expected a high-s signature to be refused, got: %v", err)
+	}
+	log.Printf("✅ High-s signature from the signer refused")
+}
+
+// misaddressedSigner signs with one key but reports the address of another, as a misconfigured
+// remote signer might
+type misaddressedSigner struct {
+	*LocalSigner
+	address common.Address
+}
+
+func (s misaddressedSigner) Address() common.Address {
+	return s.address
+}
+
+func TestSignTriplet_VerifyOwnSignatures(t *testing.T) {
+	log.Printf("🧪 Starting TestSignTriplet_VerifyOwnSignatures")
+
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	otherKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	misaddressed := misaddressedSigner{NewLocalSigner(privateKey), crypto.PubkeyToAddress(otherKey.PublicKey)}
+
+	// Off by default, a signature from a misaddressed signer goes unnoticed
+	oracle, err := NewSigningOracleWithSigner(misaddressed)
+	if err != nil {
+		t.Fatalf("Failed to create signing oracle: %v", err)
+	}
+	if _, err := oracle.SignTriplet("validator", "nominator", "msg"); err != nil {
+		t.Fatalf("Expected signing without self-verification to succeed, got: %v", err)
+	}
+	log.Printf("✅ Self-verification off by default")
+
+	t.Setenv("VERIFY_OWN_SIGNATURES", "true")
+	oracle, err = NewSigningOracleWithSigner(NewLocalSigner(privateKey))
+	if err != nil {
+		t.Fatalf("Failed to create signing oracle: %v", err)
+	}
+	if _, err := oracle.SignTriplet("validator", "nominator", "msg"); err != nil {
+		t.Fatalf("Expected a self-verified triplet signature, got: %v", err)
+	}
+	if _, err := oracle.SignEthereumMessage("hello"); err != nil {
+		t.Fatalf("Expected a self-verified message signature, got: %v", err)
+	}
+	log.Printf("✅ Normal signatures pass self-verification")
+
+	oracle, err = NewSigningOracleWithSigner(misaddressed)
+	if err != nil {
+		t.Fatalf("Failed to create signing oracle: %v", err)
+	}
+	if _, err := oracle.SignTriplet("validator", "nominator", "msg"); !errors.Is(err, errSignatureMismatch) {
+		t.Fatalf("Expected a signature from the wrong key to be refused, got: %v", err)
+	}
+	log.Printf("✅ Signature from the wrong key refused")
+}
+
+// personalSignHash is the hash personal_sign signs for raw under the default prefix
+func personalSignHash(raw []byte) []byte {
+	return crypto.Keccak256([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(raw), raw)))
+}
+
+func TestSignPersonalMessage(t *testing.T) {
+	log.Printf("🧪 Starting TestSignPersonalMessage")
+
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	oracle, err := NewSigningOracleWithSigner(NewLocalSigner(privateKey))
+	if err != nil {
+		t.Fatalf("Failed to create signing oracle: %v", err)
+	}
+
+	for name, raw := range map[string][]byte{
+		"short string":     []byte("hello"),
+		"100-byte payload": bytes.Repeat([]byte{0xa5}, 100),
+	} {
+		signature, err := oracle.SignPersonalMessage(raw)
+		if err != nil {
+			t.Fatalf("%s: SignPersonalMessage failed: %v", name, err)
+		}
+
+		// The prefix must carry the payload's own length, as personal_sign does
+		publicKey, err := crypto.SigToPub(personalSignHash(raw), signature)
+		if err != nil || crypto.PubkeyToAddress(*publicKey) != crypto.PubkeyToAddress(privateKey.PublicKey) {
+			t.Fatalf("%s: expected the signature to recover the oracle over the personal_sign hash, got %v", name, err)
+		}
+		log.Printf("✅ %s: signed over \\x19Ethereum Signed Message:\\n%d", name, len(raw))
+	}
+
+	// A 32-byte message keeps the common path's prefix
+	hash := crypto.Keccak256([]byte("payload"))
+	if !bytes.Equal(oracle.toEthSignedMessageHashLen(hash), oracle.toEthSignedMessageHash(hash)) {
+		t.Fatalf("Expected a 32-byte message to hash like the 32-byte path")
+	}
+	log.Printf("✅ 32-byte messages hash like the 32-byte path")
+}
+
+// TestSigningOracle_ConcurrentSigning shares one oracle between goroutines as the HTTP server does;
+// run it with -race to catch unguarded state
+func TestSigningOracle_ConcurrentSigning(t *testing.T) {
+	log.Printf("🧪 Starting TestSigningOracle_ConcurrentSigning")
+
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	oracle, err := NewSigningOracleWithSigner(NewLocalSigner(privateKey))
+	if err != nil {
+		t.Fatalf("Failed to create signing oracle: %v", err)
+	}
+
+	const workers = 64
+	signatures := make([][]byte, workers)
+	nonces := make([]uint64, workers)
+	errs := make(chan error, 2*workers+1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			signature, err := oracle.SignTriplet(goldenValidator, goldenNominator, fmt.Sprintf("msg-%d", i))
+			if err != nil {
+				errs <- err
+				return
+			}
+			signatures[i] = signature
+
+			signed, err := oracle.SignVerifiedDelegation(goldenValidator, goldenNominator, goldenMsg, nil)
+			if err != nil {
+				errs <- err
+				return
+			}
+			nonces[i] = signed.Nonce
+
+			oracle.SigningRate()
+		}(i)
+	}
+	// The signing-rate monitor logs while signing, so replacing its logger mustn't race with it,
+	// and neither may rotating the signer, here to the same key so every signature still recovers
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		oracle.SetLogger(slog.Default())
+	}()
+	go func() {
+		defer wg.Done()
+		if _, err := oracle.RotateSigner(NewLocalSigner(privateKey)); err != nil {
+			errs <- err
+		}
+	}()
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("Concurrent signing failed: %v", err)
+	}
+
+	for i, signature := range signatures {
+		hash := oracle.toEthSignedMessageHash(crypto.Keccak256(oracle.packTriplet(goldenValidator, goldenNominator, fmt.Sprintf("msg-%d", i))))
+		publicKey, err := crypto.SigToPub(hash, signature)
+		if err != nil || crypto.PubkeyToAddress(*publicKey) != crypto.PubkeyToAddress(privateKey.PublicKey) {
+			t.Fatalf("Signature %d doesn't recover to the oracle: %v", i, err)
+		}
+	}
+	log.Printf("✅ %d concurrent triplet signatures recover to the oracle", workers)
+
+	seen := make(map[uint64]bool, workers)
+	for _, nonce := range nonces {
+		if nonce < 1 || nonce > workers || seen[nonce] {
+			t.Fatalf("Expected nonces 1 to %d issued once each, got %v", workers, nonces)
+		}
+		seen[nonce] = true
+	}
+	if last := oracle.LastNonce(goldenNominator); last != workers {
+		t.Fatalf("Expected last nonce %d, got %d", workers, last)
+	}
+	if count := oracle.SigningRate().Count; count != 2*workers {
+		t.Fatalf("Expected %d signatures counted, got %d", 2*workers, count)
+	}
+	log.Printf("✅ Nonces 1 to %d issued once each and every signature counted", workers)
+}
+
+func TestNewSigningOracle_PrivateKeyFile(t *testing.T) {
+	log.Printf("🧪 Starting TestNewSigningOracle_PrivateKeyFile")
+
+	const key = "1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+	const otherKey = "f0d74d99fe2407579e9dfa323ca8a3d83114773326bb99764b8b817bed78f784"
+
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "key")
+	if err := os.WriteFile(keyFile, []byte("0x"+key+"\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write key file: %v", err)
+	}
+
+	t.Setenv("PRIVATE_KEY", key)
+	t.Setenv("PRIVATE_KEY_FILE", "")
+	inline, err := NewSigningOracle()
+	if err != nil {
+		t.Fatalf("Failed to create signing oracle from PRIVATE_KEY: %v", err)
+	}
+	log.Printf("✅ Inline key loaded: %s", inline.GetAddress())
+
+	cases := []struct {
+		name    string
+		inline  string
+		file    string
+		wantErr string
+	}{
+		{"file only", "", keyFile, ""},
+		{"file and matching inline key", "0x" + strings.ToUpper(key), keyFile, ""},
+		{"file and different inline key", otherKey, keyFile, "different keys"},
+		{"unreadable file", "", filepath.Join(dir, "missing"), "failed to read PRIVATE_KEY_FILE"},
+		{"neither", "", "", "PRIVATE_KEY or PRIVATE_KEY_FILE"},
+	}
+
+	for _, tc := range cases {
+		t.Setenv("PRIVATE_KEY", tc.inline)
+		t.Setenv("PRIVATE_KEY_FILE", tc.file)
+
+		oracle, err := NewSigningOracle()
+		if tc.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("%s: expected an error containing %q, got: %v", tc.name, tc.wantErr, err)
+			}
+			log.Printf("✅ %s rejected: %v", tc.name, err)
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: failed to create signing oracle: %v", tc.name, err)
+		}
+		if oracle.GetAddress() != inline.GetAddress() {
+			t.Fatalf("%s: expected address %s, got %s", tc.name, inline.GetAddress(), oracle.GetAddress())
+		}
+		log.Printf("✅ %s loaded the same key", tc.name)
+	}
+}
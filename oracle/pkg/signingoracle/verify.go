@@ -0,0 +1,154 @@
+package signingoracle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"oracle/pkg/delegation"
+)
+
+var (
+	// ErrDelegationNotFound is matched, with errors.Is, when the nominator hasn't delegated to the
+	// validator. Use errors.As with *VerificationError for the sub-checks.
+	ErrDelegationNotFound = errors.New("nominator has not delegated to the specified validator")
+	// ErrBondBelowThreshold is matched, with errors.Is, when the nominator's active bond is below
+	// the configured minimum. Use errors.As with *VerificationError for the sub-checks.
+	ErrBondBelowThreshold = errors.New("nominator's active bond is below the required minimum")
+	// ErrActiveEraUnavailable is matched, with errors.Is, when the active era a signature should
+	// commit to couldn't be looked up
+	ErrActiveEraUnavailable = errors.New("failed to look up active era")
+	// ErrSigningFailed is matched, with errors.Is, when a verified delegation couldn't be signed
+	ErrSigningFailed = errors.New("failed to sign verified delegation")
+)
+
+// VerificationError is a delegation that failed one of the checks CheckDelegation runs
+type VerificationError struct {
+	// Err is ErrDelegationNotFound or ErrBondBelowThreshold
+	Err error
+	// Verification holds the sub-checks, which tell why the delegation failed
+	Verification *delegation.VerificationResult
+}
+
+func (e *VerificationError) Error() string { return e.Err.Error() }
+
+func (e *VerificationError) Unwrap() error { return e.Err }
+
+// DelegationChecker verifies nominations on-chain before anything is signed.
+// *delegation.Verifier implements it.
+type DelegationChecker interface {
+	VerifyDelegationDetail(ctx context.Context, nominatorAddress, validatorAddress string) (*delegation.VerificationResult, error)
+	ActiveEra(ctx context.Context) (uint32, error)
+	CheckBondedThreshold(ctx context.Context, nominatorAddress string) (bool, *big.Int, error)
+}
+
+var _ DelegationChecker = (*delegation.Verifier)(nil)
+
+// CheckDelegation runs the checks every signature requires against checker: the nominator has
+// delegated to the validator and its active bond meets the configured minimum. A delegation that
+// fails them is reported as a *VerificationError; errors reading the chain are returned wrapped.
+func CheckDelegation(ctx context.Context, checker DelegationChecker, validator, nominator string) (*delegation.VerificationResult, error) {
+	verification, err := checker.VerifyDelegationDetail(ctx, nominator, validator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify delegation: %w", err)
+	}
+	if !verification.IsValid {
+		return verification, &VerificationError{Err: ErrDelegationNotFound, Verification: verification}
+	}
+
+	meetsThreshold, bonded, err := checker.CheckBondedThreshold(ctx, nominator)
+	if err != nil {
+		return verification, fmt.Errorf("failed to check bonded amount: %w", err)
+	}
+	if bonded != nil {
+		verification.BondedThresholdValidation = meetsThreshold
+		verification.BondedAmount = bonded.String()
+	}
+	if !meetsThreshold {
+		return verification, &VerificationError{Err: ErrBondBelowThreshold, Verification: verification}
+	}
+	return verification, nil
+}
+
+// Response is a delegation VerifyAndSign verified and signed, together with the nonce, deadline
+// and, when bound, era its signature commits to
+type Response struct {
+	ValidatorAddress string
+	NominatorAddress string
+	Msg              string
+	// Signature is the 65-byte r||s||v signature, nil in a dry run
+	Signature []byte
+	Era       *uint32
+	Nonce     uint64
+	Deadline  int64
+	// DryRun is set when the delegation was verified but, as asked, not signed
+	DryRun       bool
+	Verification *delegation.VerificationResult
+}
+
+// VerifyOptions adjust what VerifyAndSignWithOptions signs
+type VerifyOptions struct {
+	// BindEra commits the signature to the active era
+	BindEra bool
+	// Era, when set, is the era to commit to in place of looking up the active era, so that
+	// several signatures can share one lookup. It implies BindEra.
+	Era *uint32
+	// DryRun stops after verification: nothing is signed and no nonce is issued
+	DryRun bool
+}
+
+// VerifyAndSign checks the nominator's delegation to the validator with the oracle's delegation
+// checker and, when it passes, signs the triplet as SignVerifiedDelegation does. It is the
+// transport-independent core of the /verify endpoint.
+func (so *SigningOracle) VerifyAndSign(ctx context.Context, validator, nominator, msg string) (*Response, error) {
+	return so.VerifyAndSignWithOptions(ctx, validator, nominator, msg, VerifyOptions{})
+}
+
+// VerifyAndSignWithOptions is VerifyAndSign, optionally committing to the active era or a given
+// one, or verifying without signing
+func (so *SigningOracle) VerifyAndSignWithOptions(ctx context.Context, validator, nominator, msg string, opts VerifyOptions) (*Response, error) {
+	verification, err := CheckDelegation(ctx, so.checker, validator, nominator)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &Response{
+		ValidatorAddress: validator,
+		NominatorAddress: nominator,
+		Msg:              msg,
+		Verification:     verification,
+	}
+	if opts.Era != nil {
+		era := *opts.Era
+		response.Era = &era
+	} else if opts.BindEra {
+		era, err := so.checker.ActiveEra(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrActiveEraUnavailable, err)
+		}
+		response.Era = &era
+	}
+	if opts.DryRun {
+		response.DryRun = true
+		return response, nil
+	}
+
+	signed, err := so.SignVerifiedDelegation(validator, nominator, msg, response.Era)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSigningFailed, err)
+	}
+	response.Signature = signed.Signature
+	response.Nonce = signed.Nonce
+	response.Deadline = signed.Deadline
+	return response, nil
+}
+
+// SetDelegationChecker replaces what VerifyAndSign checks delegations with, e.g. a stub in tests;
+// nil restores the oracle's delegation verifier
+func (so *SigningOracle) SetDelegationChecker(checker DelegationChecker) {
+	if checker == nil {
+		checker = so.verifier
+	}
+	so.checker = checker
+}
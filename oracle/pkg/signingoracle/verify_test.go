@@ -0,0 +1,200 @@
+package signingoracle
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"oracle/pkg/delegation"
+	"oracle/pkg/msghash"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// stubChecker reports a fixed delegation outcome, active era and bond, and records the addresses
+// it was asked about
+type stubChecker struct {
+	delegated bool
+	bonded    *big.Int
+	minBonded *big.Int
+	era       uint32
+	err       error
+	eraErr    error
+
+	nominator, validator string
+}
+
+func (s *stubChecker) VerifyDelegationDetail(ctx context.Context, nominatorAddress, validatorAddress string) (*delegation.VerificationResult, error) {
+	s.nominator, s.validator = nominatorAddress, validatorAddress
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &delegation.VerificationResult{
+		NominatorAddress:  nominatorAddress,
+		ValidatorAddress:  validatorAddress,
+		IsValid:           s.delegated,
+		AddressValidation: true,
+		StorageValidation: s.delegated,
+	}, nil
+}
+
+func (s *stubChecker) ActiveEra(ctx context.Context) (uint32, error) {
+	return s.era, s.eraErr
+}
+
+func (s *stubChecker) CheckBondedThreshold(ctx context.Context, nominatorAddress string) (bool, *big.Int, error) {
+	if s.minBonded == nil {
+		return true, nil, nil
+	}
+	return s.bonded.Cmp(s.minBonded) >= 0, s.bonded, nil
+}
+
+func newStubbedOracle(t *testing.T, checker DelegationChecker) *SigningOracle {
+	t.Helper()
+
+	os.Setenv("PRIVATE_KEY", "1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")
+	defer os.Unsetenv("PRIVATE_KEY")
+
+	oracle, err := NewSigningOracle()
+	if err != nil {
+		t.Fatalf("Failed to create signing oracle: %v", err)
+	}
+	oracle.now = func() time.Time { return time.Unix(1700000000, 0) }
+	oracle.SetDelegationChecker(checker)
+	return oracle
+}
+
+// responseSigner recovers the address that signed resp under the era, nonce and deadline it reports
+func responseSigner(t *testing.T, oracle *SigningOracle, resp *Response) string {
+	t.Helper()
+
+	packed := oracle.packTriplet(resp.ValidatorAddress, resp.NominatorAddress, resp.Msg)
+	if resp.Era != nil {
+		packed = append(packed, msghash.PackUint32(*resp.Era)...)
+	}
+	packed = append(packed, msghash.PackUint64(resp.Nonce)...)
+	packed = append(packed, msghash.PackUint64(uint64(resp.Deadline))...)
+
+	publicKey, err := crypto.SigToPub(oracle.toEthSignedMessageHash(crypto.Keccak256(packed)), resp.Signature)
+	if err != nil {
+		t.Fatalf("Failed to recover signer: %v", err)
+	}
+	return crypto.PubkeyToAddress(*publicKey).Hex()
+}
+
+func TestVerifyAndSign_SignsVerifiedDelegation(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyAndSign_SignsVerifiedDelegation")
+
+	checker := &stubChecker{delegated: true, era: 1523}
+	oracle := newStubbedOracle(t, checker)
+
+	resp, err := oracle.VerifyAndSign(context.Background(), goldenValidator, goldenNominator, goldenMsg)
+	if err != nil {
+		t.Fatalf("VerifyAndSign failed: %v", err)
+	}
+	if checker.nominator != goldenNominator || checker.validator != goldenValidator {
+		t.Fatalf("Expected the checker to be asked about %s -> %s, got %s -> %s", goldenNominator, goldenValidator, checker.nominator, checker.validator)
+	}
+	if resp.Verification == nil || !resp.Verification.IsValid {
+		t.Fatalf("Expected the passing verification, got %+v", resp.Verification)
+	}
+	if resp.Era != nil || resp.DryRun || resp.Nonce != 1 || resp.Deadline != 1700000000+int64(DefaultSignatureTTL.Seconds()) {
+		t.Fatalf("Unexpected signed fields: era=%v dryRun=%v nonce=%d deadline=%d", resp.Era, resp.DryRun, resp.Nonce, resp.Deadline)
+	}
+	if got := responseSigner(t, oracle, resp); got != oracle.GetAddress() {
+		t.Fatalf("Expected the signature to recover to %s, got %s", oracle.GetAddress(), got)
+	}
+	log.Printf("✅ Verified delegation signed under nonce %d", resp.Nonce)
+
+	resp, err = oracle.VerifyAndSignWithOptions(context.Background(), goldenValidator, goldenNominator, goldenMsg, VerifyOptions{BindEra: true})
+	if err != nil {
+		t.Fatalf("VerifyAndSignWithOptions failed: %v", err)
+	}
+	if resp.Era == nil || *resp.Era != 1523 || resp.Nonce != 2 {
+		t.Fatalf("Expected era 1523 under nonce 2, got era=%v nonce=%d", resp.Era, resp.Nonce)
+	}
+	if got := responseSigner(t, oracle, resp); got != oracle.GetAddress() {
+		t.Fatalf("Expected the era-bound signature to recover to %s, got %s", oracle.GetAddress(), got)
+	}
+	log.Printf("✅ Signature bound to era %d", *resp.Era)
+
+	// A given era is committed to without looking up the active one
+	sharedEra := uint32(1500)
+	resp, err = oracle.VerifyAndSignWithOptions(context.Background(), goldenValidator, goldenNominator, goldenMsg, VerifyOptions{Era: &sharedEra})
+	if err != nil {
+		t.Fatalf("VerifyAndSignWithOptions failed: %v", err)
+	}
+	if resp.Era == nil || *resp.Era != sharedEra || resp.Nonce != 3 {
+		t.Fatalf("Expected era %d under nonce 3, got era=%v nonce=%d", sharedEra, resp.Era, resp.Nonce)
+	}
+	if got := responseSigner(t, oracle, resp); got != oracle.GetAddress() {
+		t.Fatalf("Expected the signature bound to the given era to recover to %s, got %s", oracle.GetAddress(), got)
+	}
+	log.Printf("✅ Signature bound to the given era %d", *resp.Era)
+
+	resp, err = oracle.VerifyAndSignWithOptions(context.Background(), goldenValidator, goldenNominator, goldenMsg, VerifyOptions{BindEra: true, DryRun: true})
+	if err != nil {
+		t.Fatalf("Dry run failed: %v", err)
+	}
+	if !resp.DryRun || resp.Signature != nil || resp.Nonce != 0 || resp.Era == nil || oracle.LastNonce(goldenNominator) != 3 {
+		t.Fatalf("Expected a dry run to sign nothing and issue no nonce, got %+v (last nonce %d)", resp, oracle.LastNonce(goldenNominator))
+	}
+	log.Printf("✅ Dry run verified without signing")
+}
+
+func TestVerifyAndSign_ReportsFailures(t *testing.T) {
+	log.Printf("🧪 Starting TestVerifyAndSign_ReportsFailures")
+
+	rpcErr := &delegation.UpstreamError{Method: "state_getStorage", Err: errors.New("RPC endpoint returned HTTP 503")}
+	cases := []struct {
+		name    string
+		checker *stubChecker
+		opts    VerifyOptions
+		want    error
+		// verified reports whether the error carries the verification sub-checks
+		verified bool
+	}{
+		{"not delegated", &stubChecker{delegated: false}, VerifyOptions{}, ErrDelegationNotFound, true},
+		{"bond below threshold", &stubChecker{delegated: true, bonded: big.NewInt(1), minBonded: big.NewInt(2)}, VerifyOptions{}, ErrBondBelowThreshold, true},
+		{"verifier error", &stubChecker{err: rpcErr}, VerifyOptions{}, delegation.ErrRPCUnavailable, false},
+		{"era lookup error", &stubChecker{delegated: true, eraErr: rpcErr}, VerifyOptions{BindEra: true}, ErrActiveEraUnavailable, false},
+	}
+
+	for _, tc := range cases {
+		oracle := newStubbedOracle(t, tc.checker)
+		resp, err := oracle.VerifyAndSignWithOptions(context.Background(), goldenValidator, goldenNominator, goldenMsg, tc.opts)
+		if !errors.Is(err, tc.want) || resp != nil {
+			t.Fatalf("%s: expected %v, got %+v: %v", tc.name, tc.want, resp, err)
+		}
+		var verificationErr *VerificationError
+		if errors.As(err, &verificationErr) != tc.verified {
+			t.Fatalf("%s: expected a VerificationError: %v, got %v", tc.name, tc.verified, err)
+		}
+		if tc.verified && verificationErr.Verification == nil {
+			t.Fatalf("%s: expected the sub-checks alongside the error", tc.name)
+		}
+		if oracle.LastNonce(goldenNominator) != 0 {
+			t.Fatalf("%s: expected no nonce to be issued", tc.name)
+		}
+		log.Printf("✅ %s reported: %v", tc.name, err)
+	}
+
+	// The bonded amount is reported with the sub-checks
+	oracle := newStubbedOracle(t, &stubChecker{delegated: true, bonded: big.NewInt(1), minBonded: big.NewInt(2)})
+	_, err := oracle.VerifyAndSign(context.Background(), goldenValidator, goldenNominator, goldenMsg)
+	var verificationErr *VerificationError
+	if !errors.As(err, &verificationErr) || verificationErr.Verification.BondedAmount != "1" || verificationErr.Verification.BondedThresholdValidation {
+		t.Fatalf("Expected the bonded amount in the sub-checks, got %v", err)
+	}
+	log.Printf("✅ Bonded amount %s reported", verificationErr.Verification.BondedAmount)
+
+	oracle.SetDelegationChecker(nil)
+	if oracle.checker != DelegationChecker(oracle.verifier) {
+		t.Fatalf("Expected a nil checker to restore the delegation verifier")
+	}
+	log.Printf("✅ Nil checker restores the delegation verifier")
+}
@@ -12,6 +12,11 @@ contract OracleVerifiedDelegation {
 
     address public immutable oracleAddress;
 
+    // Last nonce consumed per nominator; the oracle issues each nominator increasing nonces, so a
+    // signature can only be submitted once and never after a later one. The oracle must persist
+    // its nonces (NONCE_STORE_FILE): in-memory nonces restart at 1 and would be rejected here.
+    mapping(bytes32 => uint64) public lastNonce;
+
     event MessageStored(string validator, string nominator, string msgText);
 
     constructor() {
@@ -20,10 +25,15 @@ contract OracleVerifiedDelegation {
         oracleAddress = address(0x6c6Fa8CEeF6AbB97dCd75a6e390386E4B49A5e09);
     }
 
+    /// @notice Stores a message signed by the oracle's /verify endpoint, which commits to the
+    /// nominator's nonce, a unix deadline and the chain ID. The oracle must run with CHAIN_ID set
+    /// to the chain this contract is deployed on.
     function submitMessage(
         string memory validator_address,
         string memory nominator_address,
         string memory msgText,
+        uint64 nonce,
+        uint64 deadline,
         bytes memory signature
     ) public {
         // Step 1: Check that msg.sender is the nominator
@@ -33,26 +43,53 @@ contract OracleVerifiedDelegation {
         //     "msg.sender does not match nominator_address"
         // );
 
-        // Step 2: Rebuild message hash
+        // Step 2: Reject expired signatures and rebuild the message hash. abi.encode
+        // length-prefixes each string, so ("ab", "c") and ("a", "bc") can't produce the same
+        // hash as they would packed. The chain ID keeps a signature from being replayed on a
+        // deployment on another chain.
+        require(block.timestamp <= deadline, "Signature expired");
         bytes32 messageHash = keccak256(
-            abi.encodePacked(validator_address, nominator_address, msgText)
+            abi.encodePacked(
+                abi.encode(validator_address, nominator_address, msgText),
+                nonce,
+                deadline,
+                uint64(block.chainid)
+            )
         );
-        bytes32 ethSignedMessageHash = toEthSignedMessageHash(messageHash);
 
-        // Step 3: Recover signer from signature
-        address recovered = recoverSigner(ethSignedMessageHash, signature);
-        require(recovered == oracleAddress, "Signature not from oracle");
+        // Step 3: Check the oracle signed it, and consume the nonce
+        requireOracleSignature(messageHash, signature);
+        consumeNonce(nominator_address, nonce);
 
         // Step 4: Persist the message
-        messages.push(
-            Message({
-                validator_address: validator_address,
-                nominator_address: nominator_address,
-                msgText: msgText
-            })
+        storeMessage(validator_address, nominator_address, msgText);
+    }
+
+    /// @notice Stores a message signed by /verify?bind_era=true, which also commits to the era
+    /// the delegation was verified in
+    function submitMessageForEra(
+        string memory validator_address,
+        string memory nominator_address,
+        string memory msgText,
+        uint32 era,
+        uint64 nonce,
+        uint64 deadline,
+        bytes memory signature
+    ) public {
+        require(block.timestamp <= deadline, "Signature expired");
+        bytes32 messageHash = keccak256(
+            abi.encodePacked(
+                abi.encode(validator_address, nominator_address, msgText),
+                era,
+                nonce,
+                deadline,
+                uint64(block.chainid)
+            )
         );
 
-        emit MessageStored(validator_address, nominator_address, msgText);
+        requireOracleSignature(messageHash, signature);
+        consumeNonce(nominator_address, nonce);
+        storeMessage(validator_address, nominator_address, msgText);
     }
 
     /// @notice Stores a message without any sender or signature validation
@@ -61,6 +98,28 @@ contract OracleVerifiedDelegation {
         string memory nominator_address,
         string memory msgText
     ) public {
+        storeMessage(validator_address, nominator_address, msgText);
+    }
+
+    // Helpers
+
+    function requireOracleSignature(bytes32 messageHash, bytes memory signature) internal view {
+        bytes32 ethSignedMessageHash = toEthSignedMessageHash(messageHash);
+        address recovered = recoverSigner(ethSignedMessageHash, signature);
+        require(recovered == oracleAddress, "Signature not from oracle");
+    }
+
+    function consumeNonce(string memory nominator_address, uint64 nonce) internal {
+        bytes32 nominatorKey = keccak256(bytes(nominator_address));
+        require(nonce > lastNonce[nominatorKey], "Nonce already used");
+        lastNonce[nominatorKey] = nonce;
+    }
+
+    function storeMessage(
+        string memory validator_address,
+        string memory nominator_address,
+        string memory msgText
+    ) internal {
         messages.push(
             Message({
                 validator_address: validator_address,
@@ -72,8 +131,6 @@ contract OracleVerifiedDelegation {
         emit MessageStored(validator_address, nominator_address, msgText);
     }
 
-    // Helpers
-
     function toEthSignedMessageHash(bytes32 hash) internal pure returns (bytes32) {
         return keccak256(
             abi.encodePacked("\x19Ethereum Signed Message:\n32", hash)